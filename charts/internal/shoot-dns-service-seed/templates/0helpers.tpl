@@ -21,7 +21,7 @@ apiVersion: config.dns.gardener.cloud/v1alpha1
 kind: DNSManagerConfiguration
 class: {{ .Values.nextGeneration.dnsClass }}
 logFormat: json
-logLevel: info
+logLevel: {{ .Values.logLevel }}
 clientConnection:
   kubeconfig: /var/run/secrets/gardener.cloud/shoot/generic-kubeconfig/kubeconfig
 controlPlaneClientConnection:
@@ -28,7 +28,9 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 
 	admissioncmd "github.com/gardener/gardener-extension-shoot-dns-service/pkg/admission/cmd"
+	"github.com/gardener/gardener-extension-shoot-dns-service/pkg/admission/mutator"
 	"github.com/gardener/gardener-extension-shoot-dns-service/pkg/admission/validator"
+	policyv1alpha1 "github.com/gardener/gardener-extension-shoot-dns-service/pkg/apis/policy/v1alpha1"
 	serviceinstall "github.com/gardener/gardener-extension-shoot-dns-service/pkg/apis/service/install"
 )
 
@@ -99,6 +101,10 @@ func NewAdmissionCommand(ctx context.Context) *cobra.Command {
 
 			if admissionConfig := admissionOpts.Completed(); admissionConfig != nil {
 				validator.DefaultAddOptions.GCPWorkloadIdentityConfig = *admissionConfig
+				validator.DefaultAddOptions.ProviderTypeRestrictions = admissionOpts.CompletedProviderTypeRestrictions()
+				validator.DefaultAddOptions.QuotaVerifier = admissionOpts.QuotaOptions.Verifier()
+				validator.DefaultAddOptions.QuotaConfig = admissionOpts.QuotaOptions.QuotaConfig()
+				mutator.DefaultAddOptions.QuotaVerifier = admissionOpts.QuotaOptions.Verifier()
 			} else {
 				return fmt.Errorf("could not complete admission options")
 			}
@@ -126,6 +132,10 @@ func NewAdmissionCommand(ctx context.Context) *cobra.Command {
 				runtimelog.Log.Error(err, "Could not update manager scheme with security.gardener.cloud/v1alpha1 API version")
 				os.Exit(1)
 			}
+			if err := policyv1alpha1.AddToScheme(mgr.GetScheme()); err != nil {
+				runtimelog.Log.Error(err, "Could not update manager scheme with policy.dns.extensions.gardener.cloud/v1alpha1 API version")
+				os.Exit(1)
+			}
 
 			sourceCluster, err := cluster.New(inClusterConfig, func(opts *cluster.Options) {
 				opts.Logger = log
@@ -11,21 +11,21 @@ import (
 	dnsapi "github.com/gardener/external-dns-management/pkg/apis/dns/v1alpha1"
 	extensionscontroller "github.com/gardener/gardener/extensions/pkg/controller"
 	"github.com/gardener/gardener/extensions/pkg/controller/heartbeat"
-	"github.com/gardener/gardener/extensions/pkg/util"
 	"github.com/spf13/cobra"
 	corev1 "k8s.io/api/core/v1"
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/kubernetes/scheme"
-	componentbaseconfigv1alpha1 "k8s.io/component-base/config/v1alpha1"
 	"k8s.io/component-base/version/verflag"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 
+	policyv1alpha1 "github.com/gardener/gardener-extension-shoot-dns-service/pkg/apis/policy/v1alpha1"
 	serviceinstall "github.com/gardener/gardener-extension-shoot-dns-service/pkg/apis/service/install"
 	"github.com/gardener/gardener-extension-shoot-dns-service/pkg/controller/config"
 	"github.com/gardener/gardener-extension-shoot-dns-service/pkg/controller/healthcheck"
 	"github.com/gardener/gardener-extension-shoot-dns-service/pkg/controller/lifecycle"
+	"github.com/gardener/gardener-extension-shoot-dns-service/pkg/controller/metrics"
 	"github.com/gardener/gardener-extension-shoot-dns-service/pkg/service"
 )
 
@@ -58,11 +58,8 @@ func NewServiceControllerCommand() *cobra.Command {
 }
 
 func (o *Options) run(ctx context.Context) error {
-	// TODO: Make these flags configurable via command line parameters or component config file.
-	util.ApplyClientConnectionConfigurationToRESTConfig(&componentbaseconfigv1alpha1.ClientConnectionConfiguration{
-		QPS:   100.0,
-		Burst: 130,
-	}, o.restOptions.Completed().Config)
+	controllerConfig := o.configOptions.Completed()
+	controllerConfig.ApplyClientConnection(o.restOptions.Completed().Config)
 
 	mgrScheme := runtime.NewScheme()
 	if err := scheme.AddToScheme(mgrScheme); err != nil {
@@ -74,6 +71,9 @@ func (o *Options) run(ctx context.Context) error {
 	if err := serviceinstall.AddToScheme(mgrScheme); err != nil {
 		return fmt.Errorf("could not update manager scheme: %s", err)
 	}
+	if err := policyv1alpha1.AddToScheme(mgrScheme); err != nil {
+		return fmt.Errorf("could not update manager scheme (policy): %s", err)
+	}
 	if err := extensionscontroller.AddToScheme(mgrScheme); err != nil {
 		return fmt.Errorf("could not update manager scheme: %s", err)
 	}
@@ -98,10 +98,24 @@ func (o *Options) run(ctx context.Context) error {
 
 	o.serviceOptions.Completed().Apply(&config.DNSService)
 	o.healthOptions.Completed().ApplyHealthCheckConfig(&healthcheck.DefaultAddOptions.HealthCheckConfig)
+	o.healthOptions.Completed().ApplyDNSEntriesCheckConfig(&healthcheck.DefaultDNSEntriesCheckConfig)
 	o.healthControllerOptions.Completed().Apply(&healthcheck.DefaultAddOptions.Controller)
 	o.lifecycleControllerOptions.Completed().Apply(&lifecycle.DefaultAddOptions.Controller)
 	o.reconcileOptions.Completed().Apply(&lifecycle.DefaultAddOptions.IgnoreOperationAnnotation)
+	o.lifecycleOptions.Completed().Apply(&lifecycle.DefaultAddOptions)
 	o.heartbeatControllerOptions.Completed().Apply(&heartbeat.DefaultAddOptions)
+	// settings of the configuration file take precedence over the command line flags
+	flagConfig := config.DNSService
+	controllerConfig.Apply(&config.DNSService)
+	controllerConfig.ApplyHealthCheckConfig(&healthcheck.DefaultAddOptions.HealthCheckConfig)
+	controllerConfig.ApplyDNSEntriesCheckConfig(&healthcheck.DefaultDNSEntriesCheckConfig)
+	if reloader := controllerConfig.NewReloader(mgr.GetLogger(), flagConfig); reloader != nil {
+		if err := mgr.Add(reloader); err != nil {
+			return fmt.Errorf("could not add controller configuration reloader: %s", err)
+		}
+	}
+
+	metrics.RegisterAll()
 
 	if err := o.controllerSwitches.Completed().AddToManager(ctx, mgr); err != nil {
 		return fmt.Errorf("could not add controllers to manager: %s", err)
@@ -22,6 +22,8 @@ type Options struct {
 	generalOptions               *controllercmd.GeneralOptions
 	serviceOptions               *dnsservicecmd.DNSServiceOptions
 	healthOptions                *dnsservicecmd.HealthOptions
+	lifecycleOptions             *dnsservicecmd.LifecycleOptions
+	configOptions                *dnsservicecmd.ConfigOptions
 	restOptions                  *controllercmd.RESTOptions
 	managerOptions               *controllercmd.ManagerOptions
 	lifecycleControllerOptions   *controllercmd.ControllerOptions
@@ -36,10 +38,12 @@ type Options struct {
 // NewOptions creates a new Options instance.
 func NewOptions() *Options {
 	options := &Options{
-		generalOptions: &controllercmd.GeneralOptions{},
-		serviceOptions: &dnsservicecmd.DNSServiceOptions{},
-		healthOptions:  &dnsservicecmd.HealthOptions{},
-		restOptions:    &controllercmd.RESTOptions{},
+		generalOptions:   &controllercmd.GeneralOptions{},
+		serviceOptions:   &dnsservicecmd.DNSServiceOptions{},
+		healthOptions:    &dnsservicecmd.HealthOptions{},
+		lifecycleOptions: &dnsservicecmd.LifecycleOptions{},
+		configOptions:    &dnsservicecmd.ConfigOptions{},
+		restOptions:      &controllercmd.RESTOptions{},
 		managerOptions: &controllercmd.ManagerOptions{
 			// These are default values.
 			LeaderElection:          true,
@@ -72,6 +76,8 @@ func NewOptions() *Options {
 		options.generalOptions,
 		options.serviceOptions,
 		options.healthOptions,
+		options.lifecycleOptions,
+		options.configOptions,
 		options.restOptions,
 		options.managerOptions,
 		controllercmd.PrefixOption("lifecycle-", options.lifecycleControllerOptions),
@@ -0,0 +1,79 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/client-go/tools/clientcmd"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var scheme = runtime.NewScheme()
+
+func init() {
+	utilruntime.Must(corev1.AddToScheme(scheme))
+}
+
+// options contains the options common to all subcommands.
+type options struct {
+	kubeconfig string
+	namespace  string
+	shootID    string
+
+	out    io.Writer
+	errOut io.Writer
+}
+
+// NewLeakedRecordsCommand creates a new command for listing and deleting DNS records leaked by the forced deletion
+// of shoots.
+func NewLeakedRecordsCommand() *cobra.Command {
+	opts := &options{
+		out:    os.Stdout,
+		errOut: os.Stderr,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "shoot-dns-service-leaked-records",
+		Short: "List and delete DNS records leaked by the forced deletion of shoots",
+		Long: `List and delete DNS records, which may have been leaked in the infrastructure by the forced deletion
+of shoots. On forced deletion, the shoot-dns-service extension records the DNS records of all DNS entries whose
+finalizers are removed in an inventory stored in config maps in the namespace of the extension.`,
+		SilenceUsage: true,
+	}
+	cmd.PersistentFlags().StringVar(&opts.kubeconfig, "kubeconfig", "", "path to the kubeconfig of the seed (defaults to the KUBECONFIG environment variable)")
+	cmd.PersistentFlags().StringVarP(&opts.namespace, "namespace", "n", "", "namespace of the extension in the seed containing the inventory (defaults to the namespace of the kubeconfig context)")
+	cmd.PersistentFlags().StringVar(&opts.shootID, "shoot-id", "", "only consider the records of the shoot with the given cluster identity")
+
+	cmd.AddCommand(
+		newListCommand(opts),
+		newDeleteCommand(opts),
+	)
+	return cmd
+}
+
+// client creates a client for the cluster given by the kubeconfig and determines the namespace.
+func (o *options) client() (client.Client, error) {
+	loadingRules := clientcmd.NewDefaultClientConfigLoadingRules()
+	loadingRules.ExplicitPath = o.kubeconfig
+	clientConfig := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(loadingRules, &clientcmd.ConfigOverrides{})
+	restConfig, err := clientConfig.ClientConfig()
+	if err != nil {
+		return nil, fmt.Errorf("could not load kubeconfig: %w", err)
+	}
+	if o.namespace == "" {
+		o.namespace, _, err = clientConfig.Namespace()
+		if err != nil {
+			return nil, fmt.Errorf("could not determine namespace: %w", err)
+		}
+	}
+	return client.New(restConfig, client.Options{Scheme: scheme})
+}
@@ -0,0 +1,211 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/gardener/external-dns-management/pkg/dnsman2/apis/config"
+	"github.com/gardener/external-dns-management/pkg/dnsman2/dns/provider"
+	"github.com/gardener/external-dns-management/pkg/dnsman2/dns/provider/handler"
+	"github.com/gardener/external-dns-management/pkg/dnsman2/dns/utils"
+	"github.com/go-logr/logr"
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/util/flowcontrol"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logzap "sigs.k8s.io/controller-runtime/pkg/log/zap"
+	"sigs.k8s.io/yaml"
+
+	"github.com/gardener/gardener-extension-shoot-dns-service/pkg/controller/common"
+)
+
+func newListCommand(opts *options) *cobra.Command {
+	return &cobra.Command{
+		Use:   "list",
+		Short: "List the DNS records of the inventory",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			c, err := opts.client()
+			if err != nil {
+				return err
+			}
+			inventories, err := opts.listInventories(cmd.Context(), c)
+			if err != nil {
+				return err
+			}
+
+			w := tabwriter.NewWriter(opts.out, 0, 4, 2, ' ', 0)
+			fmt.Fprintln(w, "SHOOTNAMESPACE\tDNSNAME\tTYPE\tTARGETS\tPROVIDERTYPE\tPROVIDER\tZONE")
+			for _, inventory := range inventories {
+				for _, record := range inventory.Records {
+					fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n", inventory.ShootNamespace, record.DNSName, record.RecordType,
+						strings.Join(record.Targets, ","), record.ProviderType, record.Provider, record.Zone)
+				}
+			}
+			return w.Flush()
+		},
+	}
+}
+
+func newDeleteCommand(opts *options) *cobra.Command {
+	var (
+		secretName         string
+		providerType       string
+		providerConfigFile string
+		dryRun             bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "delete",
+		Short: "Delete the DNS records of the inventory in the infrastructure",
+		Long: `Delete the DNS records of the inventory of the given provider type in the infrastructure using the credentials
+of the given secret. Only records in hosted zones reachable with these credentials are deleted. Successfully
+deleted records are removed from the inventory.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ctx := cmd.Context()
+			c, err := opts.client()
+			if err != nil {
+				return err
+			}
+			inventories, err := opts.listInventories(ctx, c)
+			if err != nil {
+				return err
+			}
+
+			secretKey, err := parseNamespacedName(secretName, opts.namespace)
+			if err != nil {
+				return err
+			}
+			secret := &corev1.Secret{}
+			if err := c.Get(ctx, secretKey, secret); err != nil {
+				return fmt.Errorf("could not read secret %s: %w", secretKey, err)
+			}
+			var providerConfig *runtime.RawExtension
+			if providerConfigFile != "" {
+				data, err := os.ReadFile(providerConfigFile)
+				if err != nil {
+					return err
+				}
+				raw, err := yaml.YAMLToJSON(data)
+				if err != nil {
+					return fmt.Errorf("invalid provider config: %w", err)
+				}
+				providerConfig = &runtime.RawExtension{Raw: raw}
+			}
+
+			log := logzap.New(logzap.WriteTo(opts.errOut))
+			ctx = logr.NewContext(ctx, log)
+			dnsHandler, err := handler.CreateStandardDNSHandlerFactory(config.DNSProviderControllerConfig{}).Create(providerType, &provider.DNSHandlerConfig{
+				Log:         log,
+				Properties:  utils.NewPropertiesFromSecretData(secret.Data),
+				Config:      providerConfig,
+				Metrics:     common.NoMetrics{},
+				RateLimiter: flowcontrol.NewFakeAlwaysRateLimiter(),
+			})
+			if err != nil {
+				return fmt.Errorf("could not create DNS handler for provider type %q: %w", providerType, err)
+			}
+			defer dnsHandler.Release()
+			return opts.deleteRecords(ctx, c, dnsHandler, inventories, providerType, dryRun)
+		},
+	}
+	cmd.Flags().StringVar(&secretName, "secret", "", "secret with the provider credentials ('<namespace>/<name>' or '<name>' in the namespace of the inventory)")
+	cmd.Flags().StringVar(&providerType, "provider-type", "", "provider type of the credentials, only records of this provider type are deleted")
+	cmd.Flags().StringVar(&providerConfigFile, "provider-config", "", "file containing the optional provider config")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "only print the records which would be deleted")
+	_ = cmd.MarkFlagRequired("secret")
+	_ = cmd.MarkFlagRequired("provider-type")
+	return cmd
+}
+
+// deleteRecords deletes the DNS records of the inventories of the given provider type in the hosted zones of the DNS
+// handler and removes the deleted records from the inventories.
+func (o *options) deleteRecords(ctx context.Context, c client.Client, dnsHandler provider.DNSHandler, inventories []common.LeakedRecordsInventory, providerType string, dryRun bool) error {
+	zones, err := dnsHandler.GetZones(ctx)
+	if err != nil {
+		return fmt.Errorf("could not get hosted zones: %w", err)
+	}
+
+	failed := 0
+	for _, inventory := range inventories {
+		var remaining []common.LeakedDNSRecord
+		for _, record := range inventory.Records {
+			zone := findZone(zones, record)
+			if record.ProviderType != providerType || zone == nil {
+				remaining = append(remaining, record)
+				continue
+			}
+			if dryRun {
+				fmt.Fprintf(o.out, "would delete %s %s in zone %s\n", record.RecordType, record.DNSName, record.Zone)
+				remaining = append(remaining, record)
+				continue
+			}
+			if err := common.DeleteLeakedRecord(ctx, dnsHandler, zone, record); err != nil {
+				fmt.Fprintf(o.errOut, "failed to delete %s %s in zone %s: %s\n", record.RecordType, record.DNSName, record.Zone, err)
+				remaining = append(remaining, record)
+				failed++
+				continue
+			}
+			fmt.Fprintf(o.out, "deleted %s %s in zone %s\n", record.RecordType, record.DNSName, record.Zone)
+		}
+		if len(remaining) == len(inventory.Records) {
+			continue
+		}
+		inventory.Records = remaining
+		if err := common.UpdateLeakedRecords(ctx, c, &inventory); err != nil {
+			return fmt.Errorf("could not update inventory %s/%s: %w", inventory.Namespace, inventory.Name, err)
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("deletion of %d DNS records failed", failed)
+	}
+	return nil
+}
+
+// listInventories lists the inventories in the namespace, optionally restricted to the given shoot ID.
+func (o *options) listInventories(ctx context.Context, c client.Reader) ([]common.LeakedRecordsInventory, error) {
+	inventories, err := common.ListLeakedRecords(ctx, c, o.namespace)
+	if err != nil {
+		return nil, err
+	}
+	if o.shootID == "" {
+		return inventories, nil
+	}
+	var result []common.LeakedRecordsInventory
+	for _, inventory := range inventories {
+		if inventory.ShootID == o.shootID {
+			result = append(result, inventory)
+		}
+	}
+	return result, nil
+}
+
+func findZone(zones []provider.DNSHostedZone, record common.LeakedDNSRecord) provider.DNSHostedZone {
+	for _, zone := range zones {
+		if zone.ZoneID().ID == record.Zone {
+			return zone
+		}
+	}
+	return nil
+}
+
+func parseNamespacedName(name, defaultNamespace string) (client.ObjectKey, error) {
+	parts := strings.Split(name, "/")
+	switch {
+	case len(parts) == 1 && parts[0] != "":
+		return client.ObjectKey{Namespace: defaultNamespace, Name: parts[0]}, nil
+	case len(parts) == 2 && parts[0] != "" && parts[1] != "":
+		return client.ObjectKey{Namespace: parts[0], Name: parts[1]}, nil
+	default:
+		return client.ObjectKey{}, fmt.Errorf("invalid secret %q (expected '<namespace>/<name>' or '<name>')", name)
+	}
+}
@@ -0,0 +1,211 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	"github.com/gardener/external-dns-management/pkg/dnsman2/dns"
+	"github.com/gardener/external-dns-management/pkg/dnsman2/dns/provider"
+	"github.com/gardener/external-dns-management/pkg/dnsman2/dns/utils"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/gardener/gardener-extension-shoot-dns-service/pkg/controller/common"
+)
+
+// mockDNSHandler records the deleted record sets and fails for the configured DNS names.
+type mockDNSHandler struct {
+	zones   []provider.DNSHostedZone
+	deleted []string
+	failing sets.Set[string]
+}
+
+var _ provider.DNSHandler = &mockDNSHandler{}
+
+func (m *mockDNSHandler) ProviderType() string {
+	return "mock"
+}
+
+func (m *mockDNSHandler) GetZones(_ context.Context) ([]provider.DNSHostedZone, error) {
+	return m.zones, nil
+}
+
+func (m *mockDNSHandler) GetCustomQueryDNSFunc(_ dns.ZoneInfo, _ utils.QueryDNSFactoryFunc) (provider.CustomQueryDNSFunc, error) {
+	return nil, nil
+}
+
+func (m *mockDNSHandler) ExecuteRequests(_ context.Context, zone provider.DNSHostedZone, requests provider.ChangeRequests) error {
+	if m.failing.Has(requests.Name.DNSName) {
+		return fmt.Errorf("simulated failure")
+	}
+	for recordType, update := range requests.Updates {
+		if update.Old == nil || update.New != nil {
+			return fmt.Errorf("unexpected update of %s %s", recordType, requests.Name.DNSName)
+		}
+		m.deleted = append(m.deleted, fmt.Sprintf("%s %s %s", zone.ZoneID().ID, recordType, requests.Name.DNSName))
+	}
+	return nil
+}
+
+func (m *mockDNSHandler) Release() {}
+
+var _ = Describe("Commands", func() {
+	Describe("#parseNamespacedName", func() {
+		DescribeTable("should parse valid names",
+			func(name string, expected client.ObjectKey) {
+				key, err := parseNamespacedName(name, "default")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(key).To(Equal(expected))
+			},
+			Entry("name only", "secret", client.ObjectKey{Namespace: "default", Name: "secret"}),
+			Entry("namespace and name", "garden/secret", client.ObjectKey{Namespace: "garden", Name: "secret"}),
+		)
+
+		DescribeTable("should reject invalid names",
+			func(name string) {
+				_, err := parseNamespacedName(name, "default")
+				Expect(err).To(MatchError(fmt.Sprintf("invalid secret %q (expected '<namespace>/<name>' or '<name>')", name)))
+			},
+			Entry("empty", ""),
+			Entry("missing name", "ns/"),
+			Entry("missing namespace", "/name"),
+			Entry("too many parts", "a/b/c"),
+		)
+	})
+
+	Describe("#findZone", func() {
+		var zones = []provider.DNSHostedZone{
+			provider.NewDNSHostedZone("mock", "zone-a", "a.example.com", "", false),
+			provider.NewDNSHostedZone("mock", "zone-b", "b.example.com", "", false),
+		}
+
+		It("should find the zone of the record", func() {
+			Expect(findZone(zones, common.LeakedDNSRecord{Zone: "zone-b"})).To(Equal(zones[1]))
+		})
+
+		It("should return nil for an unknown zone", func() {
+			Expect(findZone(zones, common.LeakedDNSRecord{Zone: "zone-c"})).To(BeNil())
+			Expect(findZone(nil, common.LeakedDNSRecord{Zone: "zone-a"})).To(BeNil())
+		})
+	})
+
+	Describe("#deleteRecords", func() {
+		const (
+			namespace = "extension-shoot-dns-service"
+			shootID   = "shoot--foo--bar-78897def-5208-4feb-b0f0-015950eadbb9-test-landscape"
+		)
+
+		var (
+			ctx        context.Context
+			fakeClient client.Client
+			dnsHandler *mockDNSHandler
+			out        *bytes.Buffer
+			errOut     *bytes.Buffer
+			opts       *options
+
+			record = func(dnsName, providerType, zone string) common.LeakedDNSRecord {
+				return common.LeakedDNSRecord{
+					Entry:        dnsName,
+					DNSName:      dnsName,
+					RecordType:   "A",
+					Targets:      []string{"1.2.3.4"},
+					TTL:          300,
+					Provider:     "shoot--foo--bar/external",
+					ProviderType: providerType,
+					Zone:         zone,
+				}
+			}
+
+			listInventories = func() []common.LeakedRecordsInventory {
+				GinkgoHelper()
+				inventories, err := common.ListLeakedRecords(ctx, fakeClient, namespace)
+				Expect(err).NotTo(HaveOccurred())
+				return inventories
+			}
+		)
+
+		BeforeEach(func() {
+			ctx = context.Background()
+			fakeClient = fake.NewClientBuilder().WithScheme(scheme).Build()
+			dnsHandler = &mockDNSHandler{
+				zones:   []provider.DNSHostedZone{provider.NewDNSHostedZone("mock", "zone-a", "a.example.com", "", false)},
+				failing: sets.New[string](),
+			}
+			out, errOut = &bytes.Buffer{}, &bytes.Buffer{}
+			opts = &options{namespace: namespace, out: out, errOut: errOut}
+
+			Expect(common.AddLeakedRecords(ctx, fakeClient, namespace, shootID, "shoot--foo--bar", []common.LeakedDNSRecord{
+				record("one.a.example.com", "mock", "zone-a"),
+				record("two.a.example.com", "mock", "zone-a"),
+				record("other.b.example.com", "mock", "zone-b"),
+				record("other.a.example.com", "other", "zone-a"),
+			})).To(Succeed())
+		})
+
+		It("should delete the records of the provider type in reachable zones and update the inventory", func() {
+			Expect(opts.deleteRecords(ctx, fakeClient, dnsHandler, listInventories(), "mock", false)).To(Succeed())
+
+			Expect(dnsHandler.deleted).To(ConsistOf("zone-a A one.a.example.com", "zone-a A two.a.example.com"))
+			Expect(out.String()).To(ContainSubstring("deleted A one.a.example.com in zone zone-a"))
+			inventories := listInventories()
+			Expect(inventories).To(HaveLen(1))
+			Expect(inventories[0].Records).To(ConsistOf(
+				HaveField("DNSName", "other.b.example.com"),
+				HaveField("DNSName", "other.a.example.com"),
+			))
+		})
+
+		It("should delete the inventory if no records are left", func() {
+			dnsHandler.zones = append(dnsHandler.zones, provider.NewDNSHostedZone("mock", "zone-b", "b.example.com", "", false))
+			Expect(opts.deleteRecords(ctx, fakeClient, dnsHandler, listInventories(), "mock", false)).To(Succeed())
+			Expect(opts.deleteRecords(ctx, fakeClient, dnsHandler, listInventories(), "other", false)).To(Succeed())
+
+			Expect(dnsHandler.deleted).To(HaveLen(4))
+			Expect(listInventories()).To(BeEmpty())
+		})
+
+		It("should only print the records on dry run without updating the inventory", func() {
+			Expect(opts.deleteRecords(ctx, fakeClient, dnsHandler, listInventories(), "mock", true)).To(Succeed())
+
+			Expect(dnsHandler.deleted).To(BeEmpty())
+			Expect(out.String()).To(Equal("would delete A one.a.example.com in zone zone-a\nwould delete A two.a.example.com in zone zone-a\n"))
+			inventories := listInventories()
+			Expect(inventories).To(HaveLen(1))
+			Expect(inventories[0].Records).To(HaveLen(4))
+		})
+
+		It("should keep the records failed to delete in the inventory", func() {
+			dnsHandler.failing.Insert("two.a.example.com")
+
+			err := opts.deleteRecords(ctx, fakeClient, dnsHandler, listInventories(), "mock", false)
+			Expect(err).To(MatchError("deletion of 1 DNS records failed"))
+
+			Expect(dnsHandler.deleted).To(ConsistOf("zone-a A one.a.example.com"))
+			Expect(errOut.String()).To(ContainSubstring("failed to delete A two.a.example.com in zone zone-a: simulated failure"))
+			inventories := listInventories()
+			Expect(inventories).To(HaveLen(1))
+			Expect(inventories[0].Records).To(ConsistOf(
+				HaveField("DNSName", "two.a.example.com"),
+				HaveField("DNSName", "other.b.example.com"),
+				HaveField("DNSName", "other.a.example.com"),
+			))
+		})
+
+		It("should not update the inventory if all deletions failed", func() {
+			dnsHandler.failing.Insert("one.a.example.com", "two.a.example.com")
+			inventories := listInventories()
+
+			err := opts.deleteRecords(ctx, fakeClient, dnsHandler, inventories, "mock", false)
+			Expect(err).To(MatchError("deletion of 2 DNS records failed"))
+			Expect(listInventories()).To(Equal(inventories))
+		})
+	})
+})
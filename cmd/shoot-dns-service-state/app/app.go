@@ -0,0 +1,174 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	dnsv1alpha1 "github.com/gardener/external-dns-management/pkg/apis/dns/v1alpha1"
+	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/client-go/tools/clientcmd"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+
+	"github.com/gardener/gardener-extension-shoot-dns-service/pkg/controller/common"
+)
+
+var scheme = runtime.NewScheme()
+
+func init() {
+	utilruntime.Must(corev1.AddToScheme(scheme))
+	utilruntime.Must(extensionsv1alpha1.AddToScheme(scheme))
+	utilruntime.Must(dnsv1alpha1.AddToScheme(scheme))
+}
+
+// options contains the options common to all subcommands.
+type options struct {
+	kubeconfig string
+	namespace  string
+	extension  string
+	file       string
+
+	out    io.Writer
+	errOut io.Writer
+	in     io.Reader
+}
+
+// NewStateCommand creates a new command for inspecting and editing the state of the shoot-dns-service extension.
+func NewStateCommand() *cobra.Command {
+	opts := &options{
+		out:    os.Stdout,
+		errOut: os.Stderr,
+		in:     os.Stdin,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "shoot-dns-service-state",
+		Short: "Inspect, diff and edit the state of the shoot-dns-service extension",
+		Long: `Inspect, diff and edit the DNS state stored in the status of the shoot-dns-service Extension resource.
+The state is read either from a file containing the Extension resource or the raw state (status.state),
+or from the cluster given by the kubeconfig.`,
+		SilenceUsage: true,
+	}
+	cmd.PersistentFlags().StringVar(&opts.kubeconfig, "kubeconfig", "", "path to the kubeconfig of the seed (defaults to the KUBECONFIG environment variable)")
+	cmd.PersistentFlags().StringVarP(&opts.namespace, "namespace", "n", "", "shoot namespace in the seed (defaults to the namespace of the kubeconfig context)")
+	cmd.PersistentFlags().StringVar(&opts.extension, "extension", "", "name of the Extension resource (defaults to the extension of type shoot-dns-service in the namespace)")
+	cmd.PersistentFlags().StringVarP(&opts.file, "file", "f", "", "file to read the Extension resource or the raw state from ('-' for stdin)")
+
+	cmd.AddCommand(
+		newDecodeCommand(opts),
+		newListCommand(opts),
+		newDiffCommand(opts),
+		newEncodeCommand(opts),
+	)
+	return cmd
+}
+
+// client creates a client for the cluster given by the kubeconfig and determines the namespace.
+func (o *options) client() (client.Client, error) {
+	loadingRules := clientcmd.NewDefaultClientConfigLoadingRules()
+	loadingRules.ExplicitPath = o.kubeconfig
+	clientConfig := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(loadingRules, &clientcmd.ConfigOverrides{})
+	restConfig, err := clientConfig.ClientConfig()
+	if err != nil {
+		return nil, fmt.Errorf("could not load kubeconfig: %w", err)
+	}
+	if o.namespace == "" {
+		o.namespace, _, err = clientConfig.Namespace()
+		if err != nil {
+			return nil, fmt.Errorf("could not determine namespace: %w", err)
+		}
+	}
+	return client.New(restConfig, client.Options{Scheme: scheme})
+}
+
+// readFile reads the file given by the file option.
+func (o *options) readFile() ([]byte, error) {
+	if o.file == "-" {
+		return io.ReadAll(o.in)
+	}
+	return os.ReadFile(o.file)
+}
+
+// loadExtension loads the Extension resource from the file or the cluster. The returned client is nil if the
+// extension has been read from a file and no kubeconfig is given, which is only allowed for states without chunks.
+func (o *options) loadExtension(ctx context.Context) (*extensionsv1alpha1.Extension, client.Client, error) {
+	if o.file == "" {
+		c, err := o.client()
+		if err != nil {
+			return nil, nil, err
+		}
+		ext, err := o.getExtension(ctx, c)
+		return ext, c, err
+	}
+
+	data, err := o.readFile()
+	if err != nil {
+		return nil, nil, err
+	}
+	ext, err := parseExtension(data)
+	if err != nil {
+		return nil, nil, err
+	}
+	// a kubeconfig is only needed to read the chunks of a chunked state
+	var c client.Client
+	if o.kubeconfig != "" || os.Getenv(clientcmd.RecommendedConfigPathEnvVar) != "" {
+		if c, err = o.client(); err != nil {
+			return nil, nil, err
+		}
+		if ext.Namespace == "" {
+			ext.Namespace = o.namespace
+		}
+	}
+	if c == nil && ext.Status.State != nil && common.DetectStateFormat(ext.Status.State.Raw) == common.StateFormatChunked {
+		return nil, nil, fmt.Errorf("kubeconfig required to reassemble chunked state")
+	}
+	return ext, c, nil
+}
+
+func (o *options) getExtension(ctx context.Context, c client.Client) (*extensionsv1alpha1.Extension, error) {
+	if o.extension != "" {
+		ext := &extensionsv1alpha1.Extension{}
+		if err := c.Get(ctx, client.ObjectKey{Namespace: o.namespace, Name: o.extension}, ext); err != nil {
+			return nil, err
+		}
+		return ext, nil
+	}
+	ext, err := common.FindExtension(ctx, c, o.namespace)
+	if err != nil {
+		return nil, err
+	}
+	if ext == nil {
+		return nil, fmt.Errorf("no shoot-dns-service extension found in namespace %q", o.namespace)
+	}
+	return ext, nil
+}
+
+// parseExtension parses an Extension resource in JSON or YAML. Any other content is taken as the raw state.
+func parseExtension(data []byte) (*extensionsv1alpha1.Extension, error) {
+	ext := &extensionsv1alpha1.Extension{}
+	if err := yaml.Unmarshal(data, ext); err == nil && ext.Kind == extensionsv1alpha1.ExtensionResource {
+		return ext, nil
+	}
+
+	raw, err := yaml.YAMLToJSON(data)
+	if err != nil {
+		return nil, fmt.Errorf("file contains neither an Extension resource nor a state: %w", err)
+	}
+	return &extensionsv1alpha1.Extension{
+		Status: extensionsv1alpha1.ExtensionStatus{
+			DefaultStatus: extensionsv1alpha1.DefaultStatus{
+				State: &runtime.RawExtension{Raw: raw},
+			},
+		},
+	}, nil
+}
@@ -0,0 +1,110 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+
+	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/client-go/tools/clientcmd"
+
+	"github.com/gardener/gardener-extension-shoot-dns-service/pkg/controller/common"
+)
+
+var _ = Describe("App", func() {
+	const rawState = `{"compressedState":"CwOAe30D"}`
+
+	Describe("#parseExtension", func() {
+		It("should parse an Extension resource in YAML", func() {
+			ext, err := parseExtension([]byte(`apiVersion: extensions.gardener.cloud/v1alpha1
+kind: Extension
+metadata:
+  name: shoot-dns-service
+  namespace: shoot--foo--bar
+status:
+  state:
+    compressedState: CwOAe30D
+`))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ext.Name).To(Equal("shoot-dns-service"))
+			Expect(ext.Namespace).To(Equal("shoot--foo--bar"))
+			Expect(ext.Status.State).NotTo(BeNil())
+			Expect(string(ext.Status.State.Raw)).To(Equal(rawState))
+		})
+
+		It("should parse an Extension resource in JSON", func() {
+			ext, err := parseExtension([]byte(`{"apiVersion":"extensions.gardener.cloud/v1alpha1","kind":"Extension","metadata":{"name":"shoot-dns-service"},"status":{"state":` + rawState + `}}`))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ext.Name).To(Equal("shoot-dns-service"))
+			Expect(string(ext.Status.State.Raw)).To(Equal(rawState))
+		})
+
+		It("should take any other content as raw state", func() {
+			ext, err := parseExtension([]byte(rawState))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ext.Name).To(BeEmpty())
+			Expect(string(ext.Status.State.Raw)).To(Equal(rawState))
+		})
+
+		It("should convert a raw state in YAML to JSON", func() {
+			ext, err := parseExtension([]byte("compressedState: CwOAe30D\n"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(ext.Status.State.Raw)).To(Equal(rawState))
+		})
+
+		It("should fail for invalid content", func() {
+			_, err := parseExtension([]byte("{foo: [bar"))
+			Expect(err).To(MatchError(ContainSubstring("file contains neither an Extension resource nor a state")))
+		})
+	})
+
+	Describe("#loadExtension", func() {
+		var (
+			ctx  context.Context
+			opts *options
+		)
+
+		BeforeEach(func() {
+			ctx = context.Background()
+			opts = &options{file: filepath.Join(GinkgoT().TempDir(), "state.json")}
+			GinkgoT().Setenv(clientcmd.RecommendedConfigPathEnvVar, "")
+		})
+
+		It("should load a state from a file without kubeconfig", func() {
+			Expect(os.WriteFile(opts.file, []byte(rawState), 0600)).To(Succeed())
+
+			ext, c, err := opts.loadExtension(ctx)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(c).To(BeNil())
+			Expect(common.DetectStateFormat(ext.Status.State.Raw)).To(Equal(common.StateFormatCompressed))
+		})
+
+		It("should fail for a chunked state without kubeconfig", func() {
+			Expect(os.WriteFile(opts.file, []byte(`{"chunkedState":{"size":1000,"checksum":"0123456789abcdef","chunks":[{"name":"state-chunk-0","checksum":"0123456789abcdef"}]}}`), 0600)).To(Succeed())
+
+			_, _, err := opts.loadExtension(ctx)
+			Expect(err).To(MatchError("kubeconfig required to reassemble chunked state"))
+		})
+
+		It("should fail for a missing file", func() {
+			_, _, err := opts.loadExtension(ctx)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("should keep the metadata of an Extension resource", func() {
+			ext := `{"apiVersion":"extensions.gardener.cloud/v1alpha1","kind":"` + extensionsv1alpha1.ExtensionResource + `","metadata":{"name":"dns","namespace":"shoot--foo--bar"},"status":{"state":` + rawState + `}}`
+			Expect(os.WriteFile(opts.file, []byte(ext), 0600)).To(Succeed())
+
+			loaded, _, err := opts.loadExtension(ctx)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(loaded.Namespace).To(Equal("shoot--foo--bar"))
+			Expect(loaded.Name).To(Equal("dns"))
+		})
+	})
+})
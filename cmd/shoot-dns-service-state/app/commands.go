@@ -0,0 +1,248 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+	"text/tabwriter"
+
+	dnsv1alpha1 "github.com/gardener/external-dns-management/pkg/apis/dns/v1alpha1"
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/runtime/serializer"
+	"sigs.k8s.io/yaml"
+
+	"github.com/gardener/gardener-extension-shoot-dns-service/pkg/apis"
+	"github.com/gardener/gardener-extension-shoot-dns-service/pkg/apis/helper"
+	legacyapi "github.com/gardener/gardener-extension-shoot-dns-service/pkg/apis/v1alpha1"
+	wireapi "github.com/gardener/gardener-extension-shoot-dns-service/pkg/apis/v1alpha2"
+	"github.com/gardener/gardener-extension-shoot-dns-service/pkg/controller/common"
+)
+
+const (
+	formatEnvelope   = "envelope"
+	formatCompressed = "compressed"
+)
+
+func newDecodeCommand(opts *options) *cobra.Command {
+	return &cobra.Command{
+		Use:   "decode",
+		Short: "Print the state of the extension as YAML",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ext, c, err := opts.loadExtension(cmd.Context())
+			if err != nil {
+				return err
+			}
+			state, err := common.GetExtensionState(cmd.Context(), c, ext)
+			if err != nil {
+				return err
+			}
+			data, err := marshalState(state)
+			if err != nil {
+				return err
+			}
+			if ext.Status.State != nil {
+				fmt.Fprintf(opts.out, "# format: %s\n", common.DetectStateFormat(ext.Status.State.Raw))
+			}
+			_, err = opts.out.Write(data)
+			return err
+		},
+	}
+}
+
+func newListCommand(opts *options) *cobra.Command {
+	return &cobra.Command{
+		Use:   "list",
+		Short: "List the DNS entries of the state with their DNS names and targets",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ext, c, err := opts.loadExtension(cmd.Context())
+			if err != nil {
+				return err
+			}
+			state, err := common.GetExtensionState(cmd.Context(), c, ext)
+			if err != nil {
+				return err
+			}
+
+			w := tabwriter.NewWriter(opts.out, 0, 4, 2, ' ', 0)
+			fmt.Fprintln(w, "NAME\tDNSNAME\tTARGETS")
+			for _, entry := range sortedEntries(state) {
+				var dnsName, targets string
+				if entry.Spec != nil {
+					dnsName = entry.Spec.DNSName
+					targets = strings.Join(append(slices.Clone(entry.Spec.Targets), entry.Spec.Text...), ",")
+				}
+				fmt.Fprintf(w, "%s\t%s\t%s\n", entry.Name, dnsName, targets)
+			}
+			return w.Flush()
+		},
+	}
+}
+
+func newDiffCommand(opts *options) *cobra.Command {
+	return &cobra.Command{
+		Use:   "diff",
+		Short: "Compare the state with the live DNS entries of the shoot in the namespace",
+		Long: `Compare the state with the live DNS entries of the shoot in the namespace.
+Entries only contained in the state are prefixed with '-', entries only existing in the cluster with '+'
+and entries with a different specification with '~'.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			c, err := opts.client()
+			if err != nil {
+				return err
+			}
+			ext, err := opts.getExtension(cmd.Context(), c)
+			if err != nil {
+				return err
+			}
+			state, err := common.GetExtensionState(cmd.Context(), c, ext)
+			if err != nil {
+				return err
+			}
+			live, err := common.NewShootDNSEntriesHelper(cmd.Context(), c, ext).List()
+			if err != nil {
+				return err
+			}
+			for _, line := range diffState(state, live) {
+				fmt.Fprintln(opts.out, line)
+			}
+			return nil
+		},
+	}
+}
+
+func newEncodeCommand(opts *options) *cobra.Command {
+	var (
+		format string
+		codec  string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "encode",
+		Short: "Encode a DNS state in YAML into a state blob for the status of the extension",
+		Long: `Encode a DNS state in YAML (e.g. the edited output of the decode command) read from the file given
+with --file (or stdin) into a state blob, which can be stored in the status.state field of the extension.`,
+		Args: cobra.NoArgs,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			if opts.file == "" {
+				opts.file = "-"
+			}
+			data, err := opts.readFile()
+			if err != nil {
+				return err
+			}
+			blob, err := encodeState(data, format, common.StateCodec(codec))
+			if err != nil {
+				return err
+			}
+			if len(blob) > common.MaxInlineStateSize {
+				fmt.Fprintf(opts.errOut, "warning: the encoded state has %d bytes, the extension stores states larger than %d bytes in chunks if the state envelope is enabled\n", len(blob), common.MaxInlineStateSize)
+			}
+			_, err = fmt.Fprintln(opts.out, string(blob))
+			return err
+		},
+	}
+	cmd.Flags().StringVar(&format, "format", formatEnvelope, fmt.Sprintf("format of the state blob (%s or %s for extension versions without envelope support)", formatEnvelope, formatCompressed))
+	cmd.Flags().StringVar(&codec, "codec", string(common.DefaultStateCodec), "codec of the state envelope (brotli, zstd or none)")
+	return cmd
+}
+
+// marshalState converts the state to the wire version and marshals it as YAML.
+func marshalState(state *apis.DNSState) ([]byte, error) {
+	wire := &wireapi.DNSState{}
+	if err := helper.Scheme.Convert(state, wire, nil); err != nil {
+		return nil, err
+	}
+	wire.APIVersion = wireapi.SchemeGroupVersion.String()
+	wire.Kind = wireapi.DNSStateKind
+	return yaml.Marshal(wire)
+}
+
+// encodeState decodes a DNS state in any version and encodes it in the given format.
+func encodeState(data []byte, format string, codec common.StateCodec) ([]byte, error) {
+	state := &apis.DNSState{}
+	if _, _, err := serializer.NewCodecFactory(helper.Scheme).UniversalDecoder().Decode(data, nil, state); err != nil {
+		return nil, fmt.Errorf("could not decode state: %w", err)
+	}
+
+	switch format {
+	case formatEnvelope:
+		wire := &wireapi.DNSState{}
+		plain, err := marshalWireState(state, wire, wireapi.SchemeGroupVersion.String(), wireapi.DNSStateKind)
+		if err != nil {
+			return nil, err
+		}
+		return common.EncodeStateEnvelope(plain, len(wire.Entries), codec)
+	case formatCompressed:
+		// extension versions without envelope support only know the v1alpha1 version
+		plain, err := marshalWireState(state, &legacyapi.DNSState{}, legacyapi.SchemeGroupVersion.String(), legacyapi.DNSStateKind)
+		if err != nil {
+			return nil, err
+		}
+		return common.CompressEntriesState(plain)
+	default:
+		return nil, fmt.Errorf("unsupported format %q", format)
+	}
+}
+
+// marshalWireState converts the state to the given wire object and marshals it as JSON.
+func marshalWireState(state *apis.DNSState, wire runtime.Object, apiVersion, kind string) ([]byte, error) {
+	if err := helper.Scheme.Convert(state, wire, nil); err != nil {
+		return nil, err
+	}
+	wire.GetObjectKind().SetGroupVersionKind(schema.FromAPIVersionAndKind(apiVersion, kind))
+	return json.Marshal(wire)
+}
+
+// diffState compares the entries of the state with the live DNS entries.
+func diffState(state *apis.DNSState, live []dnsv1alpha1.DNSEntry) []string {
+	liveEntries := map[string]*dnsv1alpha1.DNSEntry{}
+	for i := range live {
+		liveEntries[live[i].Name] = &live[i]
+	}
+
+	var lines []string
+	for _, entry := range sortedEntries(state) {
+		liveEntry, ok := liveEntries[entry.Name]
+		if !ok {
+			lines = append(lines, fmt.Sprintf("- %s (%s)", entry.Name, dnsNameOf(entry.Spec)))
+			continue
+		}
+		delete(liveEntries, entry.Name)
+		if entry.Spec == nil || !reflect.DeepEqual(*entry.Spec, liveEntry.Spec) {
+			lines = append(lines, fmt.Sprintf("~ %s (%s)", entry.Name, dnsNameOf(&liveEntry.Spec)))
+		}
+	}
+
+	var added []string
+	for name, entry := range liveEntries {
+		added = append(added, fmt.Sprintf("+ %s (%s)", name, dnsNameOf(&entry.Spec)))
+	}
+	slices.Sort(added)
+	return append(lines, added...)
+}
+
+func sortedEntries(state *apis.DNSState) []*apis.DNSEntry {
+	entries := slices.Clone(state.Entries)
+	slices.SortFunc(entries, func(a, b *apis.DNSEntry) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+	return entries
+}
+
+func dnsNameOf(spec *dnsv1alpha1.DNSEntrySpec) string {
+	if spec == nil {
+		return ""
+	}
+	return spec.DNSName
+}
@@ -0,0 +1,155 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"context"
+
+	dnsv1alpha1 "github.com/gardener/external-dns-management/pkg/apis/dns/v1alpha1"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/gardener/gardener-extension-shoot-dns-service/pkg/apis"
+	"github.com/gardener/gardener-extension-shoot-dns-service/pkg/controller/common"
+)
+
+var _ = Describe("Commands", func() {
+	const yamlState = `apiVersion: dns.extensions.gardener.cloud/v1alpha2
+entries:
+- name: entry2
+  spec:
+    dnsName: b.example.com
+    targets:
+    - 1.2.3.4
+- name: entry1
+  spec:
+    dnsName: a.example.com
+kind: DNSState
+redirectedEntries:
+- name: entry2
+  targets:
+  - 5.6.7.8
+`
+
+	var state *apis.DNSState
+
+	BeforeEach(func() {
+		state = &apis.DNSState{
+			Entries: []*apis.DNSEntry{
+				{Name: "entry2", Spec: &dnsv1alpha1.DNSEntrySpec{DNSName: "b.example.com", Targets: []string{"1.2.3.4"}}},
+				{Name: "entry1", Spec: &dnsv1alpha1.DNSEntrySpec{DNSName: "a.example.com"}},
+			},
+			RedirectedEntries: []*apis.RedirectedDNSEntry{
+				{Name: "entry2", Targets: []string{"5.6.7.8"}},
+			},
+		}
+	})
+
+	Describe("#marshalState", func() {
+		It("should marshal the state in the wire version", func() {
+			data, err := marshalState(state)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(data)).To(Equal(yamlState))
+		})
+
+		It("should marshal an empty state", func() {
+			data, err := marshalState(&apis.DNSState{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(data)).To(Equal("apiVersion: dns.extensions.gardener.cloud/v1alpha2\nkind: DNSState\n"))
+		})
+	})
+
+	Describe("#encodeState", func() {
+		decode := func(blob []byte) *apis.DNSState {
+			ext, err := parseExtension(blob)
+			Expect(err).NotTo(HaveOccurred())
+			decoded, err := common.GetExtensionState(context.Background(), nil, ext)
+			Expect(err).NotTo(HaveOccurred())
+			return decoded
+		}
+
+		DescribeTable("should encode the state in the envelope",
+			func(codec common.StateCodec) {
+				blob, err := encodeState([]byte(yamlState), formatEnvelope, codec)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(common.DetectStateFormat(blob)).To(Equal(common.StateFormatEnvelope))
+
+				envelope, _, err := common.DecodeStateEnvelope(blob)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(envelope.Codec).To(Equal(codec))
+				Expect(envelope.EntryCount).To(Equal(2))
+				Expect(decode(blob)).To(Equal(state))
+			},
+			Entry("brotli", common.StateCodecBrotli),
+			Entry("zstd", common.StateCodecZstd),
+			Entry("none", common.StateCodecNone),
+		)
+
+		It("should encode the v1alpha1 state in the compressed format", func() {
+			blob, err := encodeState([]byte(yamlState), formatCompressed, common.StateCodecBrotli)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(common.DetectStateFormat(blob)).To(Equal(common.StateFormatCompressed))
+
+			plain, err := common.DecompressEntriesState(blob)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(plain)).To(ContainSubstring(`"apiVersion":"dns.extensions.gardener.cloud/v1alpha1"`))
+			// redirected entries are only supported since v1alpha2
+			state.RedirectedEntries = nil
+			Expect(decode(blob)).To(Equal(state))
+		})
+
+		It("should accept a state in v1alpha1", func() {
+			blob, err := encodeState([]byte("apiVersion: dns.extensions.gardener.cloud/v1alpha1\nkind: DNSState\nentries:\n- name: entry1\n"), formatEnvelope, common.StateCodecNone)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(decode(blob).Entries).To(ConsistOf(HaveField("Name", "entry1")))
+		})
+
+		It("should fail for an unsupported format", func() {
+			_, err := encodeState([]byte(yamlState), "foo", common.StateCodecBrotli)
+			Expect(err).To(MatchError(`unsupported format "foo"`))
+		})
+
+		It("should fail for an invalid state", func() {
+			_, err := encodeState([]byte("kind: Foo\n"), formatEnvelope, common.StateCodecBrotli)
+			Expect(err).To(MatchError(ContainSubstring("could not decode state")))
+		})
+	})
+
+	Describe("#diffState", func() {
+		It("should report removed, changed and added entries", func() {
+			live := []dnsv1alpha1.DNSEntry{
+				{ObjectMeta: metav1.ObjectMeta{Name: "entry3"}, Spec: dnsv1alpha1.DNSEntrySpec{DNSName: "c.example.com"}},
+				{ObjectMeta: metav1.ObjectMeta{Name: "entry2"}, Spec: dnsv1alpha1.DNSEntrySpec{DNSName: "b.example.com", Targets: []string{"4.3.2.1"}}},
+				{ObjectMeta: metav1.ObjectMeta{Name: "entry0"}, Spec: dnsv1alpha1.DNSEntrySpec{DNSName: "0.example.com"}},
+			}
+
+			Expect(diffState(state, live)).To(Equal([]string{
+				"- entry1 (a.example.com)",
+				"~ entry2 (b.example.com)",
+				"+ entry0 (0.example.com)",
+				"+ entry3 (c.example.com)",
+			}))
+		})
+
+		It("should report nothing for equal entries", func() {
+			live := []dnsv1alpha1.DNSEntry{
+				{ObjectMeta: metav1.ObjectMeta{Name: "entry1"}, Spec: dnsv1alpha1.DNSEntrySpec{DNSName: "a.example.com"}},
+				{ObjectMeta: metav1.ObjectMeta{Name: "entry2"}, Spec: dnsv1alpha1.DNSEntrySpec{DNSName: "b.example.com", Targets: []string{"1.2.3.4"}}},
+			}
+
+			Expect(diffState(state, live)).To(BeEmpty())
+		})
+
+		It("should report entries of the state without spec as changed", func() {
+			state.Entries = []*apis.DNSEntry{{Name: "entry1"}}
+			live := []dnsv1alpha1.DNSEntry{
+				{ObjectMeta: metav1.ObjectMeta{Name: "entry1"}, Spec: dnsv1alpha1.DNSEntrySpec{DNSName: "a.example.com"}},
+			}
+
+			Expect(diffState(state, live)).To(Equal([]string{"~ entry1 (a.example.com)"}))
+		})
+	})
+})
@@ -49,6 +49,11 @@ spec:
       values:
         image:
           ref: local-skaffold/gardener-extension-admission-shoot-dns-service:v0.0.0
+        quotas:
+          operatorGroups:
+          - system:masters
+          defaultExternalProviderEntries: 10
+          defaultExternalProviderEntriesMax: 15
       virtualCluster:
         helm:
           ociRepository:
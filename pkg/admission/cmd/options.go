@@ -5,12 +5,20 @@
 package cmd
 
 import (
+	"fmt"
+	"os"
+
 	"github.com/gardener/external-dns-management/pkg/dnsman2/apis/config"
 	webhookcmd "github.com/gardener/gardener/extensions/pkg/webhook/cmd"
 	"github.com/spf13/pflag"
 
 	"github.com/gardener/gardener-extension-shoot-dns-service/pkg/admission/mutator"
+	"github.com/gardener/gardener-extension-shoot-dns-service/pkg/admission/quota"
 	"github.com/gardener/gardener-extension-shoot-dns-service/pkg/admission/validator"
+	"github.com/gardener/gardener-extension-shoot-dns-service/pkg/apis/config/loader"
+	configvalidation "github.com/gardener/gardener-extension-shoot-dns-service/pkg/apis/config/validation"
+	"github.com/gardener/gardener-extension-shoot-dns-service/pkg/apis/service/validation"
+	controllerconfig "github.com/gardener/gardener-extension-shoot-dns-service/pkg/controller/config"
 )
 
 // GardenWebhookSwitchOptions are the webhookcmd.SwitchOptions for the admission webhooks.
@@ -18,6 +26,7 @@ func GardenWebhookSwitchOptions() *webhookcmd.SwitchOptions {
 	pairs := []webhookcmd.NameToFactory{
 		webhookcmd.Switch(validator.ValidatorName, validator.New),
 		webhookcmd.Switch(mutator.MutatorName, mutator.New),
+		webhookcmd.Switch(validator.SecretValidatorName, validator.NewSecretWebhook),
 	}
 	pairs = append(pairs, validator.NewWorkloadIdentityWebhooks()...)
 	return webhookcmd.NewSwitchOptions(pairs...)
@@ -27,8 +36,11 @@ func GardenWebhookSwitchOptions() *webhookcmd.SwitchOptions {
 // ConfigOptions are command line options that can be set for admission webhooks.
 type ConfigOptions struct {
 	GCPWorkloadIdentityOptions GCPWorkloadIdentityOptions
+	ProviderTypesOptions       ProviderTypesOptions
+	QuotaOptions               QuotaOptions
 
-	config *config.InternalGCPWorkloadIdentityConfig
+	config                   *config.InternalGCPWorkloadIdentityConfig
+	providerTypeRestrictions *validation.ProviderTypeRestrictions
 }
 
 // GCPWorkloadIdentityOptions are options that specify how GCP workload identities should be validated.
@@ -55,6 +67,143 @@ func (w *GCPWorkloadIdentityOptions) AddFlags(fs *pflag.FlagSet) {
 	)
 }
 
+// ProviderTypesOptions are options that restrict the provider types allowed for additional DNS providers.
+type ProviderTypesOptions struct {
+	// AllowedProviderTypes are the allowed provider types. If empty, all provider types with a registered adapter are allowed.
+	AllowedProviderTypes []string
+	// DeniedProviderTypes are the denied provider types.
+	DeniedProviderTypes []string
+	// ProviderTypeRules are rules overwriting the allowed and denied provider types per project or seed label.
+	ProviderTypeRules []string
+}
+
+// AddFlags implements Flagger.AddFlags.
+func (o *ProviderTypesOptions) AddFlags(fs *pflag.FlagSet) {
+	fs.StringSliceVar(
+		&o.AllowedProviderTypes,
+		"allowed-provider-types",
+		nil,
+		"Provider types allowed for additional DNS providers. If not set, all provider types supported by external-dns-management are allowed.",
+	)
+	fs.StringSliceVar(
+		&o.DeniedProviderTypes,
+		"denied-provider-types",
+		nil,
+		"Provider types denied for additional DNS providers.",
+	)
+	fs.StringArrayVar(
+		&o.ProviderTypeRules,
+		"provider-types-rule",
+		nil,
+		"Rule overwriting the allowed and denied provider types for shoots of a project or on seeds selected by labels. The first matching rule is applied. Can be set multiple times, "+
+			"e.g. --provider-types-rule='project=my-project;allow=aws-route53,google-clouddns' --provider-types-rule='seed-label=environment=restricted;deny=netlify-dns'",
+	)
+}
+
+// Build returns the provider type restrictions or nil if no restrictions are configured.
+func (o *ProviderTypesOptions) Build() (*validation.ProviderTypeRestrictions, error) {
+	if len(o.AllowedProviderTypes) == 0 && len(o.DeniedProviderTypes) == 0 && len(o.ProviderTypeRules) == 0 {
+		return nil, nil
+	}
+	restrictions := &validation.ProviderTypeRestrictions{
+		Allowed: o.AllowedProviderTypes,
+		Denied:  o.DeniedProviderTypes,
+	}
+	for _, value := range o.ProviderTypeRules {
+		rule, err := validation.ParseProviderTypeRule(value)
+		if err != nil {
+			return nil, err
+		}
+		restrictions.Rules = append(restrictions.Rules, rule)
+	}
+	return restrictions, nil
+}
+
+// QuotaOptions are options for the verification of the shoot annotation requesting a DNS entries quota.
+type QuotaOptions struct {
+	// OperatorGroups are the groups of the users allowed to raise the quota above the default.
+	OperatorGroups []string
+	// DefaultQuota is the default DNS entries quota of the default external provider.
+	DefaultQuota int32
+	// MaxQuota is the maximum DNS entries quota of the default external provider.
+	MaxQuota int32
+	// ConfigFilePath is the path to the controller configuration file. Its quota settings take precedence over the
+	// command line flags, so that the admission applies the same quota limits as the lifecycle controller.
+	ConfigFilePath string
+
+	quotaConfig controllerconfig.DNSServiceConfig
+}
+
+// AddFlags implements Flagger.AddFlags.
+func (o *QuotaOptions) AddFlags(fs *pflag.FlagSet) {
+	fs.StringSliceVar(
+		&o.OperatorGroups,
+		"quota-operator-groups",
+		nil,
+		"Groups of the users allowed to raise the DNS entries quota of the default external provider above the default with the shoot annotation 'service.dns.extensions.gardener.cloud/default-external-provider-entries-quota'. Can be set multiple times.",
+	)
+	fs.Int32Var(
+		&o.DefaultQuota,
+		"default-external-provider-entries-quota",
+		0,
+		"Default DNS entries quota of the default external provider. Only operators can request a higher quota with the shoot annotation. If 0, the requested quota is not bounded.",
+	)
+	fs.Int32Var(
+		&o.MaxQuota,
+		"default-external-provider-entries-quota-max",
+		0,
+		"Maximum DNS entries quota of the default external provider which can be requested by operators with the shoot annotation. If 0, the default quota is the maximum.",
+	)
+	fs.StringVar(
+		&o.ConfigFilePath,
+		"config",
+		"",
+		"Path to the controller configuration file of the extension. Its quota settings including the quota rules take precedence over the corresponding command line flags.",
+	)
+}
+
+// Complete validates the quota options and applies the quota settings of the controller configuration file.
+func (o *QuotaOptions) Complete() error {
+	if o.DefaultQuota < 0 || o.MaxQuota < 0 {
+		return fmt.Errorf("DNS entries quotas must not be negative")
+	}
+	if o.MaxQuota != 0 && o.MaxQuota < o.DefaultQuota {
+		return fmt.Errorf("maximum DNS entries quota %d must not be less than the default quota %d", o.MaxQuota, o.DefaultQuota)
+	}
+	o.quotaConfig = controllerconfig.DNSServiceConfig{
+		DefaultExternalProviderEntriesQuota:    o.DefaultQuota,
+		DefaultExternalProviderEntriesQuotaMax: o.MaxQuota,
+	}
+	if o.ConfigFilePath == "" {
+		return nil
+	}
+	data, err := os.ReadFile(o.ConfigFilePath)
+	if err != nil {
+		return fmt.Errorf("could not read controller configuration file: %w", err)
+	}
+	cfg, err := loader.Load(data)
+	if err != nil {
+		return fmt.Errorf("invalid controller configuration file %s: %w", o.ConfigFilePath, err)
+	}
+	if errs := configvalidation.ValidateConfiguration(cfg); len(errs) > 0 {
+		return fmt.Errorf("invalid controller configuration file %s: %w", o.ConfigFilePath, errs.ToAggregate())
+	}
+	o.quotaConfig.ApplyQuotaConfiguration(cfg.Quotas)
+	return nil
+}
+
+// QuotaConfig returns the quota limits of the default external provider. Only call this if `Complete` was successful.
+func (o *QuotaOptions) QuotaConfig() controllerconfig.DNSServiceConfig {
+	return o.quotaConfig
+}
+
+// Verifier returns the verifier for the shoot annotation requesting a DNS entries quota.
+func (o *QuotaOptions) Verifier() quota.Verifier {
+	return quota.Verifier{
+		OperatorGroups: o.OperatorGroups,
+	}
+}
+
 // Complete implements RESTCompleter.Complete.
 func (c *ConfigOptions) Complete() error {
 	var err error
@@ -62,7 +211,14 @@ func (c *ConfigOptions) Complete() error {
 		AllowedTokenURLs: c.GCPWorkloadIdentityOptions.AllowedTokenURLs,
 		AllowedServiceAccountImpersonationURLRegExps: c.GCPWorkloadIdentityOptions.AllowedServiceAccountImpersonationURLRegExps,
 	})
-	return err
+	if err != nil {
+		return err
+	}
+	c.providerTypeRestrictions, err = c.ProviderTypesOptions.Build()
+	if err != nil {
+		return err
+	}
+	return c.QuotaOptions.Complete()
 }
 
 // Completed returns the completed Config. Only call this if `Complete` was successful.
@@ -70,7 +226,14 @@ func (c *ConfigOptions) Completed() *config.InternalGCPWorkloadIdentityConfig {
 	return c.config
 }
 
+// CompletedProviderTypeRestrictions returns the completed provider type restrictions. Only call this if `Complete` was successful.
+func (c *ConfigOptions) CompletedProviderTypeRestrictions() *validation.ProviderTypeRestrictions {
+	return c.providerTypeRestrictions
+}
+
 // AddFlags implements Flagger.AddFlags.
 func (c *ConfigOptions) AddFlags(fs *pflag.FlagSet) {
 	c.GCPWorkloadIdentityOptions.AddFlags(fs)
+	c.ProviderTypesOptions.AddFlags(fs)
+	c.QuotaOptions.AddFlags(fs)
 }
@@ -6,6 +6,7 @@ package mutator_test
 
 import (
 	"context"
+	"maps"
 	"time"
 
 	extensionswebhook "github.com/gardener/gardener/extensions/pkg/webhook"
@@ -15,13 +16,17 @@ import (
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	gomegatypes "github.com/onsi/gomega/types"
+	admissionv1 "k8s.io/api/admission/v1"
+	authenticationv1 "k8s.io/api/authentication/v1"
 	autoscalingv1 "k8s.io/api/autoscaling/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/serializer"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
 	admissionmutator "github.com/gardener/gardener-extension-shoot-dns-service/pkg/admission/mutator"
+	"github.com/gardener/gardener-extension-shoot-dns-service/pkg/admission/quota"
 	serviceinstall "github.com/gardener/gardener-extension-shoot-dns-service/pkg/apis/service/install"
 	servicev1alpha1 "github.com/gardener/gardener-extension-shoot-dns-service/pkg/apis/service/v1alpha1"
 	service2 "github.com/gardener/gardener-extension-shoot-dns-service/pkg/service"
@@ -241,7 +246,7 @@ var _ = Describe("Shoot Mutator", func() {
 			Scheme: scheme,
 		}
 
-		mutator = admissionmutator.NewShootMutator(mgr)
+		mutator = admissionmutator.NewShootMutator(mgr, quota.Verifier{OperatorGroups: []string{"operators"}})
 	})
 
 	DescribeTable("#Mutate",
@@ -396,6 +401,59 @@ var _ = Describe("Shoot Mutator", func() {
 		}), nil),
 		Entry("shoot in deletion", dnsStyleEnabled, shootInDeletion, []gardencorev1beta1.DNSProvider{additional}, BeNil(), nil, nil),
 	)
+
+	It("should verify the quota annotation set by an operator", func() {
+		ctx := admission.NewContextWithRequest(context.Background(), admission.Request{
+			AdmissionRequest: admissionv1.AdmissionRequest{UserInfo: authenticationv1.UserInfo{Groups: []string{"operators"}}},
+		})
+		oldShoot := shoot.DeepCopy()
+		oldShoot.Spec.DNS = nil
+		newShoot := oldShoot.DeepCopy()
+		newShoot.Annotations = map[string]string{service2.DefaultExternalProviderEntriesQuotaAnnotation: "500"}
+
+		Expect(mutator.Mutate(ctx, newShoot, oldShoot)).To(Succeed())
+		Expect(newShoot.Annotations).To(HaveKeyWithValue(service2.DefaultExternalProviderEntriesQuotaVerifiedAnnotation, "500"))
+
+		newShoot.Annotations[service2.DefaultExternalProviderEntriesQuotaAnnotation] = "1000"
+		Expect(mutator.Mutate(context.Background(), newShoot, oldShoot)).To(Succeed())
+		Expect(newShoot.Annotations).NotTo(HaveKey(service2.DefaultExternalProviderEntriesQuotaVerifiedAnnotation))
+	})
+
+	DescribeTable("should remove the verification set while the extension was not enabled",
+		func(modifyOld func(*gardencorev1beta1.Shoot)) {
+			annotations := map[string]string{
+				service2.DefaultExternalProviderEntriesQuotaAnnotation:         "500",
+				service2.DefaultExternalProviderEntriesQuotaVerifiedAnnotation: "500",
+			}
+			oldShoot := shoot.DeepCopy()
+			oldShoot.Labels = map[string]string{service2.ExtensionLabel: "true"}
+			oldShoot.Annotations = annotations
+			modifyOld(oldShoot)
+			newShoot := shoot.DeepCopy()
+			newShoot.Labels = map[string]string{service2.ExtensionLabel: "true"}
+			newShoot.Annotations = maps.Clone(annotations)
+
+			Expect(mutator.Mutate(context.Background(), newShoot, oldShoot)).To(Succeed())
+			Expect(newShoot.Annotations).NotTo(HaveKey(service2.DefaultExternalProviderEntriesQuotaVerifiedAnnotation))
+		},
+		Entry("without extension label", func(s *gardencorev1beta1.Shoot) { s.Labels = nil }),
+		Entry("with disabled extension", func(s *gardencorev1beta1.Shoot) {
+			s.Spec.Extensions = append(s.Spec.Extensions, gardencorev1beta1.Extension{Type: service2.ExtensionType, Disabled: new(true)})
+		}),
+	)
+
+	It("should keep the verification if the extension was enabled", func() {
+		oldShoot := shoot.DeepCopy()
+		oldShoot.Labels = map[string]string{service2.ExtensionLabel: "true"}
+		oldShoot.Annotations = map[string]string{
+			service2.DefaultExternalProviderEntriesQuotaAnnotation:         "500",
+			service2.DefaultExternalProviderEntriesQuotaVerifiedAnnotation: "500",
+		}
+		newShoot := oldShoot.DeepCopy()
+
+		Expect(mutator.Mutate(context.Background(), newShoot, oldShoot)).To(Succeed())
+		Expect(newShoot.Annotations).To(HaveKeyWithValue(service2.DefaultExternalProviderEntriesQuotaVerifiedAnnotation, "500"))
+	})
 })
 
 func findExtensionProviderConfig(decoder runtime.Decoder, shoot *gardencorev1beta1.Shoot) *servicev1alpha1.DNSConfig {
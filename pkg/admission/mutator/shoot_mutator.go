@@ -15,39 +15,58 @@ import (
 	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
 	securityv1alpha1 "github.com/gardener/gardener/pkg/apis/security/v1alpha1"
 	autoscalingv1 "k8s.io/api/autoscaling/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/serializer"
 	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 
+	"github.com/gardener/gardener-extension-shoot-dns-service/pkg/admission/quota"
 	servicev1alpha1 "github.com/gardener/gardener-extension-shoot-dns-service/pkg/apis/service/v1alpha1"
 	pkgservice "github.com/gardener/gardener-extension-shoot-dns-service/pkg/service"
 )
 
 // NewShootMutator returns a new instance of a shoot mutator.
-func NewShootMutator(mgr manager.Manager) extensionswebhook.Mutator {
+func NewShootMutator(mgr manager.Manager, quotaVerifier quota.Verifier) extensionswebhook.Mutator {
 	return &shoot{
-		decoder: serializer.NewCodecFactory(mgr.GetScheme()).UniversalDecoder(),
-		scheme:  mgr.GetScheme(),
+		decoder:       serializer.NewCodecFactory(mgr.GetScheme()).UniversalDecoder(),
+		scheme:        mgr.GetScheme(),
+		quotaVerifier: quotaVerifier,
 	}
 }
 
 // shoot mutates shoots
 type shoot struct {
-	decoder runtime.Decoder
-	scheme  *runtime.Scheme
-	lock    sync.Mutex
-	encoder runtime.Encoder
+	decoder       runtime.Decoder
+	scheme        *runtime.Scheme
+	lock          sync.Mutex
+	encoder       runtime.Encoder
+	quotaVerifier quota.Verifier
 }
 
 // Mutate implements extensionswebhook.Mutator.Mutate
-func (s *shoot) Mutate(ctx context.Context, new, _ client.Object) error {
+func (s *shoot) Mutate(ctx context.Context, new, old client.Object) error {
 	shoot, ok := new.(*gardencorev1beta1.Shoot)
 	if !ok {
 		return fmt.Errorf("wrong object type %T", new)
 	}
 
+	// the quota annotation is verified independent of the DNS config
+	var (
+		oldObj     metav1.Object
+		oldEnabled bool
+	)
+	if old != nil {
+		oldShoot, ok := old.(*gardencorev1beta1.Shoot)
+		if !ok {
+			return fmt.Errorf("wrong object type %T (old)", old)
+		}
+		oldObj, oldEnabled = oldShoot, isEnabled(oldShoot)
+	}
+	s.quotaVerifier.UpdateVerification(ctx, shoot, oldObj, oldEnabled)
+
 	return s.mutateShoot(ctx, shoot)
 }
 
@@ -167,6 +186,20 @@ func (s *shoot) isDisabled(shoot *gardencorev1beta1.Shoot) bool {
 	return false
 }
 
+// isEnabled returns true if the shoot has the extension label and the extension is not disabled, i.e. if the
+// admission webhooks are called for changes of the shoot.
+func isEnabled(shoot *gardencorev1beta1.Shoot) bool {
+	if shoot.Labels[pkgservice.ExtensionLabel] != "true" {
+		return false
+	}
+	for _, ext := range shoot.Spec.Extensions {
+		if ext.Type == pkgservice.ExtensionType {
+			return !ptr.Deref(ext.Disabled, false)
+		}
+	}
+	return true
+}
+
 // extractDNSConfig extracts DNSConfig from providerConfig.
 func (s *shoot) extractDNSConfig(shoot *gardencorev1beta1.Shoot) (*servicev1alpha1.DNSConfig, error) {
 	ext := s.findExtension(shoot)
@@ -10,6 +10,9 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
+
+	"github.com/gardener/gardener-extension-shoot-dns-service/pkg/admission/quota"
+	pkgservice "github.com/gardener/gardener-extension-shoot-dns-service/pkg/service"
 )
 
 const (
@@ -19,19 +22,35 @@ const (
 
 var logger = log.Log.WithName("shoot-dns-service-mutator-webhook")
 
-// New creates a new webhook that validates Shoot resources.
+var (
+	// DefaultAddOptions are the default AddOptions for configuring the mutator.
+	DefaultAddOptions = AddOptions{}
+)
+
+// AddOptions are options to apply when adding the mutator webhook to the manager.
+type AddOptions struct {
+	// QuotaVerifier verifies changes of the quota annotation of shoots.
+	QuotaVerifier quota.Verifier
+}
+
+// New creates a new webhook that mutates Shoot resources.
 func New(mgr manager.Manager) (*extensionswebhook.Webhook, error) {
 	logger.Info("Setting up webhook", "name", MutatorName)
 
-	return extensionswebhook.New(mgr, extensionswebhook.Args{
+	wh, err := extensionswebhook.New(mgr, extensionswebhook.Args{
 		Name: MutatorName,
 		Path: MutatorPath,
 		Mutators: map[extensionswebhook.Mutator][]extensionswebhook.Type{
-			NewShootMutator(mgr): {{Obj: &gardencorev1beta1.Shoot{}}},
+			NewShootMutator(mgr, DefaultAddOptions.QuotaVerifier): {{Obj: &gardencorev1beta1.Shoot{}}},
 		},
 		Target: extensionswebhook.TargetSeed,
 		ObjectSelector: &metav1.LabelSelector{
-			MatchLabels: map[string]string{"extensions.extensions.gardener.cloud/shoot-dns-service": "true"},
+			MatchLabels: map[string]string{pkgservice.ExtensionLabel: "true"},
 		},
 	})
+	if err != nil {
+		return nil, err
+	}
+	// the quota verifier needs the user info of the admission request
+	return quota.WithAdmissionRequest(wh), nil
 }
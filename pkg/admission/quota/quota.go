@@ -0,0 +1,131 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package quota
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+
+	extensionswebhook "github.com/gardener/gardener/extensions/pkg/webhook"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/gardener/gardener-extension-shoot-dns-service/pkg/service"
+)
+
+// Verifier verifies changes of the shoot annotation requesting a DNS entries quota for the default external provider.
+type Verifier struct {
+	// OperatorGroups are the groups of the users allowed to raise the quota above the default.
+	OperatorGroups []string
+}
+
+// IsOperator returns true if the user of the admission request in the context is member of an operator group.
+func (v Verifier) IsOperator(ctx context.Context) bool {
+	req, err := admission.RequestFromContext(ctx)
+	if err != nil {
+		return false
+	}
+	for _, group := range req.UserInfo.Groups {
+		if slices.Contains(v.OperatorGroups, group) {
+			return true
+		}
+	}
+	return false
+}
+
+// UpdateVerification maintains the verification annotation of the new object. It is only kept unchanged if the old
+// object already had both annotations and the extension was enabled for it, as the webhook does not see changes of
+// shoots without the extension. Otherwise, the verification annotation is set to the value of the quota annotation for
+// operators and removed for all other users.
+// The parameter oldEnabled must be true if the extension was enabled for the old object.
+func (v Verifier) UpdateVerification(ctx context.Context, newObj, oldObj metav1.Object, oldEnabled bool) {
+	quota := newObj.GetAnnotations()[service.DefaultExternalProviderEntriesQuotaAnnotation]
+	verified, hasVerified := newObj.GetAnnotations()[service.DefaultExternalProviderEntriesQuotaVerifiedAnnotation]
+	if quota == "" {
+		if hasVerified {
+			removeAnnotation(newObj, service.DefaultExternalProviderEntriesQuotaVerifiedAnnotation)
+		}
+		return
+	}
+	if oldObj != nil &&
+		oldObj.GetAnnotations()[service.DefaultExternalProviderEntriesQuotaAnnotation] == quota &&
+		oldObj.GetAnnotations()[service.DefaultExternalProviderEntriesQuotaVerifiedAnnotation] == verified &&
+		(!hasVerified || oldEnabled) {
+		return
+	}
+
+	if v.IsOperator(ctx) {
+		annotations := newObj.GetAnnotations()
+		annotations[service.DefaultExternalProviderEntriesQuotaVerifiedAnnotation] = quota
+		newObj.SetAnnotations(annotations)
+	} else if hasVerified {
+		removeAnnotation(newObj, service.DefaultExternalProviderEntriesQuotaVerifiedAnnotation)
+	}
+}
+
+// Validate validates the quota annotation of the new object if it was changed. The value must be a positive number.
+// Only operators can request a quota above the default quota, and nobody above the maximum quota.
+// If the default quota is 0, the value is not bounded. If the maximum quota is 0, the default quota is also the maximum.
+// The old object must be nil if the extension was not enabled for it, so that the annotation is validated on enabling
+// the extension.
+func (v Verifier) Validate(ctx context.Context, newObj, oldObj metav1.Object, defaultQuota, maxQuota int32) field.ErrorList {
+	value := newObj.GetAnnotations()[service.DefaultExternalProviderEntriesQuotaAnnotation]
+	if value == "" || (oldObj != nil && oldObj.GetAnnotations()[service.DefaultExternalProviderEntriesQuotaAnnotation] == value) {
+		return nil
+	}
+
+	path := annotationPath()
+	quota, err := strconv.ParseInt(value, 10, 32)
+	if err != nil || quota < 1 {
+		return field.ErrorList{field.Invalid(path, value, "must be a positive integer")}
+	}
+	if defaultQuota == 0 {
+		return nil
+	}
+	if quota > int64(defaultQuota) && !v.IsOperator(ctx) {
+		return field.ErrorList{field.Forbidden(path, fmt.Sprintf("only operators can raise the quota above the default quota %d", defaultQuota))}
+	}
+	if maxQuota := cmp.Or(maxQuota, defaultQuota); quota > int64(maxQuota) {
+		return field.ErrorList{field.Invalid(path, value, fmt.Sprintf("must not exceed the maximum quota %d", maxQuota))}
+	}
+	return nil
+}
+
+// Warnings returns a warning if the quota annotation of the new object was changed to a quota above the default quota.
+func (v Verifier) Warnings(newObj, oldObj metav1.Object, defaultQuota int32) admission.Warnings {
+	value := newObj.GetAnnotations()[service.DefaultExternalProviderEntriesQuotaAnnotation]
+	if value == "" || defaultQuota == 0 || (oldObj != nil && oldObj.GetAnnotations()[service.DefaultExternalProviderEntriesQuotaAnnotation] == value) {
+		return nil
+	}
+	quota, err := strconv.ParseInt(value, 10, 32)
+	if err != nil || quota <= int64(defaultQuota) {
+		return nil
+	}
+	return admission.Warnings{fmt.Sprintf("%s: the requested quota %d is above the default quota %d", annotationPath(), quota, defaultQuota)}
+}
+
+func annotationPath() *field.Path {
+	return field.NewPath("metadata", "annotations").Key(service.DefaultExternalProviderEntriesQuotaAnnotation)
+}
+
+func removeAnnotation(obj metav1.Object, key string) {
+	annotations := obj.GetAnnotations()
+	delete(annotations, key)
+	obj.SetAnnotations(annotations)
+}
+
+// WithAdmissionRequest adds the admission request to the context passed to the mutators and validators of the webhook,
+// as the handler of the extensions library does not provide it.
+func WithAdmissionRequest(wh *extensionswebhook.Webhook) *extensionswebhook.Webhook {
+	handler := wh.Webhook.Handler
+	wh.Webhook.Handler = admission.HandlerFunc(func(ctx context.Context, req admission.Request) admission.Response {
+		return handler.Handle(admission.NewContextWithRequest(ctx, req), req)
+	})
+	return wh
+}
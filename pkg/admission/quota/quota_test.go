@@ -0,0 +1,174 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package quota_test
+
+import (
+	"context"
+
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+	"github.com/onsi/gomega/types"
+	admissionv1 "k8s.io/api/admission/v1"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/gardener/gardener-extension-shoot-dns-service/pkg/admission/quota"
+	"github.com/gardener/gardener-extension-shoot-dns-service/pkg/service"
+)
+
+var _ = Describe("Verifier", func() {
+	var (
+		verifier = quota.Verifier{OperatorGroups: []string{"operators"}}
+
+		contextForGroups = func(groups ...string) context.Context {
+			return admission.NewContextWithRequest(context.Background(), admission.Request{
+				AdmissionRequest: admissionv1.AdmissionRequest{UserInfo: authenticationv1.UserInfo{Username: "user", Groups: groups}},
+			})
+		}
+		newShoot = func(annotations map[string]string) *gardencorev1beta1.Shoot {
+			return &gardencorev1beta1.Shoot{ObjectMeta: metav1.ObjectMeta{Name: "shoot", Annotations: annotations}}
+		}
+		quotaAnnotations = func(value, verified string) map[string]string {
+			annotations := map[string]string{service.DefaultExternalProviderEntriesQuotaAnnotation: value}
+			if verified != "" {
+				annotations[service.DefaultExternalProviderEntriesQuotaVerifiedAnnotation] = verified
+			}
+			return annotations
+		}
+	)
+
+	It("should detect operators by group", func() {
+		Expect(verifier.IsOperator(contextForGroups("system:authenticated", "operators"))).To(BeTrue())
+		Expect(verifier.IsOperator(contextForGroups("system:authenticated"))).To(BeFalse())
+		Expect(verifier.IsOperator(context.Background())).To(BeFalse())
+	})
+
+	It("should verify the annotation set by an operator", func() {
+		shoot := newShoot(quotaAnnotations("500", ""))
+		verifier.UpdateVerification(contextForGroups("operators"), shoot, newShoot(nil), true)
+		Expect(shoot.Annotations).To(Equal(quotaAnnotations("500", "500")))
+	})
+
+	It("should remove the verification of an annotation changed by another user", func() {
+		shoot := newShoot(quotaAnnotations("800", "800"))
+		verifier.UpdateVerification(contextForGroups("project-members"), shoot, newShoot(quotaAnnotations("500", "500")), true)
+		Expect(shoot.Annotations).To(Equal(quotaAnnotations("800", "")))
+	})
+
+	It("should keep the verification if the annotations are unchanged", func() {
+		shoot := newShoot(quotaAnnotations("500", "500"))
+		verifier.UpdateVerification(contextForGroups("project-members"), shoot, newShoot(quotaAnnotations("500", "500")), true)
+		Expect(shoot.Annotations).To(Equal(quotaAnnotations("500", "500")))
+	})
+
+	It("should not accept a verification set while the extension was not enabled", func() {
+		shoot := newShoot(quotaAnnotations("500", "500"))
+		verifier.UpdateVerification(contextForGroups("project-members"), shoot, newShoot(quotaAnnotations("500", "500")), false)
+		Expect(shoot.Annotations).To(Equal(quotaAnnotations("500", "")))
+	})
+
+	It("should verify an unchanged annotation for operators if the extension was not enabled", func() {
+		shoot := newShoot(quotaAnnotations("500", "800"))
+		verifier.UpdateVerification(contextForGroups("operators"), shoot, newShoot(quotaAnnotations("500", "800")), false)
+		Expect(shoot.Annotations).To(Equal(quotaAnnotations("500", "500")))
+	})
+
+	It("should not verify an unchanged annotation without verification", func() {
+		shoot := newShoot(quotaAnnotations("500", ""))
+		verifier.UpdateVerification(contextForGroups("operators"), shoot, newShoot(quotaAnnotations("500", "")), false)
+		Expect(shoot.Annotations).To(Equal(quotaAnnotations("500", "")))
+	})
+
+	It("should not accept a verification set by the user on creation", func() {
+		shoot := newShoot(quotaAnnotations("500", "500"))
+		verifier.UpdateVerification(contextForGroups("project-members"), shoot, nil, false)
+		Expect(shoot.Annotations).To(Equal(quotaAnnotations("500", "")))
+	})
+
+	It("should remove the verification without quota annotation", func() {
+		shoot := newShoot(map[string]string{service.DefaultExternalProviderEntriesQuotaVerifiedAnnotation: "500"})
+		verifier.UpdateVerification(contextForGroups("operators"), shoot, nil, false)
+		Expect(shoot.Annotations).To(BeEmpty())
+	})
+
+	Describe("#Validate", func() {
+		var defaultQuota, maxQuota int32
+
+		BeforeEach(func() {
+			defaultQuota, maxQuota = 100, 500
+		})
+
+		validate := func(ctx context.Context, value, oldValue string) field.ErrorList {
+			oldShoot := newShoot(nil)
+			if oldValue != "" {
+				oldShoot = newShoot(quotaAnnotations(oldValue, ""))
+			}
+			return verifier.Validate(ctx, newShoot(quotaAnnotations(value, "")), oldShoot, defaultQuota, maxQuota)
+		}
+		errorOfType := func(errorType field.ErrorType) types.GomegaMatcher {
+			return ConsistOf(PointTo(MatchFields(IgnoreExtras, Fields{
+				"Type":  Equal(errorType),
+				"Field": Equal("metadata.annotations[service.dns.extensions.gardener.cloud/default-external-provider-entries-quota]"),
+			})))
+		}
+
+		It("should reject malformed values", func() {
+			Expect(validate(contextForGroups("operators"), "many", "")).To(errorOfType(field.ErrorTypeInvalid))
+			Expect(validate(contextForGroups("operators"), "0", "")).To(errorOfType(field.ErrorTypeInvalid))
+			defaultQuota = 0
+			Expect(validate(contextForGroups("operators"), "-5", "")).To(errorOfType(field.ErrorTypeInvalid))
+		})
+
+		It("should allow everybody to lower the quota", func() {
+			Expect(validate(contextForGroups("project-members"), "50", "")).To(BeEmpty())
+			Expect(validate(contextForGroups("project-members"), "100", "50")).To(BeEmpty())
+		})
+
+		It("should only allow operators to raise the quota", func() {
+			Expect(validate(contextForGroups("project-members"), "200", "")).To(errorOfType(field.ErrorTypeForbidden))
+			Expect(validate(contextForGroups("operators"), "200", "")).To(BeEmpty())
+		})
+
+		It("should reject values above the maximum", func() {
+			Expect(validate(contextForGroups("operators"), "501", "")).To(errorOfType(field.ErrorTypeInvalid))
+			maxQuota = 0
+			Expect(validate(contextForGroups("operators"), "101", "")).To(errorOfType(field.ErrorTypeInvalid))
+		})
+
+		It("should not bound the value without default quota", func() {
+			defaultQuota = 0
+			Expect(validate(contextForGroups("project-members"), "100000", "")).To(BeEmpty())
+		})
+
+		It("should ignore unchanged values", func() {
+			Expect(validate(contextForGroups("project-members"), "1000", "1000")).To(BeEmpty())
+		})
+
+		It("should validate unchanged values without old object", func() {
+			Expect(verifier.Validate(contextForGroups("project-members"), newShoot(quotaAnnotations("1000", "")), nil, defaultQuota, maxQuota)).To(errorOfType(field.ErrorTypeForbidden))
+		})
+	})
+
+	Describe("#Warnings", func() {
+		It("should warn about quotas above the default quota", func() {
+			Expect(verifier.Warnings(newShoot(quotaAnnotations("200", "")), nil, 100)).To(ConsistOf(
+				"metadata.annotations[service.dns.extensions.gardener.cloud/default-external-provider-entries-quota]: the requested quota 200 is above the default quota 100"))
+		})
+
+		It("should not warn about quotas up to the default quota, malformed or unchanged values", func() {
+			Expect(verifier.Warnings(newShoot(quotaAnnotations("100", "")), nil, 100)).To(BeEmpty())
+			Expect(verifier.Warnings(newShoot(quotaAnnotations("many", "")), nil, 100)).To(BeEmpty())
+			Expect(verifier.Warnings(newShoot(quotaAnnotations("200", "")), newShoot(quotaAnnotations("200", "")), 100)).To(BeEmpty())
+		})
+
+		It("should not warn without default quota", func() {
+			Expect(verifier.Warnings(newShoot(quotaAnnotations("200", "")), nil, 0)).To(BeEmpty())
+		})
+	})
+})
@@ -0,0 +1,122 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package validator
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gardener/gardener/pkg/apis/core"
+	securityv1alpha1 "github.com/gardener/gardener/pkg/apis/security/v1alpha1"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	apisservice "github.com/gardener/gardener-extension-shoot-dns-service/pkg/apis/service"
+	"github.com/gardener/gardener-extension-shoot-dns-service/pkg/apis/service/validation"
+)
+
+// validateCredentialsBindings validates the CredentialsBindings referenced by the providers.
+// Only the CredentialsBinding of the shoot can be referenced, as Gardener does not sync other ones into the seed.
+// If checkAccess is true, the user of the admission request must be allowed to read the CredentialsBinding and,
+// if the credentials are located in another namespace, the referenced credentials.
+func (s *shoot) validateCredentialsBindings(ctx context.Context, dnsConfig *apisservice.DNSConfig, shoot *core.Shoot, checkAccess bool) (field.ErrorList, error) {
+	allErrs := field.ErrorList{}
+	path := validation.ProviderConfigPath()
+	for i, p := range dnsConfig.Providers {
+		name := ptr.Deref(p.CredentialsBindingName, "")
+		if name == "" {
+			continue
+		}
+		subPath := path.Index(i).Child("credentialsBindingName")
+		if name != ptr.Deref(shoot.Spec.CredentialsBindingName, "") {
+			allErrs = append(allErrs, field.Invalid(subPath, name, "only the CredentialsBinding of the shoot (spec.credentialsBindingName) is synced into the seed and can be referenced"))
+			continue
+		}
+		if !checkAccess {
+			continue
+		}
+
+		req, err := admission.RequestFromContext(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("could not get admission request: %w", err)
+		}
+		allowed, err := s.isAllowedToRead(ctx, req.UserInfo, &authorizationv1.ResourceAttributes{
+			Group:     securityv1alpha1.SchemeGroupVersion.Group,
+			Version:   securityv1alpha1.SchemeGroupVersion.Version,
+			Resource:  "credentialsbindings",
+			Namespace: shoot.Namespace,
+			Name:      name,
+		})
+		if err != nil {
+			return nil, err
+		}
+		if !allowed {
+			allErrs = append(allErrs, field.Forbidden(subPath, "cannot reference a CredentialsBinding you are not allowed to read"))
+			continue
+		}
+
+		binding := &securityv1alpha1.CredentialsBinding{}
+		if err := s.client.Get(ctx, client.ObjectKey{Namespace: shoot.Namespace, Name: name}, binding); err != nil {
+			// reported by the validation of the DNSConfig
+			continue
+		}
+		if binding.CredentialsRef.Namespace == shoot.Namespace {
+			continue
+		}
+		allowed, err = s.isAllowedToRead(ctx, req.UserInfo, credentialsResourceAttributes(binding.CredentialsRef))
+		if err != nil {
+			return nil, err
+		}
+		if !allowed {
+			allErrs = append(allErrs, field.Forbidden(subPath.Child("credentialsRef"),
+				fmt.Sprintf("cannot reference %s %s/%s you are not allowed to read", binding.CredentialsRef.Kind, binding.CredentialsRef.Namespace, binding.CredentialsRef.Name)))
+		}
+	}
+	return allErrs, nil
+}
+
+// isAllowedToRead checks with a SubjectAccessReview if the user is allowed to get the resource.
+func (s *shoot) isAllowedToRead(ctx context.Context, userInfo authenticationv1.UserInfo, attributes *authorizationv1.ResourceAttributes) (bool, error) {
+	extra := make(map[string]authorizationv1.ExtraValue, len(userInfo.Extra))
+	for key, value := range userInfo.Extra {
+		extra[key] = authorizationv1.ExtraValue(value)
+	}
+	attributes.Verb = "get"
+	review := &authorizationv1.SubjectAccessReview{
+		Spec: authorizationv1.SubjectAccessReviewSpec{
+			ResourceAttributes: attributes,
+			User:               userInfo.Username,
+			Groups:             userInfo.Groups,
+			UID:                userInfo.UID,
+			Extra:              extra,
+		},
+	}
+	if err := s.client.Create(ctx, review); err != nil {
+		return false, fmt.Errorf("could not authorize read request for %s %s/%s: %w", attributes.Resource, attributes.Namespace, attributes.Name, err)
+	}
+	return review.Status.Allowed, nil
+}
+
+func credentialsResourceAttributes(ref corev1.ObjectReference) *authorizationv1.ResourceAttributes {
+	attributes := &authorizationv1.ResourceAttributes{
+		Namespace: ref.Namespace,
+		Name:      ref.Name,
+	}
+	switch ref.Kind {
+	case "WorkloadIdentity":
+		attributes.Group = securityv1alpha1.SchemeGroupVersion.Group
+		attributes.Version = securityv1alpha1.SchemeGroupVersion.Version
+		attributes.Resource = "workloadidentities"
+	default:
+		attributes.Version = "v1"
+		attributes.Resource = "secrets"
+	}
+	return attributes
+}
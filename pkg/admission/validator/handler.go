@@ -0,0 +1,64 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package validator
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/gardener/gardener/pkg/apis/core"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/serializer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// WarningsValidator validates objects like extensionswebhook.Validator, but additionally returns admission warnings.
+type WarningsValidator interface {
+	ValidateWithWarnings(ctx context.Context, new, old client.Object) (admission.Warnings, error)
+}
+
+// shootHandler handles admission requests for shoots like the handler of extensionswebhook.Webhook,
+// but passes the warnings of the validator through to the admission response.
+type shootHandler struct {
+	decoder   runtime.Decoder
+	validator WarningsValidator
+}
+
+func newShootHandler(mgr manager.Manager, validator WarningsValidator) admission.Handler {
+	return &shootHandler{
+		decoder:   serializer.NewCodecFactory(mgr.GetScheme()).UniversalDecoder(),
+		validator: validator,
+	}
+}
+
+// Handle implements admission.Handler.Handle
+func (h *shootHandler) Handle(ctx context.Context, req admission.Request) admission.Response {
+	shoot := &core.Shoot{}
+	if _, _, err := h.decoder.Decode(req.Object.Raw, nil, shoot); err != nil {
+		logger.Error(err, "Could not decode request", "request", req.AdmissionRequest)
+		return admission.Errored(http.StatusBadRequest, fmt.Errorf("could not decode request %v: %w", req.AdmissionRequest, err))
+	}
+
+	var oldShoot client.Object
+	// Only UPDATE and DELETE operations have old objects.
+	if len(req.OldObject.Raw) != 0 {
+		old := &core.Shoot{}
+		if _, _, err := h.decoder.Decode(req.OldObject.Raw, nil, old); err != nil {
+			logger.Error(err, "Could not decode old object", "request", req.AdmissionRequest)
+			return admission.Errored(http.StatusBadRequest, fmt.Errorf("could not decode old object %v: %w", req.AdmissionRequest, err))
+		}
+		oldShoot = old
+	}
+
+	warnings, err := h.validator.ValidateWithWarnings(ctx, shoot, oldShoot)
+	if err != nil {
+		logger.Info("Admission denied", "kind", req.Kind.Kind, "namespace", shoot.Namespace, "name", shoot.Name, "error", fmt.Errorf("could not process: %w", err))
+		return admission.Denied(err.Error()).WithWarnings(warnings...)
+	}
+	return admission.Allowed("").WithWarnings(warnings...)
+}
@@ -0,0 +1,73 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package validator
+
+import (
+	"context"
+	"fmt"
+
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	apisservice "github.com/gardener/gardener-extension-shoot-dns-service/pkg/apis/service"
+	"github.com/gardener/gardener-extension-shoot-dns-service/pkg/service"
+)
+
+// providerReference is a DNS provider of a shoot referencing a resource in the namespace of the shoot.
+type providerReference struct {
+	shootName string
+	provider  apisservice.DNSProvider
+}
+
+// findProviderReferences returns the DNS providers of the shoots in the namespace which reference the resource of the
+// given kind and name with the fields `secretName` or `credentials` via the named resources of the shoot.
+// Shoots with disabled extension or with an invalid DNSConfig are ignored.
+func findProviderReferences(ctx context.Context, c client.Client, decoder runtime.Decoder, namespace, kind, name string) ([]providerReference, error) {
+	shoots := &gardencorev1beta1.ShootList{}
+	if err := c.List(ctx, shoots, client.InNamespace(namespace)); err != nil {
+		return nil, fmt.Errorf("failed to list shoots: %w", err)
+	}
+
+	var refs []providerReference
+	for _, shoot := range shoots.Items {
+		dnsConfig, err := extractDNSConfig(decoder, &shoot)
+		if err != nil || dnsConfig == nil {
+			continue
+		}
+		for _, provider := range dnsConfig.Providers {
+			resourceName := ptr.Deref(provider.Credentials, ptr.Deref(provider.SecretName, ""))
+			if resourceName != "" && referencesResource(shoot.Spec.Resources, resourceName, kind, name) {
+				refs = append(refs, providerReference{shootName: shoot.Name, provider: provider})
+			}
+		}
+	}
+	return refs, nil
+}
+
+// extractDNSConfig extracts the DNSConfig of the enabled shoot-dns-service extension of the shoot.
+func extractDNSConfig(decoder runtime.Decoder, shoot *gardencorev1beta1.Shoot) (*apisservice.DNSConfig, error) {
+	for _, ext := range shoot.Spec.Extensions {
+		if ext.Type != service.ExtensionType || ptr.Deref(ext.Disabled, false) || ext.ProviderConfig == nil {
+			continue
+		}
+		dnsConfig := &apisservice.DNSConfig{}
+		if _, _, err := decoder.Decode(ext.ProviderConfig.Raw, nil, dnsConfig); err != nil {
+			return nil, fmt.Errorf("failed to decode %s provider config: %w", ext.Type, err)
+		}
+		return dnsConfig, nil
+	}
+	return nil, nil
+}
+
+func referencesResource(resources []gardencorev1beta1.NamedResourceReference, resourceName, kind, name string) bool {
+	for _, res := range resources {
+		if res.Name == resourceName {
+			return res.ResourceRef.Kind == kind && res.ResourceRef.Name == name
+		}
+	}
+	return false
+}
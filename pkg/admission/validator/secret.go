@@ -0,0 +1,75 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package validator
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"reflect"
+
+	extensionswebhook "github.com/gardener/gardener/extensions/pkg/webhook"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/serializer"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+
+	"github.com/gardener/gardener-extension-shoot-dns-service/pkg/apis/service/validation"
+)
+
+type secret struct {
+	decoder runtime.Decoder
+	client  client.Client
+}
+
+// NewSecretValidator returns a new instance of a validator for secrets referenced by DNS providers of shoots.
+func NewSecretValidator(mgr manager.Manager) extensionswebhook.Validator {
+	return &secret{
+		decoder: serializer.NewCodecFactory(mgr.GetScheme()).UniversalDecoder(),
+		client:  mgr.GetClient(),
+	}
+}
+
+// Validate checks whether the updated data of the given secret is still valid for all DNS providers of the shoots in
+// the same namespace referencing it.
+// Secrets are validated on creation of the referencing shoots, and changes of the metadata only are not validated
+// to never block Gardener from maintaining labels and finalizers.
+func (s *secret) Validate(ctx context.Context, newObj, oldObj client.Object) error {
+	newSecret, ok := newObj.(*corev1.Secret)
+	if !ok {
+		return fmt.Errorf("wrong object type %T", newObj)
+	}
+	if oldObj == nil {
+		return nil
+	}
+	oldSecret, ok := oldObj.(*corev1.Secret)
+	if !ok {
+		return fmt.Errorf("wrong object type %T for old object", oldObj)
+	}
+	if reflect.DeepEqual(newSecret.Data, oldSecret.Data) || os.Getenv("DISABLE_SECRET_VALIDATION") == "true" {
+		return nil
+	}
+
+	refs, err := findProviderReferences(ctx, s.client, s.decoder, newSecret.Namespace, "Secret", newSecret.Name)
+	if err != nil {
+		return fmt.Errorf("failed to find shoots referencing the secret: %w", err)
+	}
+
+	allErrs := field.ErrorList{}
+	for _, ref := range refs {
+		providerType := ptr.Deref(ref.provider.Type, "")
+		if providerType == "" {
+			continue
+		}
+		if err := validation.ValidateSecretCredentials(newSecret, providerType, ref.provider.ProviderConfig); err != nil {
+			allErrs = append(allErrs, field.Invalid(field.NewPath("data"), field.OmitValueType{},
+				fmt.Sprintf("invalid for DNS provider of type %s of shoot %s: %s", providerType, ref.shootName, err)))
+		}
+	}
+	return allErrs.ToAggregate()
+}
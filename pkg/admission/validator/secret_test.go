@@ -0,0 +1,135 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package validator_test
+
+import (
+	"context"
+
+	extensionswebhook "github.com/gardener/gardener/extensions/pkg/webhook"
+	"github.com/gardener/gardener/pkg/apis/core/install"
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	"github.com/gardener/gardener/pkg/utils/test"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	autoscalingv1 "k8s.io/api/autoscaling/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/gardener/gardener-extension-shoot-dns-service/pkg/admission/validator"
+	serviceinstall "github.com/gardener/gardener-extension-shoot-dns-service/pkg/apis/service/install"
+)
+
+var _ = Describe("Secret validator", func() {
+	var (
+		ctx             = context.Background()
+		fakeClient      client.Client
+		secretValidator extensionswebhook.Validator
+
+		oldSecret *corev1.Secret
+		newSecret *corev1.Secret
+
+		shootFunc = func(name, providerType string, disabled bool) *gardencorev1beta1.Shoot {
+			return &gardencorev1beta1.Shoot{
+				ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "garden-project"},
+				Spec: gardencorev1beta1.ShootSpec{
+					Extensions: []gardencorev1beta1.Extension{
+						{
+							Type:     "shoot-dns-service",
+							Disabled: ptr.To(disabled),
+							ProviderConfig: &runtime.RawExtension{Raw: []byte(`apiVersion: service.dns.extensions.gardener.cloud/v1alpha1
+kind: DNSConfig
+providers:
+- secretName: dns-secret
+  type: ` + providerType + `
+syncProvidersFromShootSpecDNS: false
+`)},
+						},
+					},
+					Resources: []gardencorev1beta1.NamedResourceReference{
+						{
+							Name: "dns-secret",
+							ResourceRef: autoscalingv1.CrossVersionObjectReference{
+								Kind:       "Secret",
+								Name:       "my-dns-credentials",
+								APIVersion: "v1",
+							},
+						},
+					},
+				},
+			}
+		}
+	)
+
+	BeforeEach(func() {
+		mgrScheme := runtime.NewScheme()
+		install.Install(mgrScheme)
+		serviceinstall.Install(mgrScheme)
+		utilruntime.Must(scheme.AddToScheme(mgrScheme))
+		fakeClient = fakeclient.NewClientBuilder().WithScheme(mgrScheme).Build()
+		secretValidator = validator.NewSecretValidator(&test.FakeManager{Scheme: mgrScheme, Client: fakeClient})
+
+		oldSecret = &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "my-dns-credentials", Namespace: "garden-project"},
+			Data: map[string][]byte{
+				"accessKeyID":     []byte("myAccessKeyID"),
+				"secretAccessKey": []byte("mySecretAccessKey"),
+			},
+		}
+		newSecret = oldSecret.DeepCopy()
+		newSecret.Data["badKey"] = []byte("foo")
+	})
+
+	It("should not validate the creation of a secret", func() {
+		Expect(fakeClient.Create(ctx, shootFunc("shoot", "aws-route53", false))).To(Succeed())
+
+		Expect(secretValidator.Validate(ctx, newSecret, nil)).To(Succeed())
+	})
+
+	It("should not validate updates of the metadata only", func() {
+		Expect(fakeClient.Create(ctx, shootFunc("shoot", "aws-route53", false))).To(Succeed())
+		oldSecret.Data["badKey"] = []byte("foo")
+		newSecret.Labels = map[string]string{"foo": "bar"}
+
+		Expect(secretValidator.Validate(ctx, newSecret, oldSecret)).To(Succeed())
+	})
+
+	It("should allow valid updates of a referenced secret", func() {
+		Expect(fakeClient.Create(ctx, shootFunc("shoot", "aws-route53", false))).To(Succeed())
+		newSecret = oldSecret.DeepCopy()
+		newSecret.Data["secretAccessKey"] = []byte("myNewSecretAccessKey")
+
+		Expect(secretValidator.Validate(ctx, newSecret, oldSecret)).To(Succeed())
+	})
+
+	It("should ignore shoots with disabled extension", func() {
+		Expect(fakeClient.Create(ctx, shootFunc("disabled", "aws-route53", true))).To(Succeed())
+
+		Expect(secretValidator.Validate(ctx, newSecret, oldSecret)).To(Succeed())
+	})
+
+	It("should allow invalid updates of a secret which is not referenced", func() {
+		Expect(fakeClient.Create(ctx, shootFunc("shoot", "aws-route53", false))).To(Succeed())
+		oldSecret.Name = "other"
+		newSecret.Name = "other"
+
+		Expect(secretValidator.Validate(ctx, newSecret, oldSecret)).To(Succeed())
+	})
+
+	It("should reject invalid updates of a referenced secret and list the affected shoots", func() {
+		Expect(fakeClient.Create(ctx, shootFunc("shoot-a", "aws-route53", false))).To(Succeed())
+		Expect(fakeClient.Create(ctx, shootFunc("shoot-b", "aws-route53", false))).To(Succeed())
+
+		err := secretValidator.Validate(ctx, newSecret, oldSecret)
+		Expect(err).To(MatchError(
+			"[data: Invalid value: invalid for DNS provider of type aws-route53 of shoot shoot-a: validation failed for provider type aws-route53: property \"badKey\" is not allowed, " +
+				"data: Invalid value: invalid for DNS provider of type aws-route53 of shoot shoot-b: validation failed for provider type aws-route53: property \"badKey\" is not allowed]"))
+	})
+})
@@ -0,0 +1,46 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package validator
+
+import (
+	extensionswebhook "github.com/gardener/gardener/extensions/pkg/webhook"
+	v1beta1constants "github.com/gardener/gardener/pkg/apis/core/v1beta1/constants"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+)
+
+const (
+	// SecretValidatorName is the name of the validation webhook for secrets referenced by DNS providers.
+	SecretValidatorName = "validator-secret"
+	// SecretValidatorPath is the path of the validation webhook for secrets referenced by DNS providers.
+	SecretValidatorPath = "/webhooks/validate-secret"
+)
+
+// NewSecretWebhook creates a new webhook that validates secrets in project namespaces referenced by DNS providers of shoots.
+func NewSecretWebhook(mgr manager.Manager) (*extensionswebhook.Webhook, error) {
+	logger.Info("Setting up webhook", "name", SecretValidatorName)
+
+	wh, err := extensionswebhook.New(mgr, extensionswebhook.Args{
+		Name: SecretValidatorName,
+		Path: SecretValidatorPath,
+		Validators: map[extensionswebhook.Validator][]extensionswebhook.Type{
+			NewSecretValidator(mgr): {{Obj: &corev1.Secret{}}},
+		},
+		Target: extensionswebhook.TargetSeed,
+		// Secrets referenced by shoots are not labeled, therefore all secrets in project namespaces are considered.
+		NamespaceSelector: &metav1.LabelSelector{
+			MatchLabels: map[string]string{v1beta1constants.GardenRole: v1beta1constants.GardenRoleProject},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	// an unavailable webhook must not block updates of all secrets in project namespaces
+	wh.FailurePolicy = ptr.To(admissionregistrationv1.Ignore)
+	return wh, nil
+}
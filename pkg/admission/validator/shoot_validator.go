@@ -8,64 +8,97 @@ import (
 	"context"
 	"fmt"
 	"reflect"
+	"slices"
 
 	"github.com/gardener/external-dns-management/pkg/dnsman2/apis/config"
 	extensionswebhook "github.com/gardener/gardener/extensions/pkg/webhook"
 	"github.com/gardener/gardener/pkg/apis/core"
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	v1beta1constants "github.com/gardener/gardener/pkg/apis/core/v1beta1/constants"
 	securityv1alpha1 "github.com/gardener/gardener/pkg/apis/security/v1alpha1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/serializer"
 	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
+	"github.com/gardener/gardener-extension-shoot-dns-service/pkg/admission/quota"
+	policyv1alpha1 "github.com/gardener/gardener-extension-shoot-dns-service/pkg/apis/policy/v1alpha1"
+	policyhelper "github.com/gardener/gardener-extension-shoot-dns-service/pkg/apis/policy/v1alpha1/helper"
 	apisservice "github.com/gardener/gardener-extension-shoot-dns-service/pkg/apis/service"
 	"github.com/gardener/gardener-extension-shoot-dns-service/pkg/apis/service/validation"
+	controllerconfig "github.com/gardener/gardener-extension-shoot-dns-service/pkg/controller/config"
 	"github.com/gardener/gardener-extension-shoot-dns-service/pkg/service"
 )
 
 // NewShootValidator returns a new instance of a shoot validator.
 // The parameter gcpConfig is used to validate the GCP Workload Identity configuration in the DNSConfig if present.
-func NewShootValidator(mgr manager.Manager, gcpConfig config.InternalGCPWorkloadIdentityConfig) extensionswebhook.Validator {
+// The parameter providerTypes restricts the allowed provider types. If nil, all supported provider types are allowed.
+// The parameter quotaVerifier bounds the DNS entries quota requested by the shoot annotation to the limits of the
+// quotaConfig, which are the same as applied by the lifecycle controller.
+func NewShootValidator(mgr manager.Manager, gcpConfig config.InternalGCPWorkloadIdentityConfig, providerTypes *validation.ProviderTypeRestrictions,
+	quotaVerifier quota.Verifier, quotaConfig controllerconfig.DNSServiceConfig) ShootValidator {
 	return &shoot{
-		decoder:   serializer.NewCodecFactory(mgr.GetScheme()).UniversalDecoder(),
-		client:    mgr.GetClient(),
-		gcpConfig: gcpConfig,
+		decoder:       serializer.NewCodecFactory(mgr.GetScheme()).UniversalDecoder(),
+		client:        mgr.GetClient(),
+		gcpConfig:     gcpConfig,
+		providerTypes: providerTypes,
+		quotaVerifier: quotaVerifier,
+		quotaConfig:   quotaConfig,
 	}
 }
 
+// ShootValidator validates shoots and returns the admission warnings of the DNS configuration.
+type ShootValidator interface {
+	extensionswebhook.Validator
+	WarningsValidator
+}
+
 // shoot validates shoots
 type shoot struct {
-	decoder   runtime.Decoder
-	client    client.Client
-	gcpConfig config.InternalGCPWorkloadIdentityConfig
+	decoder       runtime.Decoder
+	client        client.Client
+	gcpConfig     config.InternalGCPWorkloadIdentityConfig
+	providerTypes *validation.ProviderTypeRestrictions
+	quotaVerifier quota.Verifier
+	quotaConfig   controllerconfig.DNSServiceConfig
 }
 
 // Validate implements extensionswebhook.Validator.Validate
 func (s *shoot) Validate(ctx context.Context, new, old client.Object) error {
+	_, err := s.ValidateWithWarnings(ctx, new, old)
+	return err
+}
+
+// ValidateWithWarnings implements WarningsValidator.ValidateWithWarnings
+func (s *shoot) ValidateWithWarnings(ctx context.Context, new, old client.Object) (admission.Warnings, error) {
 	shoot, ok := new.(*core.Shoot)
 	if !ok {
-		return fmt.Errorf("wrong object type %T", new)
+		return nil, fmt.Errorf("wrong object type %T", new)
 	}
 	var oldShoot *core.Shoot
 	if old != nil {
 		oldShoot, ok = old.(*core.Shoot)
 		if !ok {
-			return fmt.Errorf("wrong object type %T (old)", old)
+			return nil, fmt.Errorf("wrong object type %T (old)", old)
 		}
 	}
 
 	return s.validateShoot(ctx, shoot, oldShoot)
 }
 
-func (s *shoot) validateShoot(ctx context.Context, shoot, oldShoot *core.Shoot) error {
+func (s *shoot) validateShoot(ctx context.Context, shoot, oldShoot *core.Shoot) (admission.Warnings, error) {
 	if s.isDisabled(shoot) {
-		return nil
+		return nil, nil
 	}
 	dnsConfig, err := s.extractDNSConfig(shoot)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	var oldDnsConfig *apisservice.DNSConfig
 	if oldShoot != nil {
@@ -76,18 +109,157 @@ func (s *shoot) validateShoot(ctx context.Context, shoot, oldShoot *core.Shoot)
 		}
 	}
 
-	allErrs := field.ErrorList{}
+	warnings, allErrs, err := s.validateQuota(ctx, shoot, oldShoot)
+	if err != nil {
+		return nil, err
+	}
 	if dnsConfig != nil {
-		var getter validation.ResourceGetter
+		var (
+			getter               validation.ResourceGetter
+			allowedProviderTypes []string
+		)
 		if hasChanged := oldDnsConfig == nil || !reflect.DeepEqual(dnsConfig, oldDnsConfig); hasChanged {
-			// If the DNSConfig has changed, we want to validate the secrets.
-			// Otherwise, we skip the secret validation to avoid shoot manifests updates to fail due to an unrelated changed secret.
+			// If the DNSConfig has changed, we want to validate the secrets and the provider type restrictions.
+			// Otherwise, we skip them to avoid shoot manifests updates to fail due to an unrelated changed secret or tightened restrictions.
 			getter = s.makeResourceGetter(ctx, shoot.Namespace)
+			allowedProviderTypes, err = s.allowedProviderTypes(ctx, shoot)
+			if err != nil {
+				return nil, err
+			}
+			// The domain and zone selections are only validated on changes to not block unrelated updates of existing shoots.
+			allErrs = append(allErrs, validation.ValidateDomainSelections(dnsConfig, shootDomain(shoot))...)
+		}
+		dnsConfigWarnings, dnsConfigErrs := validation.ValidateDNSConfig(dnsConfig, shootDomain(shoot), &shoot.Spec.Resources, getter, allowedProviderTypes)
+		warnings = append(warnings, dnsConfigWarnings...)
+		allErrs = append(allErrs, dnsConfigErrs...)
+		credentialsBindingErrs, err := s.validateCredentialsBindings(ctx, dnsConfig, shoot, getter != nil)
+		if err != nil {
+			return nil, err
 		}
-		allErrs = append(allErrs, validation.ValidateDNSConfig(dnsConfig, &shoot.Spec.Resources, getter)...)
+		allErrs = append(allErrs, credentialsBindingErrs...)
+		warnings = append(warnings, s.shootWarnings(ctx, dnsConfig, shoot)...)
 	}
 
-	return allErrs.ToAggregate()
+	return warnings, allErrs.ToAggregate()
+}
+
+// validateQuota validates the shoot annotation requesting a DNS entries quota for the default external provider.
+// The annotation is validated if it was changed or if the extension was not enabled for the old shoot, as the webhook
+// does not see changes of shoots without the extension.
+func (s *shoot) validateQuota(ctx context.Context, shoot, oldShoot *core.Shoot) (admission.Warnings, field.ErrorList, error) {
+	if shoot.Annotations[service.DefaultExternalProviderEntriesQuotaAnnotation] == "" {
+		return nil, field.ErrorList{}, nil
+	}
+	var oldObj metav1.Object
+	if oldShoot != nil && s.isEnabled(oldShoot) {
+		oldObj = oldShoot
+	}
+	var project string
+	if s.quotaConfig.QuotaRulesSelectProjects() {
+		var err error
+		if project, err = s.projectName(ctx, shoot.Namespace); err != nil {
+			return nil, nil, err
+		}
+	}
+	limits, err := s.quotaConfig.DefaultExternalProviderQuotaLimits(project, selectorShoot(shoot))
+	if err != nil {
+		return nil, nil, err
+	}
+	if errs := s.quotaVerifier.Validate(ctx, shoot, oldObj, limits.Quota, limits.QuotaMax); len(errs) > 0 {
+		return nil, errs, nil
+	}
+	return s.quotaVerifier.Warnings(shoot, oldObj, limits.Quota), field.ErrorList{}, nil
+}
+
+// shootWarnings returns warnings for settings of the DNSConfig which are overridden or deprecated by the shoot or its seed.
+func (s *shoot) shootWarnings(ctx context.Context, dnsConfig *apisservice.DNSConfig, shoot *core.Shoot) admission.Warnings {
+	var warnings admission.Warnings
+	path := validation.ProviderConfigPath()
+
+	if ptr.Deref(dnsConfig.SyncProvidersFromShootSpecDNS, false) && shoot.Spec.DNS != nil &&
+		slices.ContainsFunc(shoot.Spec.DNS.Providers, func(p core.DNSProvider) bool { return !ptr.Deref(p.Primary, false) }) {
+		warnings = append(warnings, fmt.Sprintf("%s: additional providers are synchronised from the deprecated section spec.dns.providers, "+
+			"please set the field to false and configure them in the providerConfig", path.Child("syncProvidersFromShootSpecDNS")))
+	}
+
+	if dnsConfig.UseNextGenerationController != nil && !*dnsConfig.UseNextGenerationController && shoot.Spec.SeedName != nil {
+		// the seed is only needed for the warning, failing to get it must not block the shoot
+		seed := &gardencorev1beta1.Seed{}
+		if err := s.client.Get(ctx, client.ObjectKey{Name: *shoot.Spec.SeedName}, seed); err == nil &&
+			seed.Labels[service.UseNextGenerationControllerSeedLabel] == "force-true" {
+			warnings = append(warnings, fmt.Sprintf("%s: the next generation DNS controller is forced by seed %s, the setting is ignored",
+				path.Child("useNextGenerationController"), *shoot.Spec.SeedName))
+		}
+	}
+	return warnings
+}
+
+// shootDomain returns the domain of the shoot, which is served by the default provider or by the primary provider.
+func shootDomain(shoot *core.Shoot) string {
+	if shoot.Spec.DNS == nil {
+		return ""
+	}
+	return ptr.Deref(shoot.Spec.DNS.Domain, "")
+}
+
+// allowedProviderTypes returns the provider types allowed for the shoot.
+// Rules selecting seeds by labels are only considered if the shoot is already scheduled.
+// The allowed types are restricted further by the rule of the ShootDNSServicePolicies applying to the shoot, like in
+// the lifecycle controller.
+func (s *shoot) allowedProviderTypes(ctx context.Context, shoot *core.Shoot) ([]string, error) {
+	var seedLabels map[string]string
+	if s.providerTypes.HasSeedRules() && shoot.Spec.SeedName != nil {
+		seed := &gardencorev1beta1.Seed{}
+		if err := s.client.Get(ctx, client.ObjectKey{Name: *shoot.Spec.SeedName}, seed); err != nil {
+			return nil, fmt.Errorf("failed to get seed %s: %w", *shoot.Spec.SeedName, err)
+		}
+		seedLabels = seed.Labels
+	}
+	policies := &policyv1alpha1.ShootDNSServicePolicyList{}
+	// if the CRD is not installed, there are no policies
+	if err := s.client.List(ctx, policies); err != nil && !meta.IsNoMatchError(err) {
+		return nil, fmt.Errorf("failed to list ShootDNSServicePolicies: %w", err)
+	}
+	var project string
+	if s.providerTypes.HasProjectRules() || len(policies.Items) > 0 {
+		var err error
+		if project, err = s.projectName(ctx, shoot.Namespace); err != nil {
+			return nil, err
+		}
+	}
+	allowed := s.providerTypes.EffectiveProviderTypes(project, seedLabels)
+
+	_, rule, err := policyhelper.FindRule(policies.Items, project, selectorShoot(shoot))
+	if err != nil {
+		logger.Error(err, "Ignoring invalid rules of ShootDNSServicePolicies")
+	}
+	if rule == nil {
+		return allowed, nil
+	}
+	return validation.RestrictProviderTypes(allowed, rule.Settings.AllowedProviderTypes), nil
+}
+
+// selectorShoot returns the fields of the shoot selected by quota rules and policies.
+func selectorShoot(shoot *core.Shoot) *gardencorev1beta1.Shoot {
+	return &gardencorev1beta1.Shoot{
+		ObjectMeta: metav1.ObjectMeta{Labels: shoot.Labels},
+		Spec:       gardencorev1beta1.ShootSpec{Purpose: (*gardencorev1beta1.ShootPurpose)(shoot.Spec.Purpose)},
+	}
+}
+
+// projectName returns the name of the project owning the namespace.
+// It is read from the project label of the namespace, as projects may use a custom namespace (spec.namespace).
+func (s *shoot) projectName(ctx context.Context, namespace string) (string, error) {
+	ns := &corev1.Namespace{}
+	if err := s.client.Get(ctx, client.ObjectKey{Name: namespace}, ns); err != nil {
+		return "", fmt.Errorf("failed to get namespace %s: %w", namespace, err)
+	}
+	return ns.Labels[v1beta1constants.ProjectName], nil
+}
+
+// isEnabled returns true if the shoot has the extension label and the extension is not disabled.
+func (s *shoot) isEnabled(shoot *core.Shoot) bool {
+	return shoot.Labels[service.ExtensionLabel] == "true" && !s.isDisabled(shoot)
 }
 
 // isDisabled returns true if extension is explicitly disabled.
@@ -145,6 +317,21 @@ func (r *resourceGetter) GetWorkloadIdentity(name string) (*securityv1alpha1.Wor
 	return wl, err
 }
 
+func (r *resourceGetter) GetCredentialsBinding(name string) (*securityv1alpha1.CredentialsBinding, error) {
+	binding := &securityv1alpha1.CredentialsBinding{}
+	err := r.client.Get(r.ctx, client.ObjectKey{Namespace: r.namespace, Name: name}, binding)
+	return binding, err
+}
+
+func (r *resourceGetter) InNamespace(namespace string) validation.ResourceGetter {
+	return &resourceGetter{
+		ctx:                               r.ctx,
+		client:                            r.client,
+		namespace:                         namespace,
+		internalGCPWorkloadIdentityConfig: r.internalGCPWorkloadIdentityConfig,
+	}
+}
+
 func (r *resourceGetter) GetInternalGCPWorkloadIdentityConfig() config.InternalGCPWorkloadIdentityConfig {
 	return r.internalGCPWorkloadIdentityConfig
 }
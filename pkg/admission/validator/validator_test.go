@@ -7,30 +7,44 @@ package validator_test
 import (
 	"context"
 	"regexp"
+	"strings"
 
 	"github.com/gardener/external-dns-management/pkg/dnsman2/apis/config"
 	extensionswebhook "github.com/gardener/gardener/extensions/pkg/webhook"
 	gardencore "github.com/gardener/gardener/pkg/apis/core"
 	"github.com/gardener/gardener/pkg/apis/core/install"
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	v1beta1constants "github.com/gardener/gardener/pkg/apis/core/v1beta1/constants"
 	securityv1alpha1 "github.com/gardener/gardener/pkg/apis/security/v1alpha1"
 	"github.com/gardener/gardener/pkg/utils/test"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	gomegatypes "github.com/onsi/gomega/types"
+	admissionv1 "k8s.io/api/admission/v1"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
 	v1 "k8s.io/api/autoscaling/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	"k8s.io/client-go/kubernetes/scheme"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 	"sigs.k8s.io/yaml"
 
+	"github.com/gardener/gardener-extension-shoot-dns-service/pkg/admission/quota"
 	admissionvalidator "github.com/gardener/gardener-extension-shoot-dns-service/pkg/admission/validator"
+	policyv1alpha1 "github.com/gardener/gardener-extension-shoot-dns-service/pkg/apis/policy/v1alpha1"
 	serviceinstall "github.com/gardener/gardener-extension-shoot-dns-service/pkg/apis/service/install"
+	"github.com/gardener/gardener-extension-shoot-dns-service/pkg/apis/service/validation"
+	controllerconfig "github.com/gardener/gardener-extension-shoot-dns-service/pkg/controller/config"
+	"github.com/gardener/gardener-extension-shoot-dns-service/pkg/service"
 )
 
 var _ = Describe("Shoot Validator", func() {
@@ -60,6 +74,13 @@ providers:
 - credentials: shoot-dns-service-my-gcp-wl-bad
   type: google-clouddns
 syncProvidersFromShootSpecDNS: false
+`)
+		dnsConfigDeniedType = []byte(`apiVersion: service.dns.extensions.gardener.cloud/v1alpha1
+kind: DNSConfig
+providers:
+- secretName: shoot-dns-service-my-secret-good
+  type: netlify-dns
+syncProvidersFromShootSpecDNS: false
 `)
 		dnsConfigBad = []byte(`apiVersion: service.dns.extensions.gardener.cloud/v1alpha1
 kind: DNSConfig
@@ -68,7 +89,13 @@ providers:
   type: aws-route53
 syncProvidersFromShootSpecDNS: false
 `)
-		ctx                    = context.Background()
+		ctx              = context.Background()
+		allowedReads     map[string]bool
+		contextForGroups = func(groups ...string) context.Context {
+			return admission.NewContextWithRequest(ctx, admission.Request{
+				AdmissionRequest: admissionv1.AdmissionRequest{UserInfo: authenticationv1.UserInfo{Username: "user", Groups: groups}},
+			})
+		}
 		secretNameGood         = "my-secret-good"
 		secretNameBad          = "my-secret-bad"
 		secretMappedNameGood   = "shoot-dns-service-my-secret-good"
@@ -82,6 +109,7 @@ syncProvidersFromShootSpecDNS: false
 				ObjectMeta: metav1.ObjectMeta{
 					Name:      "shoot",
 					Namespace: "test",
+					Labels:    map[string]string{service.ExtensionLabel: "true"},
 				},
 				Spec: gardencore.ShootSpec{
 					Extensions: []gardencore.Extension{
@@ -129,6 +157,11 @@ syncProvidersFromShootSpecDNS: false
 				},
 			}
 		}
+		shootOnSeedFunc = func(raw []byte, seedName string) *gardencore.Shoot {
+			shoot := shootFunc(raw)
+			shoot.Spec.SeedName = &seedName
+			return shoot
+		}
 		wlProviderConfigGood = `
 apiVersion: gcp.provider.extensions.gardener.cloud/v1alpha1
 kind: WorkloadIdentityConfig
@@ -181,8 +214,19 @@ credentialsConfig:
 		serviceinstall.Install(mgrScheme)
 		utilruntime.Must(scheme.AddToScheme(mgrScheme))
 		utilruntime.Must(securityv1alpha1.AddToScheme(mgrScheme))
+		utilruntime.Must(policyv1alpha1.AddToScheme(mgrScheme))
 
-		fakeClient = fakeclient.NewClientBuilder().WithScheme(mgrScheme).Build()
+		allowedReads = map[string]bool{}
+		fakeClient = fakeclient.NewClientBuilder().WithScheme(mgrScheme).WithInterceptorFuncs(interceptor.Funcs{
+			Create: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+				if review, ok := obj.(*authorizationv1.SubjectAccessReview); ok {
+					attributes := review.Spec.ResourceAttributes
+					review.Status.Allowed = allowedReads[attributes.Resource+" "+attributes.Namespace+"/"+attributes.Name]
+					return nil
+				}
+				return c.Create(ctx, obj, opts...)
+			},
+		}).Build()
 		mgr = &test.FakeManager{
 			Scheme: mgrScheme,
 			Client: fakeClient,
@@ -210,9 +254,26 @@ credentialsConfig:
 		})).To(Succeed())
 		Expect(fakeClient.Create(ctx, createWorkloadIdentity("test", secretNameGoodWL, wlProviderConfigGood))).To(Succeed())
 		Expect(fakeClient.Create(ctx, createWorkloadIdentity("test", secretNameBadWL, wlProviderConfigBad))).To(Succeed())
+		Expect(fakeClient.Create(ctx, &gardencorev1beta1.Seed{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   "restricted-seed",
+				Labels: map[string]string{"environment": "restricted"},
+			},
+		})).To(Succeed())
 		validator = admissionvalidator.NewShootValidator(mgr, config.InternalGCPWorkloadIdentityConfig{
 			AllowedTokenURLs: []string{"https://sts.googleapis.com/v1/token", "https://sts.googleapis.com/v1/token/new"},
 			AllowedServiceAccountImpersonationURLRegExps: []*regexp.Regexp{regexp.MustCompile(`^https://iamcredentials\.googleapis\.com/v1/projects/-/serviceAccounts/.+:generateAccessToken$`)},
+		}, &validation.ProviderTypeRestrictions{
+			Denied: []string{"netlify-dns"},
+			Rules: []validation.ProviderTypeRule{
+				{
+					SeedSelector: labels.SelectorFromSet(labels.Set{"environment": "restricted"}),
+					Allowed:      []string{"google-clouddns"},
+				},
+			},
+		}, quota.Verifier{OperatorGroups: []string{"operators"}}, controllerconfig.DNSServiceConfig{
+			DefaultExternalProviderEntriesQuota:    100,
+			DefaultExternalProviderEntriesQuotaMax: 500,
 		})
 	})
 
@@ -230,6 +291,12 @@ credentialsConfig:
 		Entry("update good to bad", shootFunc(dnsConfigBad), shootFunc(dnsConfigGood),
 			MatchError("spec.extensions.[@.type='shoot-dns-service'].providerConfig[0].secretName.ref: Invalid value: \"my-secret-bad\": validation of secret data or provider config failed: validation failed for provider type aws-route53: property \"badKey\" is not allowed")),
 		Entry("update bad to good", shootFunc(dnsConfigGood), shootFunc(dnsConfigBad), Succeed()),
+		Entry("create denied provider type", shootFunc(dnsConfigDeniedType), nil,
+			MatchError("spec.extensions.[@.type='shoot-dns-service'].providerConfig[0].type: Invalid value: \"netlify-dns\": provider type is not allowed. Allowed types are: alicloud-dns, aws-route53, azure-dns, azure-private-dns, cloudflare-dns, gdch-dns, google-clouddns, infoblox-dns, openstack-designate, powerdns, remote, rfc2136")),
+		Entry("update unchanged denied provider type", shootFunc(dnsConfigDeniedType), shootFunc(dnsConfigDeniedType), Succeed()),
+		Entry("create good on restricted seed", shootOnSeedFunc(dnsConfigGood, "restricted-seed"), nil,
+			MatchError("spec.extensions.[@.type='shoot-dns-service'].providerConfig[0].type: Invalid value: \"aws-route53\": provider type is not allowed. Allowed types are: google-clouddns")),
+		Entry("create good provider with workload identity on restricted seed", shootOnSeedFunc(dnsConfigGoodWorkloadIdentity, "restricted-seed"), nil, Succeed()),
 		Entry("create good provider with workload identity", shootFunc(dnsConfigGoodWorkloadIdentity), nil, Succeed()),
 		Entry("create bad provider with workload identity", shootFunc(dnsConfigBadWorkloadIdentity), nil,
 			MatchError(SatisfyAll(
@@ -237,4 +304,326 @@ credentialsConfig:
 				ContainSubstring("spec.extensions.[@.type='shoot-dns-service'].providerConfig[0].credentials.ref.credentialsConfig.service_account_impersonation_url: Invalid value: \"https://iamcredentials.foreign.com/v1/projects/-/serviceAccounts/foo@bar.example:generateAccessToken\": should match one of the allowed regular expressions: ^https://iamcredentials\\.googleapis\\.com/v1/projects/-/serviceAccounts/.+:generateAccessToken$]"),
 			))),
 	)
+
+	Describe("#Validate provider types of projects", func() {
+		BeforeEach(func() {
+			// the project uses a custom namespace, which does not follow the pattern garden-<project>
+			Expect(fakeClient.Create(ctx, &corev1.Namespace{
+				ObjectMeta: metav1.ObjectMeta{Name: "test", Labels: map[string]string{v1beta1constants.ProjectName: "restricted"}},
+			})).To(Succeed())
+			validator = admissionvalidator.NewShootValidator(mgr, config.InternalGCPWorkloadIdentityConfig{}, &validation.ProviderTypeRestrictions{
+				Rules: []validation.ProviderTypeRule{
+					{Project: "restricted", Allowed: []string{"google-clouddns"}},
+				},
+			}, quota.Verifier{}, controllerconfig.DNSServiceConfig{})
+		})
+
+		It("should apply the rule of the project owning the namespace", func() {
+			Expect(validator.Validate(ctx, shootFunc(dnsConfigGood), nil)).To(MatchError(
+				"spec.extensions.[@.type='shoot-dns-service'].providerConfig[0].type: Invalid value: \"aws-route53\": provider type is not allowed. Allowed types are: google-clouddns"))
+		})
+	})
+
+	Describe("#Validate provider types of policies", func() {
+		BeforeEach(func() {
+			Expect(fakeClient.Create(ctx, &corev1.Namespace{
+				ObjectMeta: metav1.ObjectMeta{Name: "test", Labels: map[string]string{v1beta1constants.ProjectName: "test"}},
+			})).To(Succeed())
+			Expect(fakeClient.Create(ctx, &policyv1alpha1.ShootDNSServicePolicy{
+				ObjectMeta: metav1.ObjectMeta{Name: "policy"},
+				Spec: policyv1alpha1.ShootDNSServicePolicySpec{
+					Rules: []policyv1alpha1.ShootDNSServicePolicyRule{
+						{
+							Name:     "test",
+							Selector: policyv1alpha1.ShootSelector{Projects: []string{"test"}},
+							Settings: policyv1alpha1.ShootDNSServiceSettings{AllowedProviderTypes: []string{"google-clouddns", "netlify-dns"}},
+						},
+					},
+				},
+			})).To(Succeed())
+		})
+
+		It("should restrict the allowed types to the ones of the policy", func() {
+			Expect(validator.Validate(ctx, shootFunc(dnsConfigGood), nil)).To(MatchError(
+				"spec.extensions.[@.type='shoot-dns-service'].providerConfig[0].type: Invalid value: \"aws-route53\": provider type is not allowed. Allowed types are: google-clouddns"))
+		})
+
+		It("should not validate the provider types of unchanged DNS configurations", func() {
+			shoot := shootFunc(dnsConfigGood)
+			Expect(validator.Validate(ctx, shoot, shoot)).To(Succeed())
+		})
+	})
+
+	Describe("#Validate credentials binding", func() {
+		var (
+			dnsConfigCredentialsBinding = []byte(`apiVersion: service.dns.extensions.gardener.cloud/v1alpha1
+kind: DNSConfig
+providers:
+- credentialsBindingName: shared-dns-binding
+  type: aws-route53
+syncProvidersFromShootSpecDNS: false
+`)
+			shootWithCredentialsBinding = func(bindingName string) *gardencore.Shoot {
+				shoot := shootFunc(dnsConfigCredentialsBinding)
+				shoot.Spec.CredentialsBindingName = &bindingName
+				return shoot
+			}
+			bindingField = "spec.extensions.[@.type='shoot-dns-service'].providerConfig[0].credentialsBindingName"
+		)
+
+		BeforeEach(func() {
+			Expect(fakeClient.Create(ctx, &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "shared-dns", Namespace: "shared"},
+				Data: map[string][]byte{
+					"accessKeyID":     []byte("myaccessKeyID"),
+					"secretAccessKey": []byte("mysecretAccessKey"),
+				},
+			})).To(Succeed())
+			Expect(fakeClient.Create(ctx, &securityv1alpha1.CredentialsBinding{
+				ObjectMeta:     metav1.ObjectMeta{Name: "shared-dns-binding", Namespace: "test"},
+				Provider:       securityv1alpha1.CredentialsBindingProvider{Type: "aws"},
+				CredentialsRef: corev1.ObjectReference{Kind: "Secret", APIVersion: "v1", Namespace: "shared", Name: "shared-dns"},
+			})).To(Succeed())
+			allowedReads["credentialsbindings test/shared-dns-binding"] = true
+			allowedReads["secrets shared/shared-dns"] = true
+		})
+
+		It("should accept the CredentialsBinding of the shoot", func() {
+			Expect(validator.Validate(contextForGroups("project-members"), shootWithCredentialsBinding("shared-dns-binding"), nil)).To(Succeed())
+		})
+
+		It("should reject other CredentialsBindings", func() {
+			Expect(validator.Validate(contextForGroups("project-members"), shootWithCredentialsBinding("infrastructure"), nil)).To(MatchError(ContainSubstring(
+				bindingField + ": Invalid value: \"shared-dns-binding\": only the CredentialsBinding of the shoot (spec.credentialsBindingName) is synced into the seed and can be referenced")))
+		})
+
+		It("should reject a CredentialsBinding of another provider type", func() {
+			Expect(fakeClient.Create(ctx, &securityv1alpha1.CredentialsBinding{
+				ObjectMeta:     metav1.ObjectMeta{Name: "gcp-binding", Namespace: "test"},
+				Provider:       securityv1alpha1.CredentialsBindingProvider{Type: "gcp"},
+				CredentialsRef: corev1.ObjectReference{Kind: "Secret", APIVersion: "v1", Namespace: "shared", Name: "shared-dns"},
+			})).To(Succeed())
+			allowedReads["credentialsbindings test/gcp-binding"] = true
+			shoot := shootFunc([]byte(strings.ReplaceAll(string(dnsConfigCredentialsBinding), "shared-dns-binding", "gcp-binding")))
+			shoot.Spec.CredentialsBindingName = new("gcp-binding")
+
+			Expect(validator.Validate(contextForGroups("project-members"), shoot, nil)).To(MatchError(ContainSubstring(
+				bindingField + `: Invalid value: "gcp-binding": the CredentialsBinding has the provider type "gcp", but providers of type "aws-route53" need credentials of provider type "aws"`)))
+		})
+
+		It("should reject a CredentialsBinding the user is not allowed to read", func() {
+			allowedReads["credentialsbindings test/shared-dns-binding"] = false
+			Expect(validator.Validate(contextForGroups("project-members"), shootWithCredentialsBinding("shared-dns-binding"), nil)).To(MatchError(ContainSubstring(
+				bindingField + ": Forbidden: cannot reference a CredentialsBinding you are not allowed to read")))
+		})
+
+		It("should reject credentials in another namespace the user is not allowed to read", func() {
+			allowedReads["secrets shared/shared-dns"] = false
+			Expect(validator.Validate(contextForGroups("project-members"), shootWithCredentialsBinding("shared-dns-binding"), nil)).To(MatchError(ContainSubstring(
+				bindingField + ".credentialsRef: Forbidden: cannot reference Secret shared/shared-dns you are not allowed to read")))
+		})
+
+		It("should not check the access if the DNS config is unchanged", func() {
+			allowedReads = map[string]bool{}
+			shoot := shootWithCredentialsBinding("shared-dns-binding")
+			Expect(validator.Validate(contextForGroups("project-members"), shoot, shoot.DeepCopy())).To(Succeed())
+		})
+	})
+
+	Describe("#Validate quota annotation", func() {
+		var (
+			shootWithQuota = func(value string) *gardencore.Shoot {
+				shoot := shootFunc(dnsConfigGood)
+				shoot.Annotations = map[string]string{service.DefaultExternalProviderEntriesQuotaAnnotation: value}
+				return shoot
+			}
+			quotaField = "metadata.annotations[service.dns.extensions.gardener.cloud/default-external-provider-entries-quota]"
+		)
+
+		It("should reject a malformed quota", func() {
+			Expect(validator.Validate(contextForGroups("operators"), shootWithQuota("many"), nil)).To(MatchError(ContainSubstring(quotaField + ": Invalid value: \"many\": must be a positive integer")))
+		})
+
+		It("should allow users to lower the quota", func() {
+			Expect(validator.Validate(contextForGroups("project-members"), shootWithQuota("50"), shootFunc(dnsConfigGood))).To(Succeed())
+		})
+
+		It("should only allow operators to raise the quota", func() {
+			Expect(validator.Validate(contextForGroups("project-members"), shootWithQuota("200"), shootFunc(dnsConfigGood))).To(MatchError(ContainSubstring(quotaField + ": Forbidden: only operators can raise the quota above the default quota 100")))
+			Expect(validator.Validate(contextForGroups("operators"), shootWithQuota("200"), shootFunc(dnsConfigGood))).To(Succeed())
+		})
+
+		It("should reject a quota above the maximum", func() {
+			Expect(validator.Validate(contextForGroups("operators"), shootWithQuota("501"), nil)).To(MatchError(ContainSubstring(quotaField + ": Invalid value: \"501\": must not exceed the maximum quota 500")))
+		})
+
+		It("should not validate an unchanged quota", func() {
+			Expect(validator.Validate(contextForGroups("project-members"), shootWithQuota("1000"), shootWithQuota("1000"))).To(Succeed())
+		})
+
+		It("should validate an unchanged quota if the extension label is added", func() {
+			oldShoot := shootWithQuota("1000")
+			oldShoot.Labels = nil
+			Expect(validator.Validate(contextForGroups("project-members"), shootWithQuota("1000"), oldShoot)).To(MatchError(ContainSubstring(quotaField + ": Forbidden: only operators can raise the quota above the default quota 100")))
+		})
+
+		It("should validate an unchanged quota if the extension is enabled", func() {
+			oldShoot := shootWithQuota("1000")
+			oldShoot.Spec.Extensions[0].Disabled = new(true)
+			Expect(validator.Validate(contextForGroups("project-members"), shootWithQuota("1000"), oldShoot)).To(MatchError(ContainSubstring(quotaField + ": Forbidden: only operators can raise the quota above the default quota 100")))
+		})
+
+		It("should apply the limits of the matching quota rule", func() {
+			Expect(fakeClient.Create(ctx, &corev1.Namespace{
+				ObjectMeta: metav1.ObjectMeta{Name: "test", Labels: map[string]string{v1beta1constants.ProjectName: "big"}},
+			})).To(Succeed())
+			validator = admissionvalidator.NewShootValidator(mgr, config.InternalGCPWorkloadIdentityConfig{}, nil, quota.Verifier{OperatorGroups: []string{"operators"}}, controllerconfig.DNSServiceConfig{
+				DefaultExternalProviderEntriesQuota: 100,
+				DefaultExternalProviderEntriesQuotaRules: []controllerconfig.QuotaRule{
+					{Name: "production", Selector: policyv1alpha1.ShootSelector{Purposes: []gardencorev1beta1.ShootPurpose{gardencorev1beta1.ShootPurposeProduction}}, Quota: 10},
+					{Name: "big", Selector: policyv1alpha1.ShootSelector{Projects: []string{"big"}}, Quota: 1000, QuotaMax: 2000},
+				},
+			})
+
+			Expect(validator.Validate(contextForGroups("project-members"), shootWithQuota("1000"), nil)).To(Succeed())
+			Expect(validator.Validate(contextForGroups("operators"), shootWithQuota("2001"), nil)).To(MatchError(ContainSubstring(quotaField + ": Invalid value: \"2001\": must not exceed the maximum quota 2000")))
+
+			shoot := shootWithQuota("50")
+			shoot.Spec.Purpose = new(gardencore.ShootPurposeProduction)
+			Expect(validator.Validate(contextForGroups("project-members"), shoot, nil)).To(MatchError(ContainSubstring(quotaField + ": Forbidden: only operators can raise the quota above the default quota 10")))
+		})
+	})
+
+	Describe("#Validate warnings", func() {
+		var (
+			validateWithWarnings = func(ctx context.Context, newShoot, oldShoot *gardencore.Shoot) (admission.Warnings, error) {
+				return validator.(admissionvalidator.WarningsValidator).ValidateWithWarnings(ctx, newShoot, oldShoot)
+			}
+			dnsConfigCredentials = func(extra string) []byte {
+				return []byte(`apiVersion: service.dns.extensions.gardener.cloud/v1alpha1
+kind: DNSConfig
+providers:
+- credentials: shoot-dns-service-my-secret-good
+  type: aws-route53
+  domains:
+    include:
+    - sub.shoot.example.com
+` + extra)
+			}
+			providerConfigPath = "spec.extensions.[@.type='shoot-dns-service'].providerConfig"
+		)
+
+		It("should warn about the deprecated secretName field", func() {
+			result, err := validateWithWarnings(ctx, shootFunc(dnsConfigGood), nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result).To(ConsistOf(providerConfigPath + "[0].secretName: the field secretName is deprecated, please use the field credentials instead"))
+		})
+
+		It("should warn about raised quotas", func() {
+			shoot := shootFunc(dnsConfigCredentials(""))
+			shoot.Annotations = map[string]string{service.DefaultExternalProviderEntriesQuotaAnnotation: "200"}
+
+			result, err := validateWithWarnings(contextForGroups("operators"), shoot, nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result).To(ConsistOf("metadata.annotations[service.dns.extensions.gardener.cloud/default-external-provider-entries-quota]: the requested quota 200 is above the default quota 100"))
+		})
+
+		It("should warn about domains overlapping the domain of the shoot", func() {
+			// domains inside the shoot domain are only rejected on changes of the DNS config
+			shoot := shootFunc(dnsConfigCredentials(""))
+			shoot.Spec.DNS = &gardencore.DNS{Domain: new("shoot.example.com")}
+			expected := providerConfigPath + `[0].domains.include[0]: domain "sub.shoot.example.com" overlaps with the domain "shoot.example.com" of the shoot (spec.dns.domain), please exclude it`
+
+			result, err := validateWithWarnings(ctx, shoot, shoot)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result).To(ConsistOf(expected))
+
+			// the domain of the shoot is served by the primary provider
+			shoot.Spec.DNS.Providers = []gardencore.DNSProvider{{Type: new("aws-route53"), Primary: new(true)}}
+			result, err = validateWithWarnings(ctx, shoot, shoot)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result).To(ConsistOf(expected))
+		})
+
+		It("should accept parent zones of the domain of the shoot with a warning", func() {
+			shoot := shootFunc(dnsConfigCredentials(""))
+			shoot.Spec.DNS = &gardencore.DNS{Domain: new("my.sub.shoot.example.com")}
+
+			result, err := validateWithWarnings(ctx, shoot, nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result).To(ConsistOf(providerConfigPath + `[0].domains.include[0]: domain "sub.shoot.example.com" overlaps with the domain "my.sub.shoot.example.com" of the shoot (spec.dns.domain), please exclude it`))
+
+			shoot = shootFunc(dnsConfigCredentials("    exclude:\n    - my.sub.shoot.example.com\n"))
+			shoot.Spec.DNS = &gardencore.DNS{Domain: new("my.sub.shoot.example.com")}
+			result, err = validateWithWarnings(ctx, shoot, nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result).To(BeEmpty())
+		})
+
+		It("should warn about additional providers synchronised from the shoot spec", func() {
+			shoot := shootFunc(dnsConfigCredentials("syncProvidersFromShootSpecDNS: true\n"))
+			shoot.Spec.DNS = &gardencore.DNS{Providers: []gardencore.DNSProvider{{Type: new("aws-route53")}}}
+
+			result, err := validateWithWarnings(ctx, shoot, nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result).To(ConsistOf(providerConfigPath + ".syncProvidersFromShootSpecDNS: additional providers are synchronised from the deprecated section spec.dns.providers, please set the field to false and configure them in the providerConfig"))
+		})
+
+		It("should warn about disabling the next generation controller on seeds forcing it", func() {
+			Expect(fakeClient.Create(ctx, &gardencorev1beta1.Seed{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:   "forcing-seed",
+					Labels: map[string]string{service.UseNextGenerationControllerSeedLabel: "force-true"},
+				},
+			})).To(Succeed())
+
+			result, err := validateWithWarnings(ctx, shootOnSeedFunc(dnsConfigCredentials("useNextGenerationController: false\n"), "forcing-seed"), nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result).To(ConsistOf(providerConfigPath + ".useNextGenerationController: the next generation DNS controller is forced by seed forcing-seed, the setting is ignored"))
+
+			result, err = validateWithWarnings(ctx, shootOnSeedFunc(dnsConfigCredentials("useNextGenerationController: false\n"), "restricted-seed"), nil)
+			Expect(err).To(HaveOccurred()) // aws-route53 is not allowed on the restricted seed
+			Expect(result).To(BeEmpty())
+		})
+	})
+
+	Describe("#Validate domain selections", func() {
+		var dnsConfigOverlapping = []byte(`apiVersion: service.dns.extensions.gardener.cloud/v1alpha1
+kind: DNSConfig
+providers:
+- credentials: shoot-dns-service-my-secret-good
+  type: aws-route53
+  domains:
+    include:
+    - example.com
+- credentials: shoot-dns-service-my-secret-good
+  type: aws-route53
+  domains:
+    include:
+    - sub.example.com
+`)
+
+		It("should reject overlapping domains of different providers", func() {
+			Expect(validator.Validate(ctx, shootFunc(dnsConfigOverlapping), nil)).To(MatchError(
+				"spec.extensions.[@.type='shoot-dns-service'].providerConfig[1].domains.include[0]: Invalid value: \"sub.example.com\": " +
+					"overlaps with the included domain \"example.com\" of spec.extensions.[@.type='shoot-dns-service'].providerConfig[0].domains.include[0]"))
+		})
+
+		It("should reject domains inside the domain of the shoot", func() {
+			shoot := shootFunc(dnsConfigOverlapping)
+			shoot.Spec.DNS = &gardencore.DNS{Domain: new("sub.example.com")}
+			expected := "spec.extensions.[@.type='shoot-dns-service'].providerConfig[1].domains.include[0]: Invalid value: \"sub.example.com\": " +
+				"must not be the domain \"sub.example.com\" of spec.dns.domain or one of its subdomains"
+
+			Expect(validator.Validate(ctx, shoot, nil)).To(MatchError(ContainSubstring(expected)))
+
+			// the domain of the shoot is served by the primary provider
+			shoot.Spec.DNS.Providers = []gardencore.DNSProvider{{Type: new("aws-route53"), Primary: new(true)}}
+			Expect(validator.Validate(ctx, shoot, nil)).To(MatchError(ContainSubstring(expected)))
+		})
+
+		It("should not validate the domain selections if the DNS config is unchanged", func() {
+			Expect(validator.Validate(ctx, shootFunc(dnsConfigOverlapping), shootFunc(dnsConfigOverlapping))).To(Succeed())
+		})
+	})
 })
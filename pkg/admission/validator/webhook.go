@@ -10,6 +10,9 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
+
+	"github.com/gardener/gardener-extension-shoot-dns-service/pkg/admission/quota"
+	"github.com/gardener/gardener-extension-shoot-dns-service/pkg/service"
 )
 
 const (
@@ -25,15 +28,23 @@ var logger = log.Log.WithName("shoot-dns-service-validator-webhook")
 func New(mgr manager.Manager) (*extensionswebhook.Webhook, error) {
 	logger.Info("Setting up webhook", "name", ValidatorName)
 
-	return extensionswebhook.New(mgr, extensionswebhook.Args{
+	validator := NewShootValidator(mgr, DefaultAddOptions.GCPWorkloadIdentityConfig, DefaultAddOptions.ProviderTypeRestrictions, DefaultAddOptions.QuotaVerifier, DefaultAddOptions.QuotaConfig)
+	wh, err := extensionswebhook.New(mgr, extensionswebhook.Args{
 		Name: ValidatorName,
 		Path: ValidatorPath,
 		Validators: map[extensionswebhook.Validator][]extensionswebhook.Type{
-			NewShootValidator(mgr, DefaultAddOptions.GCPWorkloadIdentityConfig): {{Obj: &core.Shoot{}}},
+			validator: {{Obj: &core.Shoot{}}},
 		},
 		Target: extensionswebhook.TargetSeed,
 		ObjectSelector: &metav1.LabelSelector{
-			MatchLabels: map[string]string{"extensions.extensions.gardener.cloud/shoot-dns-service": "true"},
+			MatchLabels: map[string]string{service.ExtensionLabel: "true"},
 		},
 	})
+	if err != nil {
+		return nil, err
+	}
+	// the handler of extensionswebhook.Webhook drops the warnings of the validator
+	wh.Webhook.Handler = newShootHandler(mgr, validator)
+	// the quota verifier needs the user info of the admission request
+	return quota.WithAdmissionRequest(wh), nil
 }
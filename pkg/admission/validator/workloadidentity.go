@@ -7,6 +7,8 @@ package validator
 import (
 	"context"
 	"fmt"
+	"slices"
+	"strings"
 
 	workloadidentityaws "github.com/gardener/external-dns-management/pkg/apis/dns/workloadidentity/aws"
 	workloadidentityazure "github.com/gardener/external-dns-management/pkg/apis/dns/workloadidentity/azure"
@@ -15,25 +17,38 @@ import (
 	extensionswebhook "github.com/gardener/gardener/extensions/pkg/webhook"
 	securityv1alpha1 "github.com/gardener/gardener/pkg/apis/security/v1alpha1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/serializer"
 	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
 	"sigs.k8s.io/yaml"
+
+	"github.com/gardener/gardener-extension-shoot-dns-service/pkg/apis/service/validation"
+	workloadidentityalicloud "github.com/gardener/gardener-extension-shoot-dns-service/pkg/apis/service/workloadidentity/alicloud"
+	workloadidentityopenstack "github.com/gardener/gardener-extension-shoot-dns-service/pkg/apis/service/workloadidentity/openstack"
 )
 
 type workloadIdentity struct {
+	decoder   runtime.Decoder
+	client    client.Client
 	gcpConfig config.InternalGCPWorkloadIdentityConfig
 }
 
 // NewWorkloadIdentityValidator returns a new instance of a WorkloadIdentity validator.
-func NewWorkloadIdentityValidator(gcpConfig config.InternalGCPWorkloadIdentityConfig) extensionswebhook.Validator {
+func NewWorkloadIdentityValidator(mgr manager.Manager, gcpConfig config.InternalGCPWorkloadIdentityConfig) extensionswebhook.Validator {
 	return &workloadIdentity{
+		decoder:   serializer.NewCodecFactory(mgr.GetScheme()).UniversalDecoder(),
+		client:    mgr.GetClient(),
 		gcpConfig: gcpConfig,
 	}
 }
 
-// Validate checks whether the given new workloadidentity contains a valid AWS/Azure/Google configuration.
+// Validate checks whether the given new workloadidentity contains a valid AWS/Azure/Google/OpenStack/Alicloud configuration.
 // Unsupported target system types are skipped.
-func (wi *workloadIdentity) Validate(_ context.Context, newObj, oldObj client.Object) error {
+// If the target system type is changed, it must still be compatible with the DNS providers of the shoots referencing
+// the workloadidentity.
+func (wi *workloadIdentity) Validate(ctx context.Context, newObj, oldObj client.Object) error {
 	workloadIdentity, ok := newObj.(*securityv1alpha1.WorkloadIdentity)
 	if !ok {
 		return fmt.Errorf("wrong object type %T", newObj)
@@ -47,6 +62,14 @@ func (wi *workloadIdentity) Validate(_ context.Context, newObj, oldObj client.Ob
 		}
 	}
 
+	if oldWorkloadIdentity != nil && oldWorkloadIdentity.Spec.TargetSystem.Type != workloadIdentity.Spec.TargetSystem.Type {
+		if err := wi.validateTypeChange(ctx, workloadIdentity); err != nil {
+			return err
+		}
+		// the configuration of the old target system cannot be compared with the new one
+		oldWorkloadIdentity = nil
+	}
+
 	switch workloadIdentity.Spec.TargetSystem.Type {
 	case "aws":
 		return wi.validateAWS(workloadIdentity, oldWorkloadIdentity)
@@ -54,12 +77,40 @@ func (wi *workloadIdentity) Validate(_ context.Context, newObj, oldObj client.Ob
 		return wi.validateAzure(workloadIdentity, oldWorkloadIdentity)
 	case "gcp":
 		return wi.validateGCP(workloadIdentity, oldWorkloadIdentity)
+	case "openstack":
+		return wi.validateOpenStack(workloadIdentity, oldWorkloadIdentity)
+	case "alicloud":
+		return wi.validateAlicloud(workloadIdentity, oldWorkloadIdentity)
 	default:
 		// Skip validation for unsupported target system types
 		return nil
 	}
 }
 
+// validateTypeChange checks that the new target system type of the workloadidentity is usable by all DNS providers
+// of the shoots in the same namespace referencing it.
+func (wi *workloadIdentity) validateTypeChange(ctx context.Context, workloadIdentity *securityv1alpha1.WorkloadIdentity) error {
+	refs, err := findProviderReferences(ctx, wi.client, wi.decoder, workloadIdentity.Namespace, "WorkloadIdentity", workloadIdentity.Name)
+	if err != nil {
+		return fmt.Errorf("failed to find shoots referencing the workload identity: %w", err)
+	}
+
+	var affected []string
+	for _, ref := range refs {
+		providerType := ptr.Deref(ref.provider.Type, "")
+		if validation.WorkloadIdentityTargetSystemType(providerType) != workloadIdentity.Spec.TargetSystem.Type {
+			affected = append(affected, fmt.Sprintf("%s (%s)", ref.shootName, providerType))
+		}
+	}
+	if len(affected) == 0 {
+		return nil
+	}
+	slices.Sort(affected)
+	return field.ErrorList{field.Forbidden(field.NewPath("spec", "targetSystem", "type"),
+		fmt.Sprintf("target system type %q is not compatible with the DNS providers of the shoots referencing the workload identity: %s",
+			workloadIdentity.Spec.TargetSystem.Type, strings.Join(affected, ", ")))}.ToAggregate()
+}
+
 func (wi *workloadIdentity) validateAWS(newObj, oldObj *securityv1alpha1.WorkloadIdentity) error {
 	newConfig, err := awsConfigFromRawExtension("new", newObj.Spec.TargetSystem.ProviderConfig)
 	if err != nil {
@@ -141,6 +192,60 @@ func (wi *workloadIdentity) validateGCP(newObj, oldObj *securityv1alpha1.Workloa
 	return nil
 }
 
+func (wi *workloadIdentity) validateOpenStack(newObj, oldObj *securityv1alpha1.WorkloadIdentity) error {
+	newConfig, err := openstackConfigFromRawExtension("new", newObj.Spec.TargetSystem.ProviderConfig)
+	if err != nil {
+		return err
+	}
+
+	fieldPath := field.NewPath("spec", "targetSystem", "providerConfig")
+	if oldObj != nil {
+		oldConfig, err := openstackConfigFromRawExtension("old", oldObj.Spec.TargetSystem.ProviderConfig)
+		if err != nil {
+			return err
+		}
+
+		errList := workloadidentityopenstack.ValidateWorkloadIdentityConfigUpdate(oldConfig, newConfig, fieldPath)
+		if len(errList) > 0 {
+			return fmt.Errorf("validation of target system's configuration failed: %w", errList.ToAggregate())
+		}
+		return nil
+	}
+
+	errList := workloadidentityopenstack.ValidateWorkloadIdentityConfig(newConfig, fieldPath)
+	if len(errList) > 0 {
+		return fmt.Errorf("validation of target system's configuration failed: %w", errList.ToAggregate())
+	}
+	return nil
+}
+
+func (wi *workloadIdentity) validateAlicloud(newObj, oldObj *securityv1alpha1.WorkloadIdentity) error {
+	newConfig, err := alicloudConfigFromRawExtension("new", newObj.Spec.TargetSystem.ProviderConfig)
+	if err != nil {
+		return err
+	}
+
+	fieldPath := field.NewPath("spec", "targetSystem", "providerConfig")
+	if oldObj != nil {
+		oldConfig, err := alicloudConfigFromRawExtension("old", oldObj.Spec.TargetSystem.ProviderConfig)
+		if err != nil {
+			return err
+		}
+
+		errList := workloadidentityalicloud.ValidateWorkloadIdentityConfigUpdate(oldConfig, newConfig, fieldPath)
+		if len(errList) > 0 {
+			return fmt.Errorf("validation of target system's configuration failed: %w", errList.ToAggregate())
+		}
+		return nil
+	}
+
+	errList := workloadidentityalicloud.ValidateWorkloadIdentityConfig(newConfig, fieldPath)
+	if len(errList) > 0 {
+		return fmt.Errorf("validation of target system's configuration failed: %w", errList.ToAggregate())
+	}
+	return nil
+}
+
 func awsConfigFromRawExtension(name string, providerConfig *runtime.RawExtension) (*workloadidentityaws.WorkloadIdentityConfig, error) {
 	cfg := &workloadidentityaws.WorkloadIdentityConfig{}
 	if err := configFromRawExtension(name, "AWS", providerConfig, cfg); err != nil {
@@ -165,6 +270,22 @@ func gcpConfigFromRawExtension(name string, providerConfig *runtime.RawExtension
 	return cfg, nil
 }
 
+func openstackConfigFromRawExtension(name string, providerConfig *runtime.RawExtension) (*workloadidentityopenstack.WorkloadIdentityConfig, error) {
+	cfg := &workloadidentityopenstack.WorkloadIdentityConfig{}
+	if err := configFromRawExtension(name, "OpenStack", providerConfig, cfg); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+func alicloudConfigFromRawExtension(name string, providerConfig *runtime.RawExtension) (*workloadidentityalicloud.WorkloadIdentityConfig, error) {
+	cfg := &workloadidentityalicloud.WorkloadIdentityConfig{}
+	if err := configFromRawExtension(name, "Alicloud", providerConfig, cfg); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
 func configFromRawExtension(name, infraType string, providerConfig *runtime.RawExtension, cfg any) error {
 	if providerConfig == nil || len(providerConfig.Raw) == 0 {
 		return fmt.Errorf("the %s target system is missing the %s providerConfig configuration", name, infraType)
@@ -9,24 +9,45 @@ import (
 	"regexp"
 
 	"github.com/gardener/external-dns-management/pkg/dnsman2/apis/config"
+	extensionswebhook "github.com/gardener/gardener/extensions/pkg/webhook"
+	"github.com/gardener/gardener/pkg/apis/core/install"
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
 	securityv1alpha1 "github.com/gardener/gardener/pkg/apis/security/v1alpha1"
+	"github.com/gardener/gardener/pkg/utils/test"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	autoscalingv1 "k8s.io/api/autoscaling/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	"github.com/gardener/gardener-extension-shoot-dns-service/pkg/admission/validator"
+	serviceinstall "github.com/gardener/gardener-extension-shoot-dns-service/pkg/apis/service/install"
 )
 
 var _ = Describe("WorkloadIdentity validator", func() {
 	Describe("#Validate", func() {
 		var (
 			ctx                       = context.Background()
-			workloadIdentityValidator = validator.NewWorkloadIdentityValidator(config.InternalGCPWorkloadIdentityConfig{
+			fakeClient                client.Client
+			workloadIdentityValidator extensionswebhook.Validator
+			workloadIdentity          *securityv1alpha1.WorkloadIdentity
+		)
+
+		BeforeEach(func() {
+			scheme := runtime.NewScheme()
+			install.Install(scheme)
+			serviceinstall.Install(scheme)
+			utilruntime.Must(securityv1alpha1.AddToScheme(scheme))
+			fakeClient = fakeclient.NewClientBuilder().WithScheme(scheme).Build()
+			workloadIdentityValidator = validator.NewWorkloadIdentityValidator(&test.FakeManager{Scheme: scheme, Client: fakeClient}, config.InternalGCPWorkloadIdentityConfig{
 				AllowedTokenURLs: []string{"https://sts.googleapis.com/v1/token", "https://sts.googleapis.com/v1/token/new"},
 				AllowedServiceAccountImpersonationURLRegExps: []*regexp.Regexp{regexp.MustCompile(`^https://iamcredentials\.googleapis\.com/v1/projects/-/serviceAccounts/.+:generateAccessToken$`)},
 			})
-			workloadIdentity *securityv1alpha1.WorkloadIdentity
-		)
+		})
 
 		It("should skip validation if workload identity is not of type 'aws'", func() {
 			wi := &securityv1alpha1.WorkloadIdentity{
@@ -212,5 +233,244 @@ credentialsConfig:
 				Expect(err.Error()).To(Equal(`validation of target system's configuration failed: spec.targetSystem.providerConfig.credentialsConfig.service_account_impersonation_url: Invalid value: "https://iamcredentials.googleapis.com/v1/projects/-/serviceAccounts/foo@bar.example:generateAccessTokeninvalid": should match one of the allowed regular expressions: ^https://iamcredentials\.googleapis\.com/v1/projects/-/serviceAccounts/.+:generateAccessToken$`))
 			})
 		})
+
+		Context("OpenStack Workload Identity", func() {
+			BeforeEach(func() {
+				workloadIdentity = &securityv1alpha1.WorkloadIdentity{
+					Spec: securityv1alpha1.WorkloadIdentitySpec{
+						Audiences: []string{"foo"},
+						TargetSystem: securityv1alpha1.TargetSystem{
+							Type: "openstack",
+							ProviderConfig: &runtime.RawExtension{
+								Raw: []byte(`
+apiVersion: openstack.provider.extensions.gardener.cloud/v1alpha1
+kind: WorkloadIdentityConfig
+authURL: "https://keystone.example.com/v3"
+domainName: "default"
+tenantName: "dns"
+identityProvider: "gardener"
+protocol: "openid"
+`),
+							},
+						},
+					},
+				}
+			})
+
+			It("should successfully validate the creation of a workload identity", func() {
+				Expect(workloadIdentityValidator.Validate(ctx, workloadIdentity, nil)).To(Succeed())
+			})
+
+			It("should successfully validate the update of a workload identity", func() {
+				newWorkloadIdentity := workloadIdentity.DeepCopy()
+				newWorkloadIdentity.Spec.TargetSystem.ProviderConfig.Raw = []byte(`
+apiVersion: openstack.provider.extensions.gardener.cloud/v1alpha1
+kind: WorkloadIdentityConfig
+authURL: "https://keystone.example.com/v3"
+domainName: "default"
+tenantName: "dns"
+identityProvider: "gardener-new"
+protocol: "openid"
+`)
+				Expect(workloadIdentityValidator.Validate(ctx, newWorkloadIdentity, workloadIdentity)).To(Succeed())
+			})
+
+			It("should fail to validate if authURL is not a https URL", func() {
+				workloadIdentity.Spec.TargetSystem.ProviderConfig.Raw = []byte(`
+apiVersion: openstack.provider.extensions.gardener.cloud/v1alpha1
+kind: WorkloadIdentityConfig
+authURL: "http://keystone.example.com/v3"
+domainName: "default"
+tenantName: "dns"
+identityProvider: "gardener"
+protocol: "openid"
+`)
+				err := workloadIdentityValidator.Validate(ctx, workloadIdentity, nil)
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("validation of target system's configuration failed: spec.targetSystem.providerConfig.authURL: Invalid value: \"http://keystone.example.com/v3\": authURL must be a valid https URL"))
+			})
+
+			It("should not allow changing the tenantName", func() {
+				newWorkloadIdentity := workloadIdentity.DeepCopy()
+				newWorkloadIdentity.Spec.TargetSystem.ProviderConfig.Raw = []byte(`
+apiVersion: openstack.provider.extensions.gardener.cloud/v1alpha1
+kind: WorkloadIdentityConfig
+authURL: "https://keystone.example.com/v3"
+domainName: "default"
+tenantName: "other"
+identityProvider: "gardener"
+protocol: "openid"
+`)
+				err := workloadIdentityValidator.Validate(ctx, newWorkloadIdentity, workloadIdentity)
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("validation of target system's configuration failed: spec.targetSystem.providerConfig.tenantName: Invalid value: \"other\": field is immutable"))
+			})
+		})
+
+		Context("Alicloud Workload Identity", func() {
+			BeforeEach(func() {
+				workloadIdentity = &securityv1alpha1.WorkloadIdentity{
+					Spec: securityv1alpha1.WorkloadIdentitySpec{
+						Audiences: []string{"foo"},
+						TargetSystem: securityv1alpha1.TargetSystem{
+							Type: "alicloud",
+							ProviderConfig: &runtime.RawExtension{
+								Raw: []byte(`
+apiVersion: alicloud.provider.extensions.gardener.cloud/v1alpha1
+kind: WorkloadIdentityConfig
+roleARN: "acs:ram::123456789012:role/dns-admin"
+oidcProviderARN: "acs:ram::123456789012:oidc-provider/gardener"
+`),
+							},
+						},
+					},
+				}
+			})
+
+			It("should successfully validate the creation of a workload identity", func() {
+				Expect(workloadIdentityValidator.Validate(ctx, workloadIdentity, nil)).To(Succeed())
+			})
+
+			It("should successfully validate the update of a workload identity", func() {
+				newWorkloadIdentity := workloadIdentity.DeepCopy()
+				newWorkloadIdentity.Spec.TargetSystem.ProviderConfig.Raw = []byte(`
+apiVersion: alicloud.provider.extensions.gardener.cloud/v1alpha1
+kind: WorkloadIdentityConfig
+roleARN: "acs:ram::123456789012:role/dns-operator"
+oidcProviderARN: "acs:ram::123456789012:oidc-provider/gardener"
+`)
+				Expect(workloadIdentityValidator.Validate(ctx, newWorkloadIdentity, workloadIdentity)).To(Succeed())
+			})
+
+			It("should fail to validate if roleARN is empty", func() {
+				workloadIdentity.Spec.TargetSystem.ProviderConfig.Raw = []byte(`
+apiVersion: alicloud.provider.extensions.gardener.cloud/v1alpha1
+kind: WorkloadIdentityConfig
+oidcProviderARN: "acs:ram::123456789012:oidc-provider/gardener"
+`)
+				err := workloadIdentityValidator.Validate(ctx, workloadIdentity, nil)
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("validation of target system's configuration failed: spec.targetSystem.providerConfig.roleARN: Required value: roleARN is required"))
+			})
+
+			It("should not allow changing the oidcProviderARN", func() {
+				newWorkloadIdentity := workloadIdentity.DeepCopy()
+				newWorkloadIdentity.Spec.TargetSystem.ProviderConfig.Raw = []byte(`
+apiVersion: alicloud.provider.extensions.gardener.cloud/v1alpha1
+kind: WorkloadIdentityConfig
+roleARN: "acs:ram::123456789012:role/dns-admin"
+oidcProviderARN: "acs:ram::123456789012:oidc-provider/other"
+`)
+				err := workloadIdentityValidator.Validate(ctx, newWorkloadIdentity, workloadIdentity)
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("validation of target system's configuration failed: spec.targetSystem.providerConfig.oidcProviderARN: Invalid value: \"acs:ram::123456789012:oidc-provider/other\": field is immutable"))
+			})
+		})
+
+		Context("Target system type change", func() {
+			var (
+				shootFunc = func(name, dnsConfig string, disabled bool) *gardencorev1beta1.Shoot {
+					return &gardencorev1beta1.Shoot{
+						ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "garden-project"},
+						Spec: gardencorev1beta1.ShootSpec{
+							Extensions: []gardencorev1beta1.Extension{
+								{
+									Type:           "shoot-dns-service",
+									Disabled:       ptr.To(disabled),
+									ProviderConfig: &runtime.RawExtension{Raw: []byte(dnsConfig)},
+								},
+							},
+							Resources: []gardencorev1beta1.NamedResourceReference{
+								{
+									Name: "dns-workload-identity",
+									ResourceRef: autoscalingv1.CrossVersionObjectReference{
+										Kind:       "WorkloadIdentity",
+										Name:       "dns",
+										APIVersion: "security.gardener.cloud/v1alpha1",
+									},
+								},
+								{
+									Name: "other-workload-identity",
+									ResourceRef: autoscalingv1.CrossVersionObjectReference{
+										Kind:       "WorkloadIdentity",
+										Name:       "other",
+										APIVersion: "security.gardener.cloud/v1alpha1",
+									},
+								},
+							},
+						},
+					}
+				}
+				dnsConfigFunc = func(providerType, credentials string) string {
+					return `apiVersion: service.dns.extensions.gardener.cloud/v1alpha1
+kind: DNSConfig
+providers:
+- credentials: ` + credentials + `
+  type: ` + providerType + `
+syncProvidersFromShootSpecDNS: false
+`
+				}
+				oldWorkloadIdentity *securityv1alpha1.WorkloadIdentity
+			)
+
+			BeforeEach(func() {
+				oldWorkloadIdentity = &securityv1alpha1.WorkloadIdentity{
+					ObjectMeta: metav1.ObjectMeta{Name: "dns", Namespace: "garden-project"},
+					Spec: securityv1alpha1.WorkloadIdentitySpec{
+						Audiences: []string{"foo"},
+						TargetSystem: securityv1alpha1.TargetSystem{
+							Type: "aws",
+							ProviderConfig: &runtime.RawExtension{
+								Raw: []byte(`
+apiVersion: aws.provider.extensions.gardener.cloud/v1alpha1
+kind: WorkloadIdentityConfig
+roleARN: "foo"
+`),
+							},
+						},
+					},
+				}
+				workloadIdentity = oldWorkloadIdentity.DeepCopy()
+				workloadIdentity.Spec.TargetSystem = securityv1alpha1.TargetSystem{
+					Type: "azure",
+					ProviderConfig: &runtime.RawExtension{
+						Raw: []byte(`
+apiVersion: azure.provider.extensions.gardener.cloud/v1alpha1
+kind: WorkloadIdentityConfig
+clientID: "11111c4e-db61-17fa-a141-ed39b34aa561"
+tenantID: "22222c4e-db61-17fa-a141-ed39b34aa561"
+subscriptionID: "33333c4e-db61-17fa-a141-ed39b34aa561"
+`),
+					},
+				}
+			})
+
+			It("should allow the change if no shoot references the workload identity", func() {
+				Expect(fakeClient.Create(ctx, shootFunc("other", dnsConfigFunc("aws-route53", "other-workload-identity"), false))).To(Succeed())
+
+				Expect(workloadIdentityValidator.Validate(ctx, workloadIdentity, oldWorkloadIdentity)).To(Succeed())
+			})
+
+			It("should allow the change if the referencing providers are compatible with the new type", func() {
+				Expect(fakeClient.Create(ctx, shootFunc("azure", dnsConfigFunc("azure-private-dns", "dns-workload-identity"), false))).To(Succeed())
+
+				Expect(workloadIdentityValidator.Validate(ctx, workloadIdentity, oldWorkloadIdentity)).To(Succeed())
+			})
+
+			It("should ignore shoots with disabled extension", func() {
+				Expect(fakeClient.Create(ctx, shootFunc("disabled", dnsConfigFunc("aws-route53", "dns-workload-identity"), true))).To(Succeed())
+
+				Expect(workloadIdentityValidator.Validate(ctx, workloadIdentity, oldWorkloadIdentity)).To(Succeed())
+			})
+
+			It("should reject the change and list the affected shoots", func() {
+				Expect(fakeClient.Create(ctx, shootFunc("shoot-b", dnsConfigFunc("aws-route53", "dns-workload-identity"), false))).To(Succeed())
+				Expect(fakeClient.Create(ctx, shootFunc("shoot-a", dnsConfigFunc("google-clouddns", "dns-workload-identity"), false))).To(Succeed())
+				Expect(fakeClient.Create(ctx, shootFunc("shoot-c", dnsConfigFunc("azure-dns", "dns-workload-identity"), false))).To(Succeed())
+
+				err := workloadIdentityValidator.Validate(ctx, workloadIdentity, oldWorkloadIdentity)
+				Expect(err).To(MatchError(`spec.targetSystem.type: Forbidden: target system type "azure" is not compatible with the DNS providers of the shoots referencing the workload identity: shoot-a (google-clouddns), shoot-b (aws-route53)`))
+			})
+		})
 	})
 })
@@ -14,6 +14,10 @@ import (
 	securityv1alpha1 "github.com/gardener/gardener/pkg/apis/security/v1alpha1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
+
+	"github.com/gardener/gardener-extension-shoot-dns-service/pkg/admission/quota"
+	"github.com/gardener/gardener-extension-shoot-dns-service/pkg/apis/service/validation"
+	controllerconfig "github.com/gardener/gardener-extension-shoot-dns-service/pkg/controller/config"
 )
 
 const (
@@ -32,6 +36,13 @@ var (
 type AddOptions struct {
 	// GCPWorkloadIdentityConfig is the GCP workload identity validation configuration.
 	GCPWorkloadIdentityConfig config.InternalGCPWorkloadIdentityConfig
+	// ProviderTypeRestrictions restricts the provider types allowed for additional DNS providers.
+	ProviderTypeRestrictions *validation.ProviderTypeRestrictions
+	// QuotaVerifier bounds the DNS entries quota of the default external provider requested by the shoot annotation.
+	QuotaVerifier quota.Verifier
+	// QuotaConfig contains the quota limits of the default external provider, i.e. the default quotas and the quota
+	// rules of the controller configuration.
+	QuotaConfig controllerconfig.DNSServiceConfig
 }
 
 // NewWorkloadIdentityWebhooks creates a new webhooks that validates provider dependent WorkloadIdentity resources.
@@ -40,7 +51,7 @@ func NewWorkloadIdentityWebhooks() []webhookcmd.NameToFactory {
 	// provider type has its own label key.
 	// Object selectors cannot be combined with multiple ORed labels in a single webhook.
 	var pairs []webhookcmd.NameToFactory
-	for _, providerType := range []string{"aws", "azure", "gcp"} {
+	for _, providerType := range []string{"aws", "azure", "gcp", "openstack", "alicloud"} {
 		validatorName := fmt.Sprintf(WorkloadIdentityValidatorNameFormat, providerType)
 		validatorPath := fmt.Sprintf(WorkloadIdentityValidatorPathFormat, providerType)
 		pairs = append(pairs, webhookcmd.NameToFactory{
@@ -51,7 +62,7 @@ func NewWorkloadIdentityWebhooks() []webhookcmd.NameToFactory {
 					Name: validatorName,
 					Path: validatorPath,
 					Validators: map[extensionswebhook.Validator][]extensionswebhook.Type{
-						NewWorkloadIdentityValidator(DefaultAddOptions.GCPWorkloadIdentityConfig): {{Obj: &securityv1alpha1.WorkloadIdentity{}}},
+						NewWorkloadIdentityValidator(mgr, DefaultAddOptions.GCPWorkloadIdentityConfig): {{Obj: &securityv1alpha1.WorkloadIdentity{}}},
 					},
 					Target: extensionswebhook.TargetSeed,
 					ObjectSelector: &metav1.LabelSelector{
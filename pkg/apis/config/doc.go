@@ -0,0 +1,11 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// +k8s:deepcopy-gen=package
+// +groupName=shootdnsservice.extensions.config.gardener.cloud
+
+//go:generate ../../../hack/update-codegen.sh
+
+// Package config contains the configuration of the shoot-dns-service controller.
+package config // import "github.com/gardener/gardener-extension-shoot-dns-service/pkg/apis/config"
@@ -0,0 +1,47 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package loader
+
+import (
+	"fmt"
+	"os"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/serializer"
+
+	"github.com/gardener/gardener-extension-shoot-dns-service/pkg/apis/config"
+	"github.com/gardener/gardener-extension-shoot-dns-service/pkg/apis/config/install"
+)
+
+var (
+	// Scheme is the scheme used to decode the controller configuration.
+	Scheme *runtime.Scheme
+	// Codecs is the codec factory used to decode the controller configuration.
+	Codecs serializer.CodecFactory
+)
+
+func init() {
+	Scheme = runtime.NewScheme()
+	install.Install(Scheme)
+	Codecs = serializer.NewCodecFactory(Scheme, serializer.EnableStrict)
+}
+
+// LoadFromFile takes a filename and de-serializes the contents into a ControllerConfiguration object.
+func LoadFromFile(filename string) (*config.ControllerConfiguration, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	return Load(data)
+}
+
+// Load takes a byte slice and de-serializes the contents into a defaulted ControllerConfiguration object.
+func Load(data []byte) (*config.ControllerConfiguration, error) {
+	cfg := &config.ControllerConfiguration{}
+	if _, _, err := Codecs.UniversalDecoder().Decode(data, nil, cfg); err != nil {
+		return nil, fmt.Errorf("failed to decode controller configuration: %w", err)
+	}
+	return cfg, nil
+}
@@ -0,0 +1,78 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package loader_test
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/gardener/gardener-extension-shoot-dns-service/pkg/apis/config/loader"
+)
+
+var _ = Describe("Loader", func() {
+	It("should load the configuration and only default the client connection", func() {
+		cfg, err := loader.Load([]byte(`apiVersion: shootdnsservice.extensions.config.gardener.cloud/v1alpha1
+kind: ControllerConfiguration
+clientConnection:
+  qps: 50
+quotas:
+  defaultExternalProviderEntries: 100
+  defaultExternalProviderEntriesRules:
+  - name: production
+    purposes: [production]
+    defaultExternalProviderEntries: 1000
+nextGeneration:
+  zoneNameservers:
+    example.com: ns1.example.com
+timeouts:
+  dnsProviderReady: 5m
+`))
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(cfg.ClientConnection.QPS).To(BeEquivalentTo(50))
+		Expect(cfg.ClientConnection.Burst).To(BeEquivalentTo(130))
+		Expect(*cfg.Quotas.DefaultExternalProviderEntries).To(BeEquivalentTo(100))
+		Expect(cfg.Quotas.ProviderEntriesMax).To(BeNil())
+		Expect(cfg.Quotas.DefaultExternalProviderEntriesRules).To(HaveLen(1))
+		Expect(cfg.Quotas.DefaultExternalProviderEntriesRules[0].Purposes).To(ConsistOf(BeEquivalentTo("production")))
+		Expect(cfg.Quotas.DefaultExternalProviderEntriesRules[0].DefaultExternalProviderEntries).To(BeEquivalentTo(1000))
+		Expect(cfg.NextGeneration.Enabled).To(BeNil())
+		Expect(cfg.NextGeneration.ZoneNameservers).To(Equal(map[string]string{"example.com": "ns1.example.com"}))
+		Expect(cfg.Timeouts.DNSProviderReady.Duration).To(Equal(5 * time.Minute))
+		Expect(cfg.Timeouts.ManagedResourceDeletion).To(BeNil())
+		Expect(cfg.Timeouts.EntryReconciliationMax).To(BeNil())
+		Expect(cfg.HealthCheckConfig).To(BeNil())
+		Expect(cfg.DNSEntriesHealthCheck).To(BeNil())
+	})
+
+	It("should not default the client connection if not set", func() {
+		cfg, err := loader.Load([]byte(`apiVersion: shootdnsservice.extensions.config.gardener.cloud/v1alpha1
+kind: ControllerConfiguration
+`))
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(cfg.ClientConnection).To(BeNil())
+		Expect(cfg.Quotas).To(BeNil())
+		Expect(cfg.NextGeneration).To(BeNil())
+		Expect(cfg.Timeouts).To(BeNil())
+	})
+
+	It("should reject unknown fields", func() {
+		_, err := loader.Load([]byte(`apiVersion: shootdnsservice.extensions.config.gardener.cloud/v1alpha1
+kind: ControllerConfiguration
+quota: {}
+`))
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should reject unknown versions", func() {
+		_, err := loader.Load([]byte(`apiVersion: shootdnsservice.extensions.config.gardener.cloud/v1
+kind: ControllerConfiguration
+`))
+		Expect(err).To(HaveOccurred())
+	})
+})
@@ -0,0 +1,104 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	healthcheckconfigv1alpha1 "github.com/gardener/gardener/extensions/pkg/apis/config/v1alpha1"
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	componentbaseconfigv1alpha1 "k8s.io/component-base/config/v1alpha1"
+)
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ControllerConfiguration defines the configuration for the shoot-dns-service controller.
+type ControllerConfiguration struct {
+	metav1.TypeMeta
+
+	// ClientConnection specifies the kubeconfig file and client connection settings for the proxy server to use when
+	// communicating with the seed apiserver.
+	ClientConnection *componentbaseconfigv1alpha1.ClientConnectionConfiguration
+	// Quotas contains the DNS entries quotas and the limits of the quotas and rate limits of the DNS providers.
+	Quotas *QuotaConfiguration
+	// NextGeneration contains the settings of the next generation DNS controller.
+	NextGeneration *NextGenerationConfiguration
+	// Timeouts contains the timeouts of the lifecycle controller.
+	Timeouts *TimeoutConfiguration
+	// HealthCheckConfig is the config for the health check controller.
+	HealthCheckConfig *healthcheckconfigv1alpha1.HealthCheckConfig
+	// DNSEntriesHealthCheck contains the settings of the health check of the DNS entries.
+	DNSEntriesHealthCheck *DNSEntriesHealthCheckConfiguration
+}
+
+// QuotaConfiguration contains the DNS entries quotas and the limits of the quotas and rate limits of the DNS providers.
+// A value of 0 means unlimited.
+type QuotaConfiguration struct {
+	// DefaultExternalProviderEntries is the DNS entries quota for the 'external' provider when using the default domain.
+	DefaultExternalProviderEntries *int32
+	// DefaultExternalProviderEntriesMax is the maximum quota shoots can request via annotation for the 'external' provider.
+	// If not set, the default quota is also the maximum.
+	DefaultExternalProviderEntriesMax *int32
+	// ProviderEntriesMax is the maximum DNS entries quota of additional providers. It is also applied to providers
+	// without explicit quota.
+	ProviderEntriesMax *int32
+	// ProviderRequestsPerDayMax is the maximum rate limit in requests per day of additional providers. It is also applied
+	// to providers without explicit rate limit.
+	ProviderRequestsPerDayMax *int32
+	// ProviderBurstMax is the maximum rate limit burst of additional providers.
+	ProviderBurstMax *int32
+	// DefaultExternalProviderEntriesRules select the DNS entries quota for the 'external' provider by shoot purpose,
+	// project and labels. The first matching rule overwrites DefaultExternalProviderEntries and
+	// DefaultExternalProviderEntriesMax.
+	DefaultExternalProviderEntriesRules []QuotaRule
+}
+
+// QuotaRule selects the DNS entries quota for the 'external' provider of shoots. All given criteria must match.
+type QuotaRule struct {
+	// Name is the name of the rule. It is reported as source of the quota in the extension status.
+	Name string
+	// Purposes are the shoot purposes matched by the rule. Shoots without purpose have the purpose 'evaluation'.
+	Purposes []gardencorev1beta1.ShootPurpose
+	// Projects are the names of the projects matched by the rule.
+	Projects []string
+	// LabelSelector selects the shoots by labels.
+	LabelSelector *metav1.LabelSelector
+	// DefaultExternalProviderEntries is the DNS entries quota for the 'external' provider (0 = unlimited).
+	DefaultExternalProviderEntries int32
+	// DefaultExternalProviderEntriesMax is the maximum quota shoots can request via annotation.
+	// If not set, the quota of the rule is also the maximum.
+	DefaultExternalProviderEntriesMax *int32
+}
+
+// NextGenerationConfiguration contains the settings of the next generation DNS controller.
+type NextGenerationConfiguration struct {
+	// Enabled enables the deployment of the next generation DNS controller for all shoots. It can still be disabled
+	// per shoot via the extension providerConfig.
+	Enabled *bool
+	// ZoneNameservers is a static mapping from zone to nameserver (for testing).
+	ZoneNameservers map[string]string
+}
+
+// TimeoutConfiguration contains the timeouts of the lifecycle controller.
+type TimeoutConfiguration struct {
+	// ManagedResourceDeletion is the timeout for the deletion of the managed resources of a shoot.
+	ManagedResourceDeletion *metav1.Duration
+	// DNSProviderReady is the timeout for a DNS provider to become ready.
+	DNSProviderReady *metav1.Duration
+	// DNSProviderDeletion is the timeout for the deletion of a DNS provider.
+	DNSProviderDeletion *metav1.Duration
+	// EntryReconciliation is the minimum time to wait for the reconciliation of the DNS entries on restore.
+	EntryReconciliation *metav1.Duration
+	// EntryReconciliationMax is the maximum time to wait for the reconciliation of the DNS entries on restore.
+	EntryReconciliationMax *metav1.Duration
+}
+
+// DNSEntriesHealthCheckConfiguration contains the settings of the health check of the DNS entries.
+type DNSEntriesHealthCheckConfiguration struct {
+	// FailureThreshold is the number of failing DNS entries from which on the health check reports an unhealthy
+	// condition (0 = disabled).
+	FailureThreshold *int32
+	// MaxReportedFailures is the maximum number of failing DNS names listed in the condition message.
+	MaxReportedFailures *int32
+}
@@ -0,0 +1,28 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha1
+
+import (
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func addDefaultingFuncs(scheme *runtime.Scheme) error {
+	return RegisterDefaults(scheme)
+}
+
+// SetDefaults_ControllerConfiguration sets default values for ControllerConfiguration objects.
+// Only the client connection is defaulted, as the other settings are only applied if they are set explicitly.
+// Otherwise, the values of the corresponding command line flags are used.
+func SetDefaults_ControllerConfiguration(obj *ControllerConfiguration) {
+	if obj.ClientConnection == nil {
+		return
+	}
+	if obj.ClientConnection.QPS == 0 {
+		obj.ClientConnection.QPS = 100
+	}
+	if obj.ClientConnection.Burst == 0 {
+		obj.ClientConnection.Burst = 130
+	}
+}
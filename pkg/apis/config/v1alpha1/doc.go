@@ -0,0 +1,12 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// +k8s:deepcopy-gen=package
+// +k8s:conversion-gen=github.com/gardener/gardener-extension-shoot-dns-service/pkg/apis/config
+// +k8s:openapi-gen=true
+// +k8s:defaulter-gen=TypeMeta
+
+// Package v1alpha1 contains the configuration of the shoot-dns-service controller.
+// +groupName=shootdnsservice.extensions.config.gardener.cloud
+package v1alpha1 // import "github.com/gardener/gardener-extension-shoot-dns-service/pkg/apis/config/v1alpha1"
@@ -0,0 +1,138 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha1
+
+import (
+	healthcheckconfigv1alpha1 "github.com/gardener/gardener/extensions/pkg/apis/config/v1alpha1"
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	componentbaseconfigv1alpha1 "k8s.io/component-base/config/v1alpha1"
+)
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ControllerConfiguration defines the configuration for the shoot-dns-service controller.
+// Settings which are not set take the values of the corresponding command line flags.
+type ControllerConfiguration struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// ClientConnection specifies the kubeconfig file and client connection settings for the proxy server to use when
+	// communicating with the seed apiserver.
+	// +optional
+	ClientConnection *componentbaseconfigv1alpha1.ClientConnectionConfiguration `json:"clientConnection,omitempty"`
+	// Quotas contains the DNS entries quotas and the limits of the quotas and rate limits of the DNS providers.
+	// +optional
+	Quotas *QuotaConfiguration `json:"quotas,omitempty"`
+	// NextGeneration contains the settings of the next generation DNS controller.
+	// +optional
+	NextGeneration *NextGenerationConfiguration `json:"nextGeneration,omitempty"`
+	// Timeouts contains the timeouts of the lifecycle controller.
+	// +optional
+	Timeouts *TimeoutConfiguration `json:"timeouts,omitempty"`
+	// HealthCheckConfig is the config for the health check controller.
+	// +optional
+	HealthCheckConfig *healthcheckconfigv1alpha1.HealthCheckConfig `json:"healthCheckConfig,omitempty"`
+	// DNSEntriesHealthCheck contains the settings of the health check of the DNS entries.
+	// +optional
+	DNSEntriesHealthCheck *DNSEntriesHealthCheckConfiguration `json:"dnsEntriesHealthCheck,omitempty"`
+}
+
+// QuotaConfiguration contains the DNS entries quotas and the limits of the quotas and rate limits of the DNS providers.
+// A value of 0 means unlimited.
+type QuotaConfiguration struct {
+	// DefaultExternalProviderEntries is the DNS entries quota for the 'external' provider when using the default domain.
+	// +optional
+	DefaultExternalProviderEntries *int32 `json:"defaultExternalProviderEntries,omitempty"`
+	// DefaultExternalProviderEntriesMax is the maximum quota shoots can request via annotation for the 'external' provider.
+	// If not set, the default quota is also the maximum.
+	// +optional
+	DefaultExternalProviderEntriesMax *int32 `json:"defaultExternalProviderEntriesMax,omitempty"`
+	// ProviderEntriesMax is the maximum DNS entries quota of additional providers. It is also applied to providers
+	// without explicit quota.
+	// +optional
+	ProviderEntriesMax *int32 `json:"providerEntriesMax,omitempty"`
+	// ProviderRequestsPerDayMax is the maximum rate limit in requests per day of additional providers. It is also applied
+	// to providers without explicit rate limit.
+	// +optional
+	ProviderRequestsPerDayMax *int32 `json:"providerRequestsPerDayMax,omitempty"`
+	// ProviderBurstMax is the maximum rate limit burst of additional providers.
+	// +optional
+	ProviderBurstMax *int32 `json:"providerBurstMax,omitempty"`
+	// DefaultExternalProviderEntriesRules select the DNS entries quota for the 'external' provider by shoot purpose,
+	// project and labels. The first matching rule overwrites DefaultExternalProviderEntries and
+	// DefaultExternalProviderEntriesMax.
+	// +optional
+	DefaultExternalProviderEntriesRules []QuotaRule `json:"defaultExternalProviderEntriesRules,omitempty"`
+}
+
+// QuotaRule selects the DNS entries quota for the 'external' provider of shoots. All given criteria must match.
+type QuotaRule struct {
+	// Name is the name of the rule. It is reported as source of the quota in the extension status.
+	Name string `json:"name"`
+	// Purposes are the shoot purposes matched by the rule. Shoots without purpose have the purpose 'evaluation'.
+	// +optional
+	Purposes []gardencorev1beta1.ShootPurpose `json:"purposes,omitempty"`
+	// Projects are the names of the projects matched by the rule.
+	// +optional
+	Projects []string `json:"projects,omitempty"`
+	// LabelSelector selects the shoots by labels.
+	// +optional
+	LabelSelector *metav1.LabelSelector `json:"labelSelector,omitempty"`
+	// DefaultExternalProviderEntries is the DNS entries quota for the 'external' provider (0 = unlimited).
+	DefaultExternalProviderEntries int32 `json:"defaultExternalProviderEntries"`
+	// DefaultExternalProviderEntriesMax is the maximum quota shoots can request via annotation.
+	// If not set, the quota of the rule is also the maximum.
+	// +optional
+	DefaultExternalProviderEntriesMax *int32 `json:"defaultExternalProviderEntriesMax,omitempty"`
+}
+
+// NextGenerationConfiguration contains the settings of the next generation DNS controller.
+type NextGenerationConfiguration struct {
+	// Enabled enables the deployment of the next generation DNS controller for all shoots. It can still be disabled
+	// per shoot via the extension providerConfig.
+	// If not set, the command line flag --use-next-generation-controller is used.
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+	// ZoneNameservers is a static mapping from zone to nameserver (for testing).
+	// +optional
+	ZoneNameservers map[string]string `json:"zoneNameservers,omitempty"`
+}
+
+// TimeoutConfiguration contains the timeouts of the lifecycle controller.
+type TimeoutConfiguration struct {
+	// ManagedResourceDeletion is the timeout for the deletion of the managed resources of a shoot.
+	// Defaults to 2m.
+	// +optional
+	ManagedResourceDeletion *metav1.Duration `json:"managedResourceDeletion,omitempty"`
+	// DNSProviderReady is the timeout for a DNS provider to become ready.
+	// Defaults to 2m.
+	// +optional
+	DNSProviderReady *metav1.Duration `json:"dnsProviderReady,omitempty"`
+	// DNSProviderDeletion is the timeout for the deletion of a DNS provider.
+	// Defaults to 2m.
+	// +optional
+	DNSProviderDeletion *metav1.Duration `json:"dnsProviderDeletion,omitempty"`
+	// EntryReconciliation is the minimum time to wait for the reconciliation of the DNS entries on restore.
+	// Defaults to 3m.
+	// +optional
+	EntryReconciliation *metav1.Duration `json:"entryReconciliation,omitempty"`
+	// EntryReconciliationMax is the maximum time to wait for the reconciliation of the DNS entries on restore.
+	// Defaults to 30m.
+	// +optional
+	EntryReconciliationMax *metav1.Duration `json:"entryReconciliationMax,omitempty"`
+}
+
+// DNSEntriesHealthCheckConfiguration contains the settings of the health check of the DNS entries.
+type DNSEntriesHealthCheckConfiguration struct {
+	// FailureThreshold is the number of failing DNS entries from which on the health check reports an unhealthy
+	// condition (0 = disabled).
+	// If not set, the command line flag --healthcheck-dns-entries-failure-threshold is used.
+	// +optional
+	FailureThreshold *int32 `json:"failureThreshold,omitempty"`
+	// MaxReportedFailures is the maximum number of failing DNS names listed in the condition message.
+	// If not set, the command line flag --healthcheck-dns-entries-max-reported-failures is used.
+	// +optional
+	MaxReportedFailures *int32 `json:"maxReportedFailures,omitempty"`
+}
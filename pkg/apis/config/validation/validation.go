@@ -0,0 +1,155 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package validation
+
+import (
+	"slices"
+
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	metav1validation "k8s.io/apimachinery/pkg/apis/meta/v1/validation"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/utils/ptr"
+
+	"github.com/gardener/gardener-extension-shoot-dns-service/pkg/apis/config"
+)
+
+var availableShootPurposes = []gardencorev1beta1.ShootPurpose{
+	gardencorev1beta1.ShootPurposeEvaluation,
+	gardencorev1beta1.ShootPurposeTesting,
+	gardencorev1beta1.ShootPurposeDevelopment,
+	gardencorev1beta1.ShootPurposeProduction,
+	gardencorev1beta1.ShootPurposeInfrastructure,
+}
+
+// ValidateConfiguration validates the passed controller configuration.
+func ValidateConfiguration(cfg *config.ControllerConfiguration) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if cfg.ClientConnection != nil {
+		path := field.NewPath("clientConnection")
+		if cfg.ClientConnection.QPS < 0 {
+			allErrs = append(allErrs, field.Invalid(path.Child("qps"), cfg.ClientConnection.QPS, "must not be negative"))
+		}
+		if cfg.ClientConnection.Burst < 0 {
+			allErrs = append(allErrs, field.Invalid(path.Child("burst"), cfg.ClientConnection.Burst, "must not be negative"))
+		}
+	}
+	if cfg.Quotas != nil {
+		allErrs = append(allErrs, validateQuotas(cfg.Quotas, field.NewPath("quotas"))...)
+	}
+	if cfg.NextGeneration != nil {
+		path := field.NewPath("nextGeneration", "zoneNameservers")
+		for zone, nameserver := range cfg.NextGeneration.ZoneNameservers {
+			if zone == "" || nameserver == "" {
+				allErrs = append(allErrs, field.Invalid(path.Key(zone), nameserver, "zone or nameserver cannot be empty"))
+			}
+		}
+	}
+	if cfg.Timeouts != nil {
+		allErrs = append(allErrs, validateTimeouts(cfg.Timeouts, field.NewPath("timeouts"))...)
+	}
+	if cfg.HealthCheckConfig != nil && cfg.HealthCheckConfig.SyncPeriod.Duration <= 0 {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("healthCheckConfig", "syncPeriod"), cfg.HealthCheckConfig.SyncPeriod.Duration.String(), "must be positive"))
+	}
+	if cfg.DNSEntriesHealthCheck != nil {
+		path := field.NewPath("dnsEntriesHealthCheck")
+		if value := ptr.Deref(cfg.DNSEntriesHealthCheck.FailureThreshold, 0); value < 0 {
+			allErrs = append(allErrs, field.Invalid(path.Child("failureThreshold"), value, "must not be negative"))
+		}
+		if value := cfg.DNSEntriesHealthCheck.MaxReportedFailures; value != nil && *value < 1 {
+			allErrs = append(allErrs, field.Invalid(path.Child("maxReportedFailures"), *value, "must be positive"))
+		}
+	}
+
+	return allErrs
+}
+
+func validateQuotas(quotas *config.QuotaConfiguration, path *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	for _, f := range []struct {
+		name  string
+		value *int32
+	}{
+		{"defaultExternalProviderEntries", quotas.DefaultExternalProviderEntries},
+		{"defaultExternalProviderEntriesMax", quotas.DefaultExternalProviderEntriesMax},
+		{"providerEntriesMax", quotas.ProviderEntriesMax},
+		{"providerRequestsPerDayMax", quotas.ProviderRequestsPerDayMax},
+		{"providerBurstMax", quotas.ProviderBurstMax},
+	} {
+		if f.value != nil && *f.value < 0 {
+			allErrs = append(allErrs, field.Invalid(path.Child(f.name), *f.value, "must not be negative"))
+		}
+	}
+	defaultQuota := ptr.Deref(quotas.DefaultExternalProviderEntries, 0)
+	maxQuota := ptr.Deref(quotas.DefaultExternalProviderEntriesMax, 0)
+	if maxQuota > 0 && maxQuota < defaultQuota {
+		allErrs = append(allErrs, field.Invalid(path.Child("defaultExternalProviderEntriesMax"), maxQuota, "must not be less than defaultExternalProviderEntries"))
+	}
+	names := sets.New[string]()
+	for i, rule := range quotas.DefaultExternalProviderEntriesRules {
+		rulePath := path.Child("defaultExternalProviderEntriesRules").Index(i)
+		switch {
+		case rule.Name == "":
+			allErrs = append(allErrs, field.Required(rulePath.Child("name"), "rule name is required"))
+		case names.Has(rule.Name):
+			allErrs = append(allErrs, field.Duplicate(rulePath.Child("name"), rule.Name))
+		}
+		names.Insert(rule.Name)
+		allErrs = append(allErrs, validateQuotaRule(rule, rulePath)...)
+	}
+
+	return allErrs
+}
+
+func validateQuotaRule(rule config.QuotaRule, path *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	for i, purpose := range rule.Purposes {
+		if !slices.Contains(availableShootPurposes, purpose) {
+			allErrs = append(allErrs, field.NotSupported(path.Child("purposes").Index(i), purpose, availableShootPurposes))
+		}
+	}
+	if rule.LabelSelector != nil {
+		allErrs = append(allErrs, metav1validation.ValidateLabelSelector(rule.LabelSelector, metav1validation.LabelSelectorValidationOptions{}, path.Child("labelSelector"))...)
+	}
+	if rule.DefaultExternalProviderEntries < 0 {
+		allErrs = append(allErrs, field.Invalid(path.Child("defaultExternalProviderEntries"), rule.DefaultExternalProviderEntries, "must not be negative"))
+	}
+	if maxQuota := ptr.Deref(rule.DefaultExternalProviderEntriesMax, 0); maxQuota < 0 {
+		allErrs = append(allErrs, field.Invalid(path.Child("defaultExternalProviderEntriesMax"), maxQuota, "must not be negative"))
+	} else if maxQuota > 0 && maxQuota < rule.DefaultExternalProviderEntries {
+		allErrs = append(allErrs, field.Invalid(path.Child("defaultExternalProviderEntriesMax"), maxQuota, "must not be less than defaultExternalProviderEntries"))
+	}
+
+	return allErrs
+}
+
+func validateTimeouts(timeouts *config.TimeoutConfiguration, path *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	for _, f := range []struct {
+		name  string
+		value *metav1.Duration
+	}{
+		{"managedResourceDeletion", timeouts.ManagedResourceDeletion},
+		{"dnsProviderReady", timeouts.DNSProviderReady},
+		{"dnsProviderDeletion", timeouts.DNSProviderDeletion},
+		{"entryReconciliation", timeouts.EntryReconciliation},
+		{"entryReconciliationMax", timeouts.EntryReconciliationMax},
+	} {
+		if f.value != nil && f.value.Duration <= 0 {
+			allErrs = append(allErrs, field.Invalid(path.Child(f.name), f.value.Duration.String(), "must be positive"))
+		}
+	}
+	if timeouts.EntryReconciliation != nil && timeouts.EntryReconciliationMax != nil &&
+		timeouts.EntryReconciliationMax.Duration < timeouts.EntryReconciliation.Duration {
+		allErrs = append(allErrs, field.Invalid(path.Child("entryReconciliationMax"), timeouts.EntryReconciliationMax.Duration.String(), "must not be less than entryReconciliation"))
+	}
+
+	return allErrs
+}
@@ -0,0 +1,100 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package validation_test
+
+import (
+	"time"
+
+	healthcheckconfigv1alpha1 "github.com/gardener/gardener/extensions/pkg/apis/config/v1alpha1"
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	componentbaseconfigv1alpha1 "k8s.io/component-base/config/v1alpha1"
+
+	"github.com/gardener/gardener-extension-shoot-dns-service/pkg/apis/config"
+	"github.com/gardener/gardener-extension-shoot-dns-service/pkg/apis/config/validation"
+)
+
+var _ = Describe("ValidateConfiguration", func() {
+	var cfg *config.ControllerConfiguration
+
+	BeforeEach(func() {
+		cfg = &config.ControllerConfiguration{
+			ClientConnection: &componentbaseconfigv1alpha1.ClientConnectionConfiguration{QPS: 100, Burst: 130},
+			Quotas: &config.QuotaConfiguration{
+				DefaultExternalProviderEntries:    new(int32(100)),
+				DefaultExternalProviderEntriesMax: new(int32(1000)),
+				DefaultExternalProviderEntriesRules: []config.QuotaRule{
+					{
+						Name:                           "production",
+						Purposes:                       []gardencorev1beta1.ShootPurpose{gardencorev1beta1.ShootPurposeProduction},
+						DefaultExternalProviderEntries: 500,
+					},
+				},
+			},
+			NextGeneration: &config.NextGenerationConfiguration{
+				ZoneNameservers: map[string]string{"example.com": "ns1.example.com"},
+			},
+			Timeouts: &config.TimeoutConfiguration{
+				EntryReconciliation:    &metav1.Duration{Duration: 3 * time.Minute},
+				EntryReconciliationMax: &metav1.Duration{Duration: 30 * time.Minute},
+			},
+			HealthCheckConfig: &healthcheckconfigv1alpha1.HealthCheckConfig{SyncPeriod: metav1.Duration{Duration: 30 * time.Second}},
+			DNSEntriesHealthCheck: &config.DNSEntriesHealthCheckConfiguration{
+				FailureThreshold:    new(int32(1)),
+				MaxReportedFailures: new(int32(5)),
+			},
+		}
+	})
+
+	It("should accept a valid configuration", func() {
+		Expect(validation.ValidateConfiguration(cfg)).To(BeEmpty())
+	})
+
+	It("should accept an empty configuration", func() {
+		Expect(validation.ValidateConfiguration(&config.ControllerConfiguration{})).To(BeEmpty())
+	})
+
+	It("should reject invalid values", func() {
+		cfg.ClientConnection.Burst = -1
+		cfg.Quotas.ProviderBurstMax = new(int32(-1))
+		cfg.Quotas.DefaultExternalProviderEntriesMax = new(int32(50))
+		cfg.NextGeneration.ZoneNameservers["example.org"] = ""
+		cfg.Timeouts.DNSProviderReady = &metav1.Duration{}
+		cfg.Timeouts.EntryReconciliationMax = &metav1.Duration{Duration: time.Minute}
+		cfg.HealthCheckConfig.SyncPeriod = metav1.Duration{}
+		cfg.DNSEntriesHealthCheck.MaxReportedFailures = new(int32(0))
+		cfg.Quotas.DefaultExternalProviderEntriesRules = append(cfg.Quotas.DefaultExternalProviderEntriesRules,
+			config.QuotaRule{
+				Name:                              "production",
+				Purposes:                          []gardencorev1beta1.ShootPurpose{"staging"},
+				LabelSelector:                     &metav1.LabelSelector{MatchLabels: map[string]string{"in valid": "true"}},
+				DefaultExternalProviderEntries:    100,
+				DefaultExternalProviderEntriesMax: new(int32(10)),
+			},
+			config.QuotaRule{DefaultExternalProviderEntries: -1},
+		)
+
+		Expect(validation.ValidateConfiguration(cfg)).To(ConsistOf(
+			PointTo(MatchFields(IgnoreExtras, Fields{"Type": Equal(field.ErrorTypeInvalid), "Field": Equal("clientConnection.burst")})),
+			PointTo(MatchFields(IgnoreExtras, Fields{"Type": Equal(field.ErrorTypeInvalid), "Field": Equal("quotas.providerBurstMax")})),
+			PointTo(MatchFields(IgnoreExtras, Fields{"Type": Equal(field.ErrorTypeInvalid), "Field": Equal("quotas.defaultExternalProviderEntriesMax")})),
+			PointTo(MatchFields(IgnoreExtras, Fields{"Type": Equal(field.ErrorTypeInvalid), "Field": Equal("nextGeneration.zoneNameservers[example.org]")})),
+			PointTo(MatchFields(IgnoreExtras, Fields{"Type": Equal(field.ErrorTypeInvalid), "Field": Equal("timeouts.dnsProviderReady")})),
+			PointTo(MatchFields(IgnoreExtras, Fields{"Type": Equal(field.ErrorTypeInvalid), "Field": Equal("timeouts.entryReconciliationMax")})),
+			PointTo(MatchFields(IgnoreExtras, Fields{"Type": Equal(field.ErrorTypeInvalid), "Field": Equal("healthCheckConfig.syncPeriod")})),
+			PointTo(MatchFields(IgnoreExtras, Fields{"Type": Equal(field.ErrorTypeInvalid), "Field": Equal("dnsEntriesHealthCheck.maxReportedFailures")})),
+			PointTo(MatchFields(IgnoreExtras, Fields{"Type": Equal(field.ErrorTypeDuplicate), "Field": Equal("quotas.defaultExternalProviderEntriesRules[1].name")})),
+			PointTo(MatchFields(IgnoreExtras, Fields{"Type": Equal(field.ErrorTypeNotSupported), "Field": Equal("quotas.defaultExternalProviderEntriesRules[1].purposes[0]")})),
+			PointTo(MatchFields(IgnoreExtras, Fields{"Type": Equal(field.ErrorTypeInvalid), "Field": Equal("quotas.defaultExternalProviderEntriesRules[1].labelSelector.matchLabels")})),
+			PointTo(MatchFields(IgnoreExtras, Fields{"Type": Equal(field.ErrorTypeInvalid), "Field": Equal("quotas.defaultExternalProviderEntriesRules[1].defaultExternalProviderEntriesMax")})),
+			PointTo(MatchFields(IgnoreExtras, Fields{"Type": Equal(field.ErrorTypeRequired), "Field": Equal("quotas.defaultExternalProviderEntriesRules[2].name")})),
+			PointTo(MatchFields(IgnoreExtras, Fields{"Type": Equal(field.ErrorTypeInvalid), "Field": Equal("quotas.defaultExternalProviderEntriesRules[2].defaultExternalProviderEntries")})),
+		))
+	})
+})
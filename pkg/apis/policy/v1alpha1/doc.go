@@ -0,0 +1,10 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// +k8s:deepcopy-gen=package
+
+// Package v1alpha1 contains the ShootDNSServicePolicy resource, which selects per-shoot settings of the
+// shoot-dns-service in the seed.
+// +groupName=policy.dns.extensions.gardener.cloud
+package v1alpha1 // import "github.com/gardener/gardener-extension-shoot-dns-service/pkg/apis/policy/v1alpha1"
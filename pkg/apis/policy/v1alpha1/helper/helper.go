@@ -0,0 +1,76 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package helper
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"slices"
+
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	"github.com/gardener/gardener-extension-shoot-dns-service/pkg/apis/policy/v1alpha1"
+)
+
+// FindRule returns the policy and its rule to apply for the given shoot of the given project, or nil if no rule matches.
+// The policies are evaluated by descending priority and name, the rules of a policy in their given order.
+// Rules with an invalid label selector never match, their errors are returned together with the result.
+func FindRule(policies []v1alpha1.ShootDNSServicePolicy, project string, shoot *gardencorev1beta1.Shoot) (*v1alpha1.ShootDNSServicePolicy, *v1alpha1.ShootDNSServicePolicyRule, error) {
+	sorted := slices.Clone(policies)
+	slices.SortFunc(sorted, func(a, b v1alpha1.ShootDNSServicePolicy) int {
+		if c := cmp.Compare(b.Spec.Priority, a.Spec.Priority); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.Name, b.Name)
+	})
+
+	var errs []error
+	for i := range sorted {
+		policy := &sorted[i]
+		for j := range policy.Spec.Rules {
+			rule := &policy.Spec.Rules[j]
+			matches, err := Matches(rule.Selector, project, shoot)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("invalid selector of rule %q of policy %q: %w", rule.Name, policy.Name, err))
+				continue
+			}
+			if matches {
+				return policy, rule, errors.Join(errs...)
+			}
+		}
+	}
+	return nil, nil, errors.Join(errs...)
+}
+
+// Matches returns true if the selector matches the given shoot of the given project.
+func Matches(selector v1alpha1.ShootSelector, project string, shoot *gardencorev1beta1.Shoot) (bool, error) {
+	if len(selector.Projects) > 0 && !slices.Contains(selector.Projects, project) {
+		return false, nil
+	}
+	if len(selector.Purposes) > 0 && !slices.Contains(selector.Purposes, ShootPurpose(shoot)) {
+		return false, nil
+	}
+	if selector.LabelSelector != nil {
+		labelSelector, err := metav1.LabelSelectorAsSelector(selector.LabelSelector)
+		if err != nil {
+			return false, err
+		}
+		if !labelSelector.Matches(labels.Set(shoot.Labels)) {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// ShootPurpose returns the purpose of the shoot. Shoots without purpose have the purpose "evaluation".
+func ShootPurpose(shoot *gardencorev1beta1.Shoot) gardencorev1beta1.ShootPurpose {
+	if shoot.Spec.Purpose == nil {
+		return gardencorev1beta1.ShootPurposeEvaluation
+	}
+	return *shoot.Spec.Purpose
+}
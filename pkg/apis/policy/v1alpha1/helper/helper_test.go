@@ -0,0 +1,116 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package helper_test
+
+import (
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/gardener/gardener-extension-shoot-dns-service/pkg/apis/policy/v1alpha1"
+	. "github.com/gardener/gardener-extension-shoot-dns-service/pkg/apis/policy/v1alpha1/helper"
+)
+
+var _ = Describe("Helper", func() {
+	var (
+		shoot *gardencorev1beta1.Shoot
+
+		newPolicy = func(name string, priority int32, rules ...v1alpha1.ShootDNSServicePolicyRule) v1alpha1.ShootDNSServicePolicy {
+			return v1alpha1.ShootDNSServicePolicy{
+				ObjectMeta: metav1.ObjectMeta{Name: name},
+				Spec:       v1alpha1.ShootDNSServicePolicySpec{Priority: priority, Rules: rules},
+			}
+		}
+		ruleNames = func(policy *v1alpha1.ShootDNSServicePolicy, rule *v1alpha1.ShootDNSServicePolicyRule) []string {
+			if policy == nil {
+				return nil
+			}
+			return []string{policy.Name, rule.Name}
+		}
+	)
+
+	BeforeEach(func() {
+		shoot = &gardencorev1beta1.Shoot{
+			ObjectMeta: metav1.ObjectMeta{Name: "bar", Namespace: "garden-foo", Labels: map[string]string{"tier": "gold"}},
+			Spec:       gardencorev1beta1.ShootSpec{Purpose: new(gardencorev1beta1.ShootPurposeProduction)},
+		}
+	})
+
+	Describe("#Matches", func() {
+		It("should match all shoots with an empty selector", func() {
+			Expect(Matches(v1alpha1.ShootSelector{}, "foo", shoot)).To(BeTrue())
+		})
+
+		It("should require all criteria to match", func() {
+			selector := v1alpha1.ShootSelector{
+				Projects:      []string{"foo"},
+				Purposes:      []gardencorev1beta1.ShootPurpose{gardencorev1beta1.ShootPurposeProduction},
+				LabelSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"tier": "gold"}},
+			}
+			Expect(Matches(selector, "foo", shoot)).To(BeTrue())
+			Expect(Matches(selector, "other", shoot)).To(BeFalse())
+			shoot.Labels = nil
+			Expect(Matches(selector, "foo", shoot)).To(BeFalse())
+		})
+
+		It("should treat shoots without purpose as evaluation shoots", func() {
+			shoot.Spec.Purpose = nil
+			selector := v1alpha1.ShootSelector{Purposes: []gardencorev1beta1.ShootPurpose{gardencorev1beta1.ShootPurposeEvaluation}}
+			Expect(Matches(selector, "foo", shoot)).To(BeTrue())
+		})
+
+		It("should fail for an invalid label selector", func() {
+			selector := v1alpha1.ShootSelector{LabelSelector: &metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{{Key: "tier", Operator: "bad"}}}}
+			_, err := Matches(selector, "foo", shoot)
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Describe("#FindRule", func() {
+		It("should return nothing if no rule matches", func() {
+			policy, rule, err := FindRule([]v1alpha1.ShootDNSServicePolicy{
+				newPolicy("a", 0, v1alpha1.ShootDNSServicePolicyRule{Name: "other", Selector: v1alpha1.ShootSelector{Projects: []string{"other"}}}),
+			}, "foo", shoot)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(policy).To(BeNil())
+			Expect(rule).To(BeNil())
+		})
+
+		It("should apply the first matching rule of the policy with the highest priority", func() {
+			policy, rule, err := FindRule([]v1alpha1.ShootDNSServicePolicy{
+				newPolicy("b", 0, v1alpha1.ShootDNSServicePolicyRule{Name: "all"}),
+				newPolicy("c", 10,
+					v1alpha1.ShootDNSServicePolicyRule{Name: "other", Selector: v1alpha1.ShootSelector{Projects: []string{"other"}}},
+					v1alpha1.ShootDNSServicePolicyRule{Name: "production", Selector: v1alpha1.ShootSelector{Purposes: []gardencorev1beta1.ShootPurpose{gardencorev1beta1.ShootPurposeProduction}}},
+					v1alpha1.ShootDNSServicePolicyRule{Name: "all"},
+				),
+				newPolicy("a", 0, v1alpha1.ShootDNSServicePolicyRule{Name: "all"}),
+			}, "foo", shoot)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ruleNames(policy, rule)).To(Equal([]string{"c", "production"}))
+		})
+
+		It("should order policies of the same priority by name", func() {
+			policy, rule, err := FindRule([]v1alpha1.ShootDNSServicePolicy{
+				newPolicy("b", 0, v1alpha1.ShootDNSServicePolicyRule{Name: "all"}),
+				newPolicy("a", 0, v1alpha1.ShootDNSServicePolicyRule{Name: "all"}),
+			}, "foo", shoot)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ruleNames(policy, rule)).To(Equal([]string{"a", "all"}))
+		})
+
+		It("should skip rules with invalid selectors and report them", func() {
+			policy, rule, err := FindRule([]v1alpha1.ShootDNSServicePolicy{
+				newPolicy("a", 0,
+					v1alpha1.ShootDNSServicePolicyRule{Name: "invalid", Selector: v1alpha1.ShootSelector{LabelSelector: &metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{{Key: "tier", Operator: "bad"}}}}},
+					v1alpha1.ShootDNSServicePolicyRule{Name: "all"},
+				),
+			}, "foo", shoot)
+			Expect(err).To(MatchError(ContainSubstring(`rule "invalid" of policy "a"`)))
+			Expect(ruleNames(policy, rule)).To(Equal([]string{"a", "all"}))
+		})
+	})
+})
@@ -0,0 +1,105 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha1
+
+import (
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +genclient
+// +genclient:nonNamespaced
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:resource:scope=Cluster,shortName=sdnspolicy
+// +kubebuilder:printcolumn:name="Priority",type="integer",JSONPath=".spec.priority"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+
+// ShootDNSServicePolicy contains rules selecting shoots by project, purpose and labels, and the settings of the
+// shoot-dns-service for the selected shoots. Of all policies, the one with the highest priority having a matching
+// rule is applied. Within a policy, the first matching rule is applied.
+type ShootDNSServicePolicy struct {
+	metav1.TypeMeta `json:",inline"`
+	// Standard object metadata.
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// Spec contains the rules of the policy.
+	Spec ShootDNSServicePolicySpec `json:"spec"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ShootDNSServicePolicyList is a list of ShootDNSServicePolicy resources.
+type ShootDNSServicePolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	// Standard list object metadata.
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	// Items is the list of ShootDNSServicePolicies.
+	Items []ShootDNSServicePolicy `json:"items"`
+}
+
+// ShootDNSServicePolicySpec is the specification of a ShootDNSServicePolicy.
+type ShootDNSServicePolicySpec struct {
+	// Priority of the policy. If rules of several policies match a shoot, the policy with the highest priority is
+	// applied. Policies with the same priority are ordered by name.
+	// +optional
+	Priority int32 `json:"priority,omitempty"`
+	// Rules are the rules of the policy. The first rule matching a shoot is applied.
+	Rules []ShootDNSServicePolicyRule `json:"rules"`
+}
+
+// ShootDNSServicePolicyRule selects shoots and contains the settings to apply for them.
+type ShootDNSServicePolicyRule struct {
+	// Name is the name of the rule, which is reported in the status of the extension.
+	Name string `json:"name"`
+	// Selector selects the shoots the rule applies to. An empty selector matches all shoots.
+	// +optional
+	Selector ShootSelector `json:"selector,omitempty"`
+	// Settings are the settings applied to the selected shoots.
+	Settings ShootDNSServiceSettings `json:"settings"`
+}
+
+// ShootSelector selects shoots. All given criteria must match.
+type ShootSelector struct {
+	// Projects are the names of the projects of the selected shoots.
+	// +optional
+	Projects []string `json:"projects,omitempty"`
+	// Purposes are the purposes of the selected shoots. Shoots without purpose have the purpose "evaluation".
+	// +optional
+	Purposes []gardencorev1beta1.ShootPurpose `json:"purposes,omitempty"`
+	// LabelSelector selects the shoots by their labels.
+	// +optional
+	LabelSelector *metav1.LabelSelector `json:"labelSelector,omitempty"`
+}
+
+// ShootDNSServiceSettings are the settings of the shoot-dns-service for a shoot.
+// Unset fields keep the behaviour given by the command line flags, seed labels and shoot annotations.
+type ShootDNSServiceSettings struct {
+	// DefaultExternalProviderEntriesQuota is the DNS entries quota of the 'external' provider when using the default
+	// domain (0 = unlimited).
+	// +optional
+	DefaultExternalProviderEntriesQuota *int32 `json:"defaultExternalProviderEntriesQuota,omitempty"`
+	// DefaultExternalProviderEntriesQuotaMax is the maximum quota of the 'external' provider, which can be requested
+	// with the shoot annotation `service.dns.extensions.gardener.cloud/default-external-provider-entries-quota`.
+	// +optional
+	DefaultExternalProviderEntriesQuotaMax *int32 `json:"defaultExternalProviderEntriesQuotaMax,omitempty"`
+	// UseNextGenerationController enables the next generation DNS controller by default. It can still be disabled
+	// per shoot via the extension providerConfig, unless forced by the seed label
+	// `service.dns.extensions.gardener.cloud/use-next-generation-controller`.
+	// +optional
+	UseNextGenerationController *bool `json:"useNextGenerationController,omitempty"`
+	// DNSProviderReplication enables the replication of DNS providers from the shoot cluster by default. It can still be
+	// overwritten per shoot via the extension providerConfig.
+	// +optional
+	DNSProviderReplication *bool `json:"dnsProviderReplication,omitempty"`
+	// AllowedProviderTypes are the allowed types of additional DNS providers. If set, they restrict the provider types
+	// allowed by the command line flags further, types denied there cannot be allowed again.
+	// +optional
+	AllowedProviderTypes []string `json:"allowedProviderTypes,omitempty"`
+	// ControllerLogLevel is the log level of the DNS controller deployed in the shoot namespace.
+	// +kubebuilder:validation:Enum=debug;info;error
+	// +optional
+	ControllerLogLevel *string `json:"controllerLogLevel,omitempty"`
+}
@@ -5,7 +5,9 @@
 package service
 
 import (
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 )
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -28,8 +30,25 @@ type DNSConfig struct {
 
 	// UseNextGenerationController is an optional flag to enable the next generation DNS controller for this shoot cluster.
 	UseNextGenerationController *bool
+
+	// Hibernation specifies the handling of the DNS records of the shoot cluster during hibernation.
+	// Defaults to "deleteRecords".
+	Hibernation *HibernationMode
 }
 
+// HibernationMode specifies the handling of the DNS records of a shoot cluster during hibernation.
+type HibernationMode string
+
+const (
+	// HibernationModeDeleteRecords deletes the DNS records during hibernation. They are recreated on wake-up.
+	HibernationModeDeleteRecords HibernationMode = "deleteRecords"
+	// HibernationModeKeepRecords keeps the DNS records published during hibernation.
+	HibernationModeKeepRecords HibernationMode = "keepRecords"
+	// HibernationModeRedirect rewrites the targets of the DNS records to the hibernation endpoint configured by the
+	// operator during hibernation. The original targets are restored on wake-up.
+	HibernationModeRedirect HibernationMode = "redirect"
+)
+
 // DNSProviderReplication contains enablement for replication of DNSProviders from shoot cluster to control plane
 type DNSProviderReplication struct {
 	// Enabled if true, the replication of DNSProviders from shoot cluster to the control plane is enabled
@@ -46,10 +65,34 @@ type DNSProvider struct {
 	// Credentials is the name of the resource reference containing the credentials for the provider.
 	// It is an alternative to SecretName and can reference either a secret or a workload identity.
 	Credentials *string
+	// CredentialsBindingName is the name of a CredentialsBinding in the project namespace referencing the credentials
+	// for the provider. It is an alternative to SecretName and Credentials.
+	CredentialsBindingName *string
 	// Type is the DNS provider type.
 	Type *string
 	// Zones contains information about which hosted zones shall be included/excluded for this provider.
 	Zones *DNSIncludeExclude
+	// Quotas contains restrictions on the DNS entries managed by this provider.
+	Quotas *DNSProviderQuotas
+	// RateLimit restricts the rate of create/update operations on DNS entries assigned to this provider.
+	RateLimit *DNSProviderRateLimit
+	// ProviderConfig contains provider-specific configuration passed to the DNSProvider resource.
+	ProviderConfig *runtime.RawExtension
+}
+
+// DNSProviderQuotas contains restrictions on the DNS entries managed by a provider.
+type DNSProviderQuotas struct {
+	// Entries is the maximum number of DNS entries allowed to be managed by this provider.
+	Entries *int32
+}
+
+// DNSProviderRateLimit contains the rate limit for create/update operations on DNS entries of a provider.
+type DNSProviderRateLimit struct {
+	// RequestsPerDay is the create/update request rate per DNS entry given by requests per day.
+	RequestsPerDay int32
+	// Burst allows bursts of up to 'burst' to exceed the rate defined by 'RequestsPerDay', while still maintaining a
+	// smoothed rate of 'RequestsPerDay'.
+	Burst *int32
 }
 
 // DNSIncludeExclude contains information about which domains shall be included/excluded.
@@ -59,3 +102,84 @@ type DNSIncludeExclude struct {
 	// Exclude is a list of domains that shall be excluded.
 	Exclude []string
 }
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// DNSStatus contains information about the DNS providers and entries managed for a shoot cluster.
+// It is written to the provider status of the extension.
+type DNSStatus struct {
+	metav1.TypeMeta
+
+	// Providers contains the status of the DNS providers managed in the control plane.
+	Providers []DNSProviderStatus
+	// Entries contains the number of shoot DNS entries by state.
+	Entries DNSEntriesStatus
+	// Policy is the rule of the ShootDNSServicePolicy applied to the shoot, if any.
+	Policy *DNSPolicyStatus
+	// DisallowedProviders are the additional DNS providers of the provider config which are not deployed, as their
+	// type is not allowed for the shoot.
+	DisallowedProviders []DNSDisallowedProviderStatus
+}
+
+// DNSDisallowedProviderStatus describes an additional DNS provider which is not deployed as its type is not allowed.
+type DNSDisallowedProviderStatus struct {
+	// Index is the index of the provider in the provider config of the extension.
+	Index int32
+	// Type is the DNS provider type.
+	Type string
+	// Message explains why the provider type is not allowed.
+	Message string
+}
+
+// DNSPolicyStatus identifies the rule of a ShootDNSServicePolicy applied to a shoot.
+type DNSPolicyStatus struct {
+	// Name is the name of the ShootDNSServicePolicy.
+	Name string
+	// Rule is the name of the applied rule.
+	Rule string
+}
+
+// DNSProviderStatus contains the status of a DNS provider managed in the control plane.
+type DNSProviderStatus struct {
+	// Name is the name of the DNSProvider resource.
+	Name string
+	// Type is the DNS provider type.
+	Type string
+	// State is the state of the DNSProvider resource.
+	State string
+	// Message is the status message of the DNSProvider resource.
+	Message *string
+	// ErrorCodes are the error codes detected in the status message.
+	ErrorCodes []gardencorev1beta1.ErrorCode
+	// EntriesQuota contains the usage of the entries quota. It is only set for the "external" provider.
+	EntriesQuota *DNSProviderQuotaUsage
+}
+
+// DNSProviderQuotaUsage contains the usage of the entries quota of a DNS provider.
+type DNSProviderQuotaUsage struct {
+	// Used is the number of shoot DNS entries assigned to the provider.
+	Used int32
+	// Limit is the entries quota of the provider. 0 means unlimited.
+	Limit int32
+	// Source is the origin of the limit: `default` for the configured default quota, `rule:<name>` for a quota rule of
+	// the controller configuration, `policy:<name>/<rule>` for a ShootDNSServicePolicy, or `annotation` for the quota
+	// requested by shoot annotation.
+	Source string
+	// Message explains why the limit differs from the quota requested by shoot annotation, e.g. if it exceeds the
+	// maximum.
+	Message string
+}
+
+// DNSEntriesStatus contains the number of shoot DNS entries by state.
+type DNSEntriesStatus struct {
+	// Total is the number of all shoot DNS entries.
+	Total int32
+	// Ready is the number of entries in state Ready.
+	Ready int32
+	// Pending is the number of entries in state Pending or without state.
+	Pending int32
+	// Error is the number of entries in state Error or Invalid.
+	Error int32
+	// Stale is the number of entries in state Stale.
+	Stale int32
+}
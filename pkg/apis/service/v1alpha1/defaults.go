@@ -8,6 +8,16 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 )
 
+// DefaultRateLimitBurst is the burst used for a provider rate limit if not specified explicitly.
+const DefaultRateLimitBurst int32 = 20
+
 func addDefaultingFuncs(scheme *runtime.Scheme) error {
 	return RegisterDefaults(scheme)
 }
+
+// SetDefaults_DNSProviderRateLimit sets default values for DNSProviderRateLimit objects.
+func SetDefaults_DNSProviderRateLimit(obj *DNSProviderRateLimit) {
+	if obj.Burst == nil {
+		obj.Burst = new(DefaultRateLimitBurst)
+	}
+}
@@ -30,6 +30,7 @@ var (
 func addKnownTypes(scheme *runtime.Scheme) error {
 	scheme.AddKnownTypes(SchemeGroupVersion,
 		&DNSConfig{},
+		&DNSStatus{},
 	)
 	return nil
 }
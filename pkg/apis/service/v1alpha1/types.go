@@ -5,7 +5,9 @@
 package v1alpha1
 
 import (
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 )
 
 // +genclient
@@ -33,8 +35,26 @@ type DNSConfig struct {
 	// UseNextGenerationController is an optional flag to enable the next generation DNS controller for this shoot cluster.
 	// +optional
 	UseNextGenerationController *bool `json:"useNextGenerationController,omitempty"`
+
+	// Hibernation specifies the handling of the DNS records of the shoot cluster during hibernation.
+	// Defaults to "deleteRecords".
+	// +optional
+	Hibernation *HibernationMode `json:"hibernation,omitempty"`
 }
 
+// HibernationMode specifies the handling of the DNS records of a shoot cluster during hibernation.
+type HibernationMode string
+
+const (
+	// HibernationModeDeleteRecords deletes the DNS records during hibernation. They are recreated on wake-up.
+	HibernationModeDeleteRecords HibernationMode = "deleteRecords"
+	// HibernationModeKeepRecords keeps the DNS records published during hibernation.
+	HibernationModeKeepRecords HibernationMode = "keepRecords"
+	// HibernationModeRedirect rewrites the targets of the DNS records to the hibernation endpoint configured by the
+	// operator during hibernation. The original targets are restored on wake-up.
+	HibernationModeRedirect HibernationMode = "redirect"
+)
+
 // DNSProviderReplication contains enablement for replication of DNSProviders from shoot cluster to control plane
 type DNSProviderReplication struct {
 	// Enabled if true, the replication of DNSProviders from shoot cluster to the control plane is enabled
@@ -55,12 +75,50 @@ type DNSProvider struct {
 	// It is an alternative to SecretName and can reference either a secret or a workload identity.
 	// +optional
 	Credentials *string `json:"credentials,omitempty"`
+	// CredentialsBindingName is the name of a CredentialsBinding in the project namespace referencing the credentials
+	// for the provider. It is an alternative to SecretName and Credentials and allows to share credentials maintained in
+	// another namespace. Only the CredentialsBinding of the shoot (`spec.credentialsBindingName`) is synced into the
+	// seed and can be referenced. Its provider type must match the DNS provider type, e.g. `aws` for `aws-route53`.
+	// +optional
+	CredentialsBindingName *string `json:"credentialsBindingName,omitempty"`
 	// Type is the DNS provider type.
 	// +optional
 	Type *string `json:"type,omitempty"`
 	// Zones contains information about which hosted zones shall be included/excluded for this provider.
 	// +optional
 	Zones *DNSIncludeExclude `json:"zones,omitempty"`
+	// Quotas contains restrictions on the DNS entries managed by this provider.
+	// The values are bounded by the maximums configured by the operator of the extension.
+	// +optional
+	Quotas *DNSProviderQuotas `json:"quotas,omitempty"`
+	// RateLimit restricts the rate of create/update operations on DNS entries assigned to this provider.
+	// The values are bounded by the maximums configured by the operator of the extension.
+	// +optional
+	RateLimit *DNSProviderRateLimit `json:"rateLimit,omitempty"`
+	// ProviderConfig contains provider-specific configuration passed to the DNSProvider resource,
+	// e.g. the batch size for `aws-route53` or the view for `infoblox-dns`.
+	// See the documentation of the external-dns-management project for the supported options of each provider type.
+	// +kubebuilder:validation:XPreserveUnknownFields
+	// +kubebuilder:pruning:PreserveUnknownFields
+	// +optional
+	ProviderConfig *runtime.RawExtension `json:"providerConfig,omitempty"`
+}
+
+// DNSProviderQuotas contains restrictions on the DNS entries managed by a provider.
+type DNSProviderQuotas struct {
+	// Entries is the maximum number of DNS entries allowed to be managed by this provider.
+	// +optional
+	Entries *int32 `json:"entries,omitempty"`
+}
+
+// DNSProviderRateLimit contains the rate limit for create/update operations on DNS entries of a provider.
+type DNSProviderRateLimit struct {
+	// RequestsPerDay is the create/update request rate per DNS entry given by requests per day.
+	RequestsPerDay int32 `json:"requestsPerDay"`
+	// Burst allows bursts of up to 'burst' to exceed the rate defined by 'RequestsPerDay', while still maintaining a
+	// smoothed rate of 'RequestsPerDay'. Defaults to 20.
+	// +optional
+	Burst *int32 `json:"burst,omitempty"`
 }
 
 // DNSIncludeExclude contains information about which domains shall be included/excluded.
@@ -72,3 +130,92 @@ type DNSIncludeExclude struct {
 	// +optional
 	Exclude []string `json:"exclude,omitempty"`
 }
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// DNSStatus contains information about the DNS providers and entries managed for a shoot cluster.
+// It is written to the provider status of the extension.
+type DNSStatus struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// Providers contains the status of the DNS providers managed in the control plane.
+	// +optional
+	Providers []DNSProviderStatus `json:"providers,omitempty"`
+	// Entries contains the number of shoot DNS entries by state.
+	Entries DNSEntriesStatus `json:"entries"`
+	// Policy is the rule of the ShootDNSServicePolicy applied to the shoot, if any.
+	// +optional
+	Policy *DNSPolicyStatus `json:"policy,omitempty"`
+	// DisallowedProviders are the additional DNS providers of the provider config which are not deployed, as their
+	// type is not allowed for the shoot.
+	// +optional
+	DisallowedProviders []DNSDisallowedProviderStatus `json:"disallowedProviders,omitempty"`
+}
+
+// DNSDisallowedProviderStatus describes an additional DNS provider which is not deployed as its type is not allowed.
+type DNSDisallowedProviderStatus struct {
+	// Index is the index of the provider in the provider config of the extension.
+	Index int32 `json:"index"`
+	// Type is the DNS provider type.
+	Type string `json:"type"`
+	// Message explains why the provider type is not allowed.
+	Message string `json:"message"`
+}
+
+// DNSPolicyStatus identifies the rule of a ShootDNSServicePolicy applied to a shoot.
+type DNSPolicyStatus struct {
+	// Name is the name of the ShootDNSServicePolicy.
+	Name string `json:"name"`
+	// Rule is the name of the applied rule.
+	Rule string `json:"rule"`
+}
+
+// DNSProviderStatus contains the status of a DNS provider managed in the control plane.
+type DNSProviderStatus struct {
+	// Name is the name of the DNSProvider resource.
+	Name string `json:"name"`
+	// Type is the DNS provider type.
+	Type string `json:"type"`
+	// State is the state of the DNSProvider resource.
+	State string `json:"state"`
+	// Message is the status message of the DNSProvider resource.
+	// +optional
+	Message *string `json:"message,omitempty"`
+	// ErrorCodes are the error codes detected in the status message.
+	// +optional
+	ErrorCodes []gardencorev1beta1.ErrorCode `json:"errorCodes,omitempty"`
+	// EntriesQuota contains the usage of the entries quota. It is only set for the "external" provider.
+	// +optional
+	EntriesQuota *DNSProviderQuotaUsage `json:"entriesQuota,omitempty"`
+}
+
+// DNSProviderQuotaUsage contains the usage of the entries quota of a DNS provider.
+type DNSProviderQuotaUsage struct {
+	// Used is the number of shoot DNS entries assigned to the provider.
+	Used int32 `json:"used"`
+	// Limit is the entries quota of the provider. 0 means unlimited.
+	Limit int32 `json:"limit"`
+	// Source is the origin of the limit: `default` for the configured default quota, `rule:<name>` for a quota rule of
+	// the controller configuration, `policy:<name>/<rule>` for a ShootDNSServicePolicy, or `annotation` for the quota
+	// requested by shoot annotation.
+	// +optional
+	Source string `json:"source,omitempty"`
+	// Message explains why the limit differs from the quota requested by shoot annotation, e.g. if it exceeds the
+	// maximum.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// DNSEntriesStatus contains the number of shoot DNS entries by state.
+type DNSEntriesStatus struct {
+	// Total is the number of all shoot DNS entries.
+	Total int32 `json:"total"`
+	// Ready is the number of entries in state Ready.
+	Ready int32 `json:"ready"`
+	// Pending is the number of entries in state Pending or without state.
+	Pending int32 `json:"pending"`
+	// Error is the number of entries in state Error or Invalid.
+	Error int32 `json:"error"`
+	// Stale is the number of entries in state Stale.
+	Stale int32 `json:"stale"`
+}
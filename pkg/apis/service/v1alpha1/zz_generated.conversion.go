@@ -13,6 +13,7 @@ import (
 	unsafe "unsafe"
 
 	service "github.com/gardener/gardener-extension-shoot-dns-service/pkg/apis/service"
+	v1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
 	conversion "k8s.io/apimachinery/pkg/conversion"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
@@ -34,6 +35,26 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*DNSDisallowedProviderStatus)(nil), (*service.DNSDisallowedProviderStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_DNSDisallowedProviderStatus_To_service_DNSDisallowedProviderStatus(a.(*DNSDisallowedProviderStatus), b.(*service.DNSDisallowedProviderStatus), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*service.DNSDisallowedProviderStatus)(nil), (*DNSDisallowedProviderStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_service_DNSDisallowedProviderStatus_To_v1alpha1_DNSDisallowedProviderStatus(a.(*service.DNSDisallowedProviderStatus), b.(*DNSDisallowedProviderStatus), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*DNSEntriesStatus)(nil), (*service.DNSEntriesStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_DNSEntriesStatus_To_service_DNSEntriesStatus(a.(*DNSEntriesStatus), b.(*service.DNSEntriesStatus), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*service.DNSEntriesStatus)(nil), (*DNSEntriesStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_service_DNSEntriesStatus_To_v1alpha1_DNSEntriesStatus(a.(*service.DNSEntriesStatus), b.(*DNSEntriesStatus), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*DNSIncludeExclude)(nil), (*service.DNSIncludeExclude)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha1_DNSIncludeExclude_To_service_DNSIncludeExclude(a.(*DNSIncludeExclude), b.(*service.DNSIncludeExclude), scope)
 	}); err != nil {
@@ -44,6 +65,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*DNSPolicyStatus)(nil), (*service.DNSPolicyStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_DNSPolicyStatus_To_service_DNSPolicyStatus(a.(*DNSPolicyStatus), b.(*service.DNSPolicyStatus), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*service.DNSPolicyStatus)(nil), (*DNSPolicyStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_service_DNSPolicyStatus_To_v1alpha1_DNSPolicyStatus(a.(*service.DNSPolicyStatus), b.(*DNSPolicyStatus), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*DNSProvider)(nil), (*service.DNSProvider)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha1_DNSProvider_To_service_DNSProvider(a.(*DNSProvider), b.(*service.DNSProvider), scope)
 	}); err != nil {
@@ -54,6 +85,36 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*DNSProviderQuotaUsage)(nil), (*service.DNSProviderQuotaUsage)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_DNSProviderQuotaUsage_To_service_DNSProviderQuotaUsage(a.(*DNSProviderQuotaUsage), b.(*service.DNSProviderQuotaUsage), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*service.DNSProviderQuotaUsage)(nil), (*DNSProviderQuotaUsage)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_service_DNSProviderQuotaUsage_To_v1alpha1_DNSProviderQuotaUsage(a.(*service.DNSProviderQuotaUsage), b.(*DNSProviderQuotaUsage), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*DNSProviderQuotas)(nil), (*service.DNSProviderQuotas)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_DNSProviderQuotas_To_service_DNSProviderQuotas(a.(*DNSProviderQuotas), b.(*service.DNSProviderQuotas), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*service.DNSProviderQuotas)(nil), (*DNSProviderQuotas)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_service_DNSProviderQuotas_To_v1alpha1_DNSProviderQuotas(a.(*service.DNSProviderQuotas), b.(*DNSProviderQuotas), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*DNSProviderRateLimit)(nil), (*service.DNSProviderRateLimit)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_DNSProviderRateLimit_To_service_DNSProviderRateLimit(a.(*DNSProviderRateLimit), b.(*service.DNSProviderRateLimit), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*service.DNSProviderRateLimit)(nil), (*DNSProviderRateLimit)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_service_DNSProviderRateLimit_To_v1alpha1_DNSProviderRateLimit(a.(*service.DNSProviderRateLimit), b.(*DNSProviderRateLimit), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*DNSProviderReplication)(nil), (*service.DNSProviderReplication)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha1_DNSProviderReplication_To_service_DNSProviderReplication(a.(*DNSProviderReplication), b.(*service.DNSProviderReplication), scope)
 	}); err != nil {
@@ -64,6 +125,26 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*DNSProviderStatus)(nil), (*service.DNSProviderStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_DNSProviderStatus_To_service_DNSProviderStatus(a.(*DNSProviderStatus), b.(*service.DNSProviderStatus), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*service.DNSProviderStatus)(nil), (*DNSProviderStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_service_DNSProviderStatus_To_v1alpha1_DNSProviderStatus(a.(*service.DNSProviderStatus), b.(*DNSProviderStatus), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*DNSStatus)(nil), (*service.DNSStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_DNSStatus_To_service_DNSStatus(a.(*DNSStatus), b.(*service.DNSStatus), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*service.DNSStatus)(nil), (*DNSStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_service_DNSStatus_To_v1alpha1_DNSStatus(a.(*service.DNSStatus), b.(*DNSStatus), scope)
+	}); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -72,6 +153,7 @@ func autoConvert_v1alpha1_DNSConfig_To_service_DNSConfig(in *DNSConfig, out *ser
 	out.Providers = *(*[]service.DNSProvider)(unsafe.Pointer(&in.Providers))
 	out.SyncProvidersFromShootSpecDNS = (*bool)(unsafe.Pointer(in.SyncProvidersFromShootSpecDNS))
 	out.UseNextGenerationController = (*bool)(unsafe.Pointer(in.UseNextGenerationController))
+	out.Hibernation = (*service.HibernationMode)(unsafe.Pointer(in.Hibernation))
 	return nil
 }
 
@@ -85,6 +167,7 @@ func autoConvert_service_DNSConfig_To_v1alpha1_DNSConfig(in *service.DNSConfig,
 	out.Providers = *(*[]DNSProvider)(unsafe.Pointer(&in.Providers))
 	out.SyncProvidersFromShootSpecDNS = (*bool)(unsafe.Pointer(in.SyncProvidersFromShootSpecDNS))
 	out.UseNextGenerationController = (*bool)(unsafe.Pointer(in.UseNextGenerationController))
+	out.Hibernation = (*HibernationMode)(unsafe.Pointer(in.Hibernation))
 	return nil
 }
 
@@ -93,6 +176,58 @@ func Convert_service_DNSConfig_To_v1alpha1_DNSConfig(in *service.DNSConfig, out
 	return autoConvert_service_DNSConfig_To_v1alpha1_DNSConfig(in, out, s)
 }
 
+func autoConvert_v1alpha1_DNSDisallowedProviderStatus_To_service_DNSDisallowedProviderStatus(in *DNSDisallowedProviderStatus, out *service.DNSDisallowedProviderStatus, s conversion.Scope) error {
+	out.Index = in.Index
+	out.Type = in.Type
+	out.Message = in.Message
+	return nil
+}
+
+// Convert_v1alpha1_DNSDisallowedProviderStatus_To_service_DNSDisallowedProviderStatus is an autogenerated conversion function.
+func Convert_v1alpha1_DNSDisallowedProviderStatus_To_service_DNSDisallowedProviderStatus(in *DNSDisallowedProviderStatus, out *service.DNSDisallowedProviderStatus, s conversion.Scope) error {
+	return autoConvert_v1alpha1_DNSDisallowedProviderStatus_To_service_DNSDisallowedProviderStatus(in, out, s)
+}
+
+func autoConvert_service_DNSDisallowedProviderStatus_To_v1alpha1_DNSDisallowedProviderStatus(in *service.DNSDisallowedProviderStatus, out *DNSDisallowedProviderStatus, s conversion.Scope) error {
+	out.Index = in.Index
+	out.Type = in.Type
+	out.Message = in.Message
+	return nil
+}
+
+// Convert_service_DNSDisallowedProviderStatus_To_v1alpha1_DNSDisallowedProviderStatus is an autogenerated conversion function.
+func Convert_service_DNSDisallowedProviderStatus_To_v1alpha1_DNSDisallowedProviderStatus(in *service.DNSDisallowedProviderStatus, out *DNSDisallowedProviderStatus, s conversion.Scope) error {
+	return autoConvert_service_DNSDisallowedProviderStatus_To_v1alpha1_DNSDisallowedProviderStatus(in, out, s)
+}
+
+func autoConvert_v1alpha1_DNSEntriesStatus_To_service_DNSEntriesStatus(in *DNSEntriesStatus, out *service.DNSEntriesStatus, s conversion.Scope) error {
+	out.Total = in.Total
+	out.Ready = in.Ready
+	out.Pending = in.Pending
+	out.Error = in.Error
+	out.Stale = in.Stale
+	return nil
+}
+
+// Convert_v1alpha1_DNSEntriesStatus_To_service_DNSEntriesStatus is an autogenerated conversion function.
+func Convert_v1alpha1_DNSEntriesStatus_To_service_DNSEntriesStatus(in *DNSEntriesStatus, out *service.DNSEntriesStatus, s conversion.Scope) error {
+	return autoConvert_v1alpha1_DNSEntriesStatus_To_service_DNSEntriesStatus(in, out, s)
+}
+
+func autoConvert_service_DNSEntriesStatus_To_v1alpha1_DNSEntriesStatus(in *service.DNSEntriesStatus, out *DNSEntriesStatus, s conversion.Scope) error {
+	out.Total = in.Total
+	out.Ready = in.Ready
+	out.Pending = in.Pending
+	out.Error = in.Error
+	out.Stale = in.Stale
+	return nil
+}
+
+// Convert_service_DNSEntriesStatus_To_v1alpha1_DNSEntriesStatus is an autogenerated conversion function.
+func Convert_service_DNSEntriesStatus_To_v1alpha1_DNSEntriesStatus(in *service.DNSEntriesStatus, out *DNSEntriesStatus, s conversion.Scope) error {
+	return autoConvert_service_DNSEntriesStatus_To_v1alpha1_DNSEntriesStatus(in, out, s)
+}
+
 func autoConvert_v1alpha1_DNSIncludeExclude_To_service_DNSIncludeExclude(in *DNSIncludeExclude, out *service.DNSIncludeExclude, s conversion.Scope) error {
 	out.Include = *(*[]string)(unsafe.Pointer(&in.Include))
 	out.Exclude = *(*[]string)(unsafe.Pointer(&in.Exclude))
@@ -115,12 +250,38 @@ func Convert_service_DNSIncludeExclude_To_v1alpha1_DNSIncludeExclude(in *service
 	return autoConvert_service_DNSIncludeExclude_To_v1alpha1_DNSIncludeExclude(in, out, s)
 }
 
+func autoConvert_v1alpha1_DNSPolicyStatus_To_service_DNSPolicyStatus(in *DNSPolicyStatus, out *service.DNSPolicyStatus, s conversion.Scope) error {
+	out.Name = in.Name
+	out.Rule = in.Rule
+	return nil
+}
+
+// Convert_v1alpha1_DNSPolicyStatus_To_service_DNSPolicyStatus is an autogenerated conversion function.
+func Convert_v1alpha1_DNSPolicyStatus_To_service_DNSPolicyStatus(in *DNSPolicyStatus, out *service.DNSPolicyStatus, s conversion.Scope) error {
+	return autoConvert_v1alpha1_DNSPolicyStatus_To_service_DNSPolicyStatus(in, out, s)
+}
+
+func autoConvert_service_DNSPolicyStatus_To_v1alpha1_DNSPolicyStatus(in *service.DNSPolicyStatus, out *DNSPolicyStatus, s conversion.Scope) error {
+	out.Name = in.Name
+	out.Rule = in.Rule
+	return nil
+}
+
+// Convert_service_DNSPolicyStatus_To_v1alpha1_DNSPolicyStatus is an autogenerated conversion function.
+func Convert_service_DNSPolicyStatus_To_v1alpha1_DNSPolicyStatus(in *service.DNSPolicyStatus, out *DNSPolicyStatus, s conversion.Scope) error {
+	return autoConvert_service_DNSPolicyStatus_To_v1alpha1_DNSPolicyStatus(in, out, s)
+}
+
 func autoConvert_v1alpha1_DNSProvider_To_service_DNSProvider(in *DNSProvider, out *service.DNSProvider, s conversion.Scope) error {
 	out.Domains = (*service.DNSIncludeExclude)(unsafe.Pointer(in.Domains))
 	out.SecretName = (*string)(unsafe.Pointer(in.SecretName))
 	out.Credentials = (*string)(unsafe.Pointer(in.Credentials))
+	out.CredentialsBindingName = (*string)(unsafe.Pointer(in.CredentialsBindingName))
 	out.Type = (*string)(unsafe.Pointer(in.Type))
 	out.Zones = (*service.DNSIncludeExclude)(unsafe.Pointer(in.Zones))
+	out.Quotas = (*service.DNSProviderQuotas)(unsafe.Pointer(in.Quotas))
+	out.RateLimit = (*service.DNSProviderRateLimit)(unsafe.Pointer(in.RateLimit))
+	out.ProviderConfig = (*runtime.RawExtension)(unsafe.Pointer(in.ProviderConfig))
 	return nil
 }
 
@@ -133,8 +294,12 @@ func autoConvert_service_DNSProvider_To_v1alpha1_DNSProvider(in *service.DNSProv
 	out.Domains = (*DNSIncludeExclude)(unsafe.Pointer(in.Domains))
 	out.SecretName = (*string)(unsafe.Pointer(in.SecretName))
 	out.Credentials = (*string)(unsafe.Pointer(in.Credentials))
+	out.CredentialsBindingName = (*string)(unsafe.Pointer(in.CredentialsBindingName))
 	out.Type = (*string)(unsafe.Pointer(in.Type))
 	out.Zones = (*DNSIncludeExclude)(unsafe.Pointer(in.Zones))
+	out.Quotas = (*DNSProviderQuotas)(unsafe.Pointer(in.Quotas))
+	out.RateLimit = (*DNSProviderRateLimit)(unsafe.Pointer(in.RateLimit))
+	out.ProviderConfig = (*runtime.RawExtension)(unsafe.Pointer(in.ProviderConfig))
 	return nil
 }
 
@@ -143,6 +308,74 @@ func Convert_service_DNSProvider_To_v1alpha1_DNSProvider(in *service.DNSProvider
 	return autoConvert_service_DNSProvider_To_v1alpha1_DNSProvider(in, out, s)
 }
 
+func autoConvert_v1alpha1_DNSProviderQuotaUsage_To_service_DNSProviderQuotaUsage(in *DNSProviderQuotaUsage, out *service.DNSProviderQuotaUsage, s conversion.Scope) error {
+	out.Used = in.Used
+	out.Limit = in.Limit
+	out.Source = in.Source
+	out.Message = in.Message
+	return nil
+}
+
+// Convert_v1alpha1_DNSProviderQuotaUsage_To_service_DNSProviderQuotaUsage is an autogenerated conversion function.
+func Convert_v1alpha1_DNSProviderQuotaUsage_To_service_DNSProviderQuotaUsage(in *DNSProviderQuotaUsage, out *service.DNSProviderQuotaUsage, s conversion.Scope) error {
+	return autoConvert_v1alpha1_DNSProviderQuotaUsage_To_service_DNSProviderQuotaUsage(in, out, s)
+}
+
+func autoConvert_service_DNSProviderQuotaUsage_To_v1alpha1_DNSProviderQuotaUsage(in *service.DNSProviderQuotaUsage, out *DNSProviderQuotaUsage, s conversion.Scope) error {
+	out.Used = in.Used
+	out.Limit = in.Limit
+	out.Source = in.Source
+	out.Message = in.Message
+	return nil
+}
+
+// Convert_service_DNSProviderQuotaUsage_To_v1alpha1_DNSProviderQuotaUsage is an autogenerated conversion function.
+func Convert_service_DNSProviderQuotaUsage_To_v1alpha1_DNSProviderQuotaUsage(in *service.DNSProviderQuotaUsage, out *DNSProviderQuotaUsage, s conversion.Scope) error {
+	return autoConvert_service_DNSProviderQuotaUsage_To_v1alpha1_DNSProviderQuotaUsage(in, out, s)
+}
+
+func autoConvert_v1alpha1_DNSProviderQuotas_To_service_DNSProviderQuotas(in *DNSProviderQuotas, out *service.DNSProviderQuotas, s conversion.Scope) error {
+	out.Entries = (*int32)(unsafe.Pointer(in.Entries))
+	return nil
+}
+
+// Convert_v1alpha1_DNSProviderQuotas_To_service_DNSProviderQuotas is an autogenerated conversion function.
+func Convert_v1alpha1_DNSProviderQuotas_To_service_DNSProviderQuotas(in *DNSProviderQuotas, out *service.DNSProviderQuotas, s conversion.Scope) error {
+	return autoConvert_v1alpha1_DNSProviderQuotas_To_service_DNSProviderQuotas(in, out, s)
+}
+
+func autoConvert_service_DNSProviderQuotas_To_v1alpha1_DNSProviderQuotas(in *service.DNSProviderQuotas, out *DNSProviderQuotas, s conversion.Scope) error {
+	out.Entries = (*int32)(unsafe.Pointer(in.Entries))
+	return nil
+}
+
+// Convert_service_DNSProviderQuotas_To_v1alpha1_DNSProviderQuotas is an autogenerated conversion function.
+func Convert_service_DNSProviderQuotas_To_v1alpha1_DNSProviderQuotas(in *service.DNSProviderQuotas, out *DNSProviderQuotas, s conversion.Scope) error {
+	return autoConvert_service_DNSProviderQuotas_To_v1alpha1_DNSProviderQuotas(in, out, s)
+}
+
+func autoConvert_v1alpha1_DNSProviderRateLimit_To_service_DNSProviderRateLimit(in *DNSProviderRateLimit, out *service.DNSProviderRateLimit, s conversion.Scope) error {
+	out.RequestsPerDay = in.RequestsPerDay
+	out.Burst = (*int32)(unsafe.Pointer(in.Burst))
+	return nil
+}
+
+// Convert_v1alpha1_DNSProviderRateLimit_To_service_DNSProviderRateLimit is an autogenerated conversion function.
+func Convert_v1alpha1_DNSProviderRateLimit_To_service_DNSProviderRateLimit(in *DNSProviderRateLimit, out *service.DNSProviderRateLimit, s conversion.Scope) error {
+	return autoConvert_v1alpha1_DNSProviderRateLimit_To_service_DNSProviderRateLimit(in, out, s)
+}
+
+func autoConvert_service_DNSProviderRateLimit_To_v1alpha1_DNSProviderRateLimit(in *service.DNSProviderRateLimit, out *DNSProviderRateLimit, s conversion.Scope) error {
+	out.RequestsPerDay = in.RequestsPerDay
+	out.Burst = (*int32)(unsafe.Pointer(in.Burst))
+	return nil
+}
+
+// Convert_service_DNSProviderRateLimit_To_v1alpha1_DNSProviderRateLimit is an autogenerated conversion function.
+func Convert_service_DNSProviderRateLimit_To_v1alpha1_DNSProviderRateLimit(in *service.DNSProviderRateLimit, out *DNSProviderRateLimit, s conversion.Scope) error {
+	return autoConvert_service_DNSProviderRateLimit_To_v1alpha1_DNSProviderRateLimit(in, out, s)
+}
+
 func autoConvert_v1alpha1_DNSProviderReplication_To_service_DNSProviderReplication(in *DNSProviderReplication, out *service.DNSProviderReplication, s conversion.Scope) error {
 	out.Enabled = in.Enabled
 	return nil
@@ -162,3 +395,63 @@ func autoConvert_service_DNSProviderReplication_To_v1alpha1_DNSProviderReplicati
 func Convert_service_DNSProviderReplication_To_v1alpha1_DNSProviderReplication(in *service.DNSProviderReplication, out *DNSProviderReplication, s conversion.Scope) error {
 	return autoConvert_service_DNSProviderReplication_To_v1alpha1_DNSProviderReplication(in, out, s)
 }
+
+func autoConvert_v1alpha1_DNSProviderStatus_To_service_DNSProviderStatus(in *DNSProviderStatus, out *service.DNSProviderStatus, s conversion.Scope) error {
+	out.Name = in.Name
+	out.Type = in.Type
+	out.State = in.State
+	out.Message = (*string)(unsafe.Pointer(in.Message))
+	out.ErrorCodes = *(*[]v1beta1.ErrorCode)(unsafe.Pointer(&in.ErrorCodes))
+	out.EntriesQuota = (*service.DNSProviderQuotaUsage)(unsafe.Pointer(in.EntriesQuota))
+	return nil
+}
+
+// Convert_v1alpha1_DNSProviderStatus_To_service_DNSProviderStatus is an autogenerated conversion function.
+func Convert_v1alpha1_DNSProviderStatus_To_service_DNSProviderStatus(in *DNSProviderStatus, out *service.DNSProviderStatus, s conversion.Scope) error {
+	return autoConvert_v1alpha1_DNSProviderStatus_To_service_DNSProviderStatus(in, out, s)
+}
+
+func autoConvert_service_DNSProviderStatus_To_v1alpha1_DNSProviderStatus(in *service.DNSProviderStatus, out *DNSProviderStatus, s conversion.Scope) error {
+	out.Name = in.Name
+	out.Type = in.Type
+	out.State = in.State
+	out.Message = (*string)(unsafe.Pointer(in.Message))
+	out.ErrorCodes = *(*[]v1beta1.ErrorCode)(unsafe.Pointer(&in.ErrorCodes))
+	out.EntriesQuota = (*DNSProviderQuotaUsage)(unsafe.Pointer(in.EntriesQuota))
+	return nil
+}
+
+// Convert_service_DNSProviderStatus_To_v1alpha1_DNSProviderStatus is an autogenerated conversion function.
+func Convert_service_DNSProviderStatus_To_v1alpha1_DNSProviderStatus(in *service.DNSProviderStatus, out *DNSProviderStatus, s conversion.Scope) error {
+	return autoConvert_service_DNSProviderStatus_To_v1alpha1_DNSProviderStatus(in, out, s)
+}
+
+func autoConvert_v1alpha1_DNSStatus_To_service_DNSStatus(in *DNSStatus, out *service.DNSStatus, s conversion.Scope) error {
+	out.Providers = *(*[]service.DNSProviderStatus)(unsafe.Pointer(&in.Providers))
+	if err := Convert_v1alpha1_DNSEntriesStatus_To_service_DNSEntriesStatus(&in.Entries, &out.Entries, s); err != nil {
+		return err
+	}
+	out.Policy = (*service.DNSPolicyStatus)(unsafe.Pointer(in.Policy))
+	out.DisallowedProviders = *(*[]service.DNSDisallowedProviderStatus)(unsafe.Pointer(&in.DisallowedProviders))
+	return nil
+}
+
+// Convert_v1alpha1_DNSStatus_To_service_DNSStatus is an autogenerated conversion function.
+func Convert_v1alpha1_DNSStatus_To_service_DNSStatus(in *DNSStatus, out *service.DNSStatus, s conversion.Scope) error {
+	return autoConvert_v1alpha1_DNSStatus_To_service_DNSStatus(in, out, s)
+}
+
+func autoConvert_service_DNSStatus_To_v1alpha1_DNSStatus(in *service.DNSStatus, out *DNSStatus, s conversion.Scope) error {
+	out.Providers = *(*[]DNSProviderStatus)(unsafe.Pointer(&in.Providers))
+	if err := Convert_service_DNSEntriesStatus_To_v1alpha1_DNSEntriesStatus(&in.Entries, &out.Entries, s); err != nil {
+		return err
+	}
+	out.Policy = (*DNSPolicyStatus)(unsafe.Pointer(in.Policy))
+	out.DisallowedProviders = *(*[]DNSDisallowedProviderStatus)(unsafe.Pointer(&in.DisallowedProviders))
+	return nil
+}
+
+// Convert_service_DNSStatus_To_v1alpha1_DNSStatus is an autogenerated conversion function.
+func Convert_service_DNSStatus_To_v1alpha1_DNSStatus(in *service.DNSStatus, out *DNSStatus, s conversion.Scope) error {
+	return autoConvert_service_DNSStatus_To_v1alpha1_DNSStatus(in, out, s)
+}
@@ -17,5 +17,15 @@ import (
 // Public to allow building arbitrary schemes.
 // All generated defaulters are covering - they call all nested defaulters.
 func RegisterDefaults(scheme *runtime.Scheme) error {
+	scheme.AddTypeDefaultingFunc(&DNSConfig{}, func(obj interface{}) { SetObjectDefaults_DNSConfig(obj.(*DNSConfig)) })
 	return nil
 }
+
+func SetObjectDefaults_DNSConfig(in *DNSConfig) {
+	for i := range in.Providers {
+		a := &in.Providers[i]
+		if a.RateLimit != nil {
+			SetDefaults_DNSProviderRateLimit(a.RateLimit)
+		}
+	}
+}
@@ -0,0 +1,242 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package validation
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+	utilvalidation "k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	"github.com/gardener/gardener-extension-shoot-dns-service/pkg/apis/service"
+)
+
+// ValidateDomainSelections validates the domain and zone selections of the providers of the passed DNSConfig.
+// Domain names must be valid RFC 1123 subdomains, optionally starting with a wildcard label, excluded domains must be
+// subdomains of an included domain of the same provider, and the included domains and zones of different providers
+// must not overlap. If shootDomain is set, the included domains must not be the domain of the shoot (`spec.dns.domain`)
+// or one of its subdomains, regardless of whether it is served by the default provider or by a primary provider.
+func ValidateDomainSelections(config *service.DNSConfig, shootDomain string) field.ErrorList {
+	allErrs := field.ErrorList{}
+	path := ProviderConfigPath()
+	for i, p := range config.Providers {
+		if p.Domains != nil {
+			allErrs = append(allErrs, validateDomainSelection(p.Domains, path.Index(i).Child("domains"))...)
+		}
+		if p.Zones != nil {
+			allErrs = append(allErrs, validateZoneSelection(p.Zones, path.Index(i).Child("zones"))...)
+		}
+	}
+	allErrs = append(allErrs, validateOverlappingSelections(config.Providers, path)...)
+	allErrs = append(allErrs, validateShootDomainOverlaps(config.Providers, shootDomain, path)...)
+	return allErrs
+}
+
+func validateDomainSelection(selection *service.DNSIncludeExclude, path *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	included := sets.New[string]()
+	for j, domain := range selection.Include {
+		subPath := path.Child("include").Index(j)
+		if errs := validateDomainName(domain, subPath); len(errs) > 0 {
+			allErrs = append(allErrs, errs...)
+			continue
+		}
+		if included.Has(normalizeDomain(domain)) {
+			allErrs = append(allErrs, field.Duplicate(subPath, domain))
+		}
+		included.Insert(normalizeDomain(domain))
+	}
+	excluded := sets.New[string]()
+	for k, domain := range selection.Exclude {
+		subPath := path.Child("exclude").Index(k)
+		if errs := validateDomainName(domain, subPath); len(errs) > 0 {
+			allErrs = append(allErrs, errs...)
+			continue
+		}
+		if excluded.Has(normalizeDomain(domain)) {
+			allErrs = append(allErrs, field.Duplicate(subPath, domain))
+		}
+		excluded.Insert(normalizeDomain(domain))
+		// without includes, the domains of all zones of the provider are selected
+		if len(selection.Include) > 0 && !slices.ContainsFunc(selection.Include, func(include string) bool {
+			return isSubdomain(baseDomain(domain), baseDomain(include))
+		}) {
+			allErrs = append(allErrs, field.Invalid(subPath, domain, "must be a subdomain of an included domain"))
+		}
+	}
+	return allErrs
+}
+
+// validateDomainName validates the domain name, which may have a trailing dot and may start with a wildcard label.
+func validateDomainName(domain string, path *field.Path) field.ErrorList {
+	name := normalizeDomain(domain)
+	if name == "" {
+		return field.ErrorList{field.Required(path, "domain must not be empty")}
+	}
+	if strings.Contains(strings.TrimPrefix(name, "*."), "*") {
+		return field.ErrorList{field.Invalid(path, domain, "a wildcard is only allowed as the complete leftmost label")}
+	}
+	allErrs := field.ErrorList{}
+	for _, msg := range utilvalidation.IsDNS1123Subdomain(strings.TrimPrefix(name, "*.")) {
+		allErrs = append(allErrs, field.Invalid(path, domain, msg))
+	}
+	return allErrs
+}
+
+func validateZoneSelection(selection *service.DNSIncludeExclude, path *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	for _, list := range []struct {
+		name  string
+		zones []string
+	}{
+		{name: "include", zones: selection.Include},
+		{name: "exclude", zones: selection.Exclude},
+	} {
+		seen := sets.New[string]()
+		for j, zone := range list.zones {
+			subPath := path.Child(list.name).Index(j)
+			// zone IDs are provider specific, e.g. 'Z1234' for AWS Route53 or 'project:zone' for Google CloudDNS
+			switch {
+			case zone == "":
+				allErrs = append(allErrs, field.Required(subPath, "zone ID must not be empty"))
+			case strings.ContainsFunc(zone, func(r rune) bool { return r == ' ' || r == '\t' || r == '\n' }):
+				allErrs = append(allErrs, field.Invalid(subPath, zone, "zone ID must not contain whitespace"))
+			case seen.Has(zone):
+				allErrs = append(allErrs, field.Duplicate(subPath, zone))
+			}
+			seen.Insert(zone)
+		}
+	}
+	for j, zone := range selection.Include {
+		if zone != "" && slices.Contains(selection.Exclude, zone) {
+			allErrs = append(allErrs, field.Invalid(path.Child("include").Index(j), zone, "zone is also excluded"))
+		}
+	}
+	return allErrs
+}
+
+// validateOverlappingSelections reports the included domains and zones of a provider which are also selected by a
+// previous provider, as entries in overlapping zones cannot be assigned to a unique provider.
+func validateOverlappingSelections(providers []service.DNSProvider, path *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	for k := 1; k < len(providers); k++ {
+		for m, domain := range includedDomains(providers[k]) {
+			if other := findOverlappingDomain(providers[:k], domain, providers[k].Domains, path); other != "" {
+				allErrs = append(allErrs, field.Invalid(path.Index(k).Child("domains", "include").Index(m), domain,
+					fmt.Sprintf("overlaps with the included domain %s", other)))
+			}
+		}
+		if providers[k].Zones == nil {
+			continue
+		}
+		for m, zone := range providers[k].Zones.Include {
+			for i := range k {
+				if providers[i].Zones != nil && zone != "" && slices.Contains(providers[i].Zones.Include, zone) {
+					allErrs = append(allErrs, field.Invalid(path.Index(k).Child("zones", "include").Index(m), zone,
+						fmt.Sprintf("zone is also included by %s", path.Index(i))))
+					break
+				}
+			}
+		}
+	}
+	return allErrs
+}
+
+// validateShootDomainOverlaps reports the included domains of the providers which are equal to or inside the domain of
+// the shoot, as the records of the shoot domain are managed by the default or primary provider.
+// Parent zones of the shoot domain are allowed, e.g. a provider for `example.com` besides the shoot domain
+// `shoot.example.com`. If they do not exclude the shoot domain, a warning is returned by ValidateDNSConfig instead.
+func validateShootDomainOverlaps(providers []service.DNSProvider, shootDomain string, path *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if shootDomain == "" {
+		return allErrs
+	}
+	shootDomain = normalizeDomain(shootDomain)
+	for i, p := range providers {
+		for j, domain := range includedDomains(p) {
+			if domain == "" {
+				continue
+			}
+			if base := baseDomain(domain); base == shootDomain || isSubdomain(base, shootDomain) {
+				allErrs = append(allErrs, field.Invalid(path.Index(i).Child("domains", "include").Index(j), domain,
+					fmt.Sprintf("must not be the domain %q of %s or one of its subdomains", shootDomain, field.NewPath("spec", "dns", "domain"))))
+			}
+		}
+	}
+	return allErrs
+}
+
+// findOverlappingDomain returns the field path and value of the first included domain of the providers overlapping
+// with the given domain, or an empty string if there is none.
+func findOverlappingDomain(providers []service.DNSProvider, domain string, selection *service.DNSIncludeExclude, path *field.Path) string {
+	for i, p := range providers {
+		for j, other := range includedDomains(p) {
+			if domainSelectionsOverlap(domain, selection, other, p.Domains) {
+				return fmt.Sprintf("%q of %s", other, path.Index(i).Child("domains", "include").Index(j))
+			}
+		}
+	}
+	return ""
+}
+
+// includedDomains returns the included domains of the provider with a valid syntax.
+func includedDomains(p service.DNSProvider) []string {
+	if p.Domains == nil {
+		return nil
+	}
+	domains := make([]string, len(p.Domains.Include))
+	for i, domain := range p.Domains.Include {
+		if len(validateDomainName(domain, nil)) == 0 {
+			domains[i] = domain
+		}
+	}
+	return domains
+}
+
+// domainSelectionsOverlap returns true if the included domain a of selection selA and the included domain b of
+// selection selB select common domain names, i.e. if they are equal or the more specific one is not excluded by the
+// selection of the less specific one. The selections may be nil.
+func domainSelectionsOverlap(a string, selA *service.DNSIncludeExclude, b string, selB *service.DNSIncludeExclude) bool {
+	if a == "" || b == "" {
+		return false
+	}
+	a, b = baseDomain(a), baseDomain(b)
+	switch {
+	case a == b:
+		return true
+	case isSubdomain(b, a):
+		return !isExcluded(b, selA)
+	case isSubdomain(a, b):
+		return !isExcluded(a, selB)
+	}
+	return false
+}
+
+func isExcluded(domain string, selection *service.DNSIncludeExclude) bool {
+	if selection == nil {
+		return false
+	}
+	return slices.ContainsFunc(selection.Exclude, func(exclude string) bool {
+		exclude = baseDomain(exclude)
+		return domain == exclude || isSubdomain(domain, exclude)
+	})
+}
+
+// isSubdomain returns true if the normalized domain is a proper subdomain of the normalized parent.
+func isSubdomain(domain, parent string) bool {
+	return strings.HasSuffix(domain, "."+parent)
+}
+
+// baseDomain returns the normalized domain without a leading wildcard label.
+func baseDomain(domain string) string {
+	return strings.TrimPrefix(normalizeDomain(domain), "*.")
+}
+
+func normalizeDomain(domain string) string {
+	return strings.ToLower(strings.TrimSuffix(domain, "."))
+}
@@ -0,0 +1,207 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package validation
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	compoundvalidation "github.com/gardener/external-dns-management/pkg/controller/provider/compound/validation"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+// candidateProviderTypes are the provider types of external-dns-management which are probed against
+// the compound adaptor registry. The registry does not offer an enumeration, so only candidates with a
+// registered DNSHandlerAdapter are supported by default.
+var candidateProviderTypes = []string{
+	"alicloud-dns",
+	"aws-route53",
+	"azure-dns", "azure-private-dns",
+	"cloudflare-dns",
+	"gdch-dns",
+	"google-clouddns",
+	"infoblox-dns",
+	"netlify-dns",
+	"openstack-designate",
+	"powerdns",
+	"remote",
+	"rfc2136",
+}
+
+// DefaultProviderTypes returns the sorted list of provider types with a registered DNSHandlerAdapter.
+// These types are allowed if no restrictions are configured.
+func DefaultProviderTypes() []string {
+	var result []string
+	for _, providerType := range candidateProviderTypes {
+		if compoundvalidation.GetAdaptor(providerType) != nil {
+			result = append(result, providerType)
+		}
+	}
+	slices.Sort(result)
+	return result
+}
+
+// ProviderTypeRestrictions restricts the provider types allowed for additional DNS providers.
+type ProviderTypeRestrictions struct {
+	// Allowed is the list of allowed provider types. If empty, the types returned by DefaultProviderTypes are allowed.
+	Allowed []string
+	// Denied is the list of denied provider types. They are removed from the allowed ones.
+	Denied []string
+	// Rules overwrite Allowed and Denied for shoots of selected projects or seeds. The first matching rule is applied.
+	Rules []ProviderTypeRule
+}
+
+// ProviderTypeRule overwrites the allowed and denied provider types for shoots of a project or of seeds selected by labels.
+type ProviderTypeRule struct {
+	// Project is the name of the project the rule applies to.
+	Project string
+	// SeedSelector selects the seeds by labels the rule applies to.
+	SeedSelector labels.Selector
+	// Allowed is the list of allowed provider types. If empty, the allowed types of the restrictions are used.
+	Allowed []string
+	// Denied is the list of denied provider types. If empty, the denied types of the restrictions are used.
+	Denied []string
+}
+
+// HasProjectRules returns true if any rule selects shoots by project.
+func (r *ProviderTypeRestrictions) HasProjectRules() bool {
+	return r != nil && slices.ContainsFunc(r.Rules, func(rule ProviderTypeRule) bool { return rule.Project != "" })
+}
+
+// HasSeedRules returns true if any rule selects shoots by seed labels.
+func (r *ProviderTypeRestrictions) HasSeedRules() bool {
+	return r != nil && slices.ContainsFunc(r.Rules, func(rule ProviderTypeRule) bool { return rule.SeedSelector != nil })
+}
+
+// EffectiveProviderTypes returns the sorted list of provider types allowed for shoots of the given project
+// on a seed with the given labels. Rules with a seed selector never match if seedLabels is nil, e.g. for unscheduled shoots.
+func (r *ProviderTypeRestrictions) EffectiveProviderTypes(project string, seedLabels map[string]string) []string {
+	if r == nil {
+		return DefaultProviderTypes()
+	}
+
+	allowed, denied := r.Allowed, r.Denied
+	for _, rule := range r.Rules {
+		if rule.matches(project, seedLabels) {
+			if len(rule.Allowed) > 0 {
+				allowed = rule.Allowed
+			}
+			if len(rule.Denied) > 0 {
+				denied = rule.Denied
+			}
+			break
+		}
+	}
+
+	if len(allowed) == 0 {
+		allowed = DefaultProviderTypes()
+	}
+	return sets.List(sets.New(allowed...).Delete(denied...))
+}
+
+// RestrictProviderTypes returns the sorted list of the allowed provider types which are also contained in the given
+// types, e.g. of a ShootDNSServicePolicy. The types can only narrow the allowed ones, types denied by the provider type
+// restrictions are never allowed again. If no types are given, the allowed types are returned.
+func RestrictProviderTypes(allowed, types []string) []string {
+	if len(types) == 0 {
+		return allowed
+	}
+	return sets.List(sets.New(allowed...).Intersection(sets.New(types...)))
+}
+
+func (r ProviderTypeRule) matches(project string, seedLabels map[string]string) bool {
+	if r.Project != "" && r.Project != project {
+		return false
+	}
+	if r.SeedSelector != nil && (seedLabels == nil || !r.SeedSelector.Matches(labels.Set(seedLabels))) {
+		return false
+	}
+	return r.Project != "" || r.SeedSelector != nil
+}
+
+// ParseProviderTypeRule parses a rule given as semicolon separated key-value pairs.
+// Supported keys are `project`, `seed-label` (a label selector), `allow`, and `deny` (comma separated provider types),
+// e.g. `project=my-project;allow=aws-route53,google-clouddns` or `seed-label=environment=restricted;deny=netlify-dns`.
+func ParseProviderTypeRule(value string) (ProviderTypeRule, error) {
+	rule := ProviderTypeRule{}
+	for part := range strings.SplitSeq(value, ";") {
+		key, val, ok := strings.Cut(strings.TrimSpace(part), "=")
+		if !ok {
+			return rule, fmt.Errorf("invalid provider type rule %q: expected key=value, but got %q", value, part)
+		}
+		switch key {
+		case "project":
+			rule.Project = val
+		case "seed-label":
+			selector, err := labels.Parse(val)
+			if err != nil {
+				return rule, fmt.Errorf("invalid provider type rule %q: invalid seed label selector: %w", value, err)
+			}
+			rule.SeedSelector = selector
+		case "allow":
+			rule.Allowed = splitProviderTypes(val)
+		case "deny":
+			rule.Denied = splitProviderTypes(val)
+		default:
+			return rule, fmt.Errorf("invalid provider type rule %q: unknown key %q", value, key)
+		}
+	}
+	if rule.Project == "" && rule.SeedSelector == nil {
+		return rule, fmt.Errorf("invalid provider type rule %q: either project or seed-label must be specified", value)
+	}
+	if len(rule.Allowed) == 0 && len(rule.Denied) == 0 {
+		return rule, fmt.Errorf("invalid provider type rule %q: either allow or deny must be specified", value)
+	}
+	return rule, nil
+}
+
+func splitProviderTypes(value string) []string {
+	var result []string
+	for providerType := range strings.SplitSeq(value, ",") {
+		if providerType = strings.TrimSpace(providerType); providerType != "" {
+			result = append(result, providerType)
+		}
+	}
+	return result
+}
+
+// ProjectNameFromTechnicalID returns the name of the project of the shoot with the given name and technical ID.
+// In contrast to the namespace of the shoot, which can be customised by the project, the technical ID is always built
+// from the project name as `shoot--<project>--<shoot>`, or as `shoot-<project>-<shoot>` for old shoots.
+// Returns an empty string if the technical ID does not belong to the shoot.
+func ProjectNameFromTechnicalID(technicalID, shootName string) string {
+	for _, separator := range []string{"--", "-"} {
+		rest, ok := strings.CutPrefix(technicalID, "shoot"+separator)
+		if !ok {
+			continue
+		}
+		if project, ok := strings.CutSuffix(rest, separator+shootName); ok && project != "" {
+			return project
+		}
+	}
+	return ""
+}
+
+// CredentialsProviderType returns the provider type of the CredentialsBindings whose credentials can be used by DNS
+// providers of the given type. For the DNS services of infrastructure providers, it is the type of the infrastructure
+// provider, e.g. 'aws' for 'aws-route53'. For all other DNS providers, it is the DNS provider type itself.
+func CredentialsProviderType(providerType string) string {
+	switch providerType {
+	case "aws-route53":
+		return "aws"
+	case "google-clouddns":
+		return "gcp"
+	case "azure-dns", "azure-private-dns":
+		return "azure"
+	case "openstack-designate":
+		return "openstack"
+	case "alicloud-dns":
+		return "alicloud"
+	default:
+		return providerType
+	}
+}
@@ -0,0 +1,111 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package validation_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/labels"
+
+	"github.com/gardener/gardener-extension-shoot-dns-service/pkg/apis/service/validation"
+)
+
+var _ = Describe("ProviderTypes", func() {
+	It("should derive the default provider types from the adaptor registry", func() {
+		Expect(validation.DefaultProviderTypes()).To(Equal([]string{
+			"alicloud-dns", "aws-route53", "azure-dns", "azure-private-dns", "cloudflare-dns", "gdch-dns", "google-clouddns",
+			"infoblox-dns", "netlify-dns", "openstack-designate", "powerdns", "remote", "rfc2136",
+		}))
+	})
+
+	Describe("#EffectiveProviderTypes", func() {
+		restrictions := &validation.ProviderTypeRestrictions{
+			Denied: []string{"netlify-dns", "remote"},
+			Rules: []validation.ProviderTypeRule{
+				{
+					Project: "special",
+					Allowed: []string{"aws-route53", "my-fork-dns"},
+				},
+				{
+					SeedSelector: labels.SelectorFromSet(labels.Set{"environment": "restricted"}),
+					Denied:       []string{"google-clouddns"},
+				},
+			},
+		}
+
+		DescribeTable("should return effective provider types",
+			func(restrictions *validation.ProviderTypeRestrictions, project string, seedLabels map[string]string, expected []string) {
+				Expect(restrictions.EffectiveProviderTypes(project, seedLabels)).To(Equal(expected))
+			},
+			Entry("no restrictions", nil, "foo", nil, validation.DefaultProviderTypes()),
+			Entry("global denied", restrictions, "foo", nil, []string{
+				"alicloud-dns", "aws-route53", "azure-dns", "azure-private-dns", "cloudflare-dns", "gdch-dns", "google-clouddns",
+				"infoblox-dns", "openstack-designate", "powerdns", "rfc2136",
+			}),
+			Entry("project rule", restrictions, "special", map[string]string{"environment": "restricted"}, []string{"aws-route53", "my-fork-dns"}),
+			Entry("seed rule", restrictions, "foo", map[string]string{"environment": "restricted"}, []string{
+				"alicloud-dns", "aws-route53", "azure-dns", "azure-private-dns", "cloudflare-dns", "gdch-dns",
+				"infoblox-dns", "netlify-dns", "openstack-designate", "powerdns", "remote", "rfc2136",
+			}),
+			Entry("global allowed", &validation.ProviderTypeRestrictions{Allowed: []string{"google-clouddns", "aws-route53"}}, "foo", nil,
+				[]string{"aws-route53", "google-clouddns"}),
+		)
+	})
+
+	Describe("#RestrictProviderTypes", func() {
+		It("should return the allowed types without restricting types", func() {
+			Expect(validation.RestrictProviderTypes([]string{"aws-route53", "google-clouddns"}, nil)).To(Equal([]string{"aws-route53", "google-clouddns"}))
+		})
+
+		It("should only keep the allowed types", func() {
+			Expect(validation.RestrictProviderTypes([]string{"aws-route53", "google-clouddns"}, []string{"google-clouddns", "netlify-dns"})).To(Equal([]string{"google-clouddns"}))
+			Expect(validation.RestrictProviderTypes([]string{"aws-route53"}, []string{"netlify-dns"})).To(BeEmpty())
+		})
+	})
+
+	Describe("#ParseProviderTypeRule", func() {
+		It("should parse a project rule", func() {
+			rule, err := validation.ParseProviderTypeRule("project=my-project;allow=aws-route53, google-clouddns")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(rule.Project).To(Equal("my-project"))
+			Expect(rule.SeedSelector).To(BeNil())
+			Expect(rule.Allowed).To(Equal([]string{"aws-route53", "google-clouddns"}))
+			Expect(rule.Denied).To(BeEmpty())
+		})
+
+		It("should parse a seed label rule", func() {
+			rule, err := validation.ParseProviderTypeRule("seed-label=environment in (restricted,secure);deny=netlify-dns")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(rule.Project).To(BeEmpty())
+			Expect(rule.SeedSelector.Matches(labels.Set{"environment": "secure"})).To(BeTrue())
+			Expect(rule.SeedSelector.Matches(labels.Set{"environment": "public"})).To(BeFalse())
+			Expect(rule.Denied).To(Equal([]string{"netlify-dns"}))
+		})
+
+		DescribeTable("should reject invalid rules",
+			func(value, errorSubstring string) {
+				_, err := validation.ParseProviderTypeRule(value)
+				Expect(err).To(MatchError(ContainSubstring(errorSubstring)))
+			},
+			Entry("missing selector", "allow=aws-route53", "either project or seed-label must be specified"),
+			Entry("missing lists", "project=foo", "either allow or deny must be specified"),
+			Entry("unknown key", "project=foo;permit=aws-route53", "unknown key \"permit\""),
+			Entry("missing value", "project", "expected key=value"),
+			Entry("invalid selector", "seed-label=in in in;deny=remote", "invalid seed label selector"),
+		)
+	})
+
+	DescribeTable("#ProjectNameFromTechnicalID",
+		func(technicalID, shootName, expected string) {
+			Expect(validation.ProjectNameFromTechnicalID(technicalID, shootName)).To(Equal(expected))
+		},
+		Entry("technical ID", "shoot--foo--bar", "bar", "foo"),
+		Entry("technical ID of shoot with hyphen", "shoot--foo--my-shoot", "my-shoot", "foo"),
+		Entry("technical ID of garden project", "shoot--garden--bar", "bar", "garden"),
+		Entry("old technical ID", "shoot-foo-bar", "bar", "foo"),
+		Entry("technical ID of other shoot", "shoot--foo--bar", "baz", ""),
+		Entry("no technical ID", "", "bar", ""),
+	)
+})
@@ -19,63 +19,122 @@ import (
 	"github.com/gardener/external-dns-management/pkg/dnsman2/apis/config"
 	"github.com/gardener/gardener/pkg/apis/core"
 	securityv1alpha1 "github.com/gardener/gardener/pkg/apis/security/v1alpha1"
+	autoscalingv1 "k8s.io/api/autoscaling/v1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 	"sigs.k8s.io/yaml"
 
 	"github.com/gardener/gardener-extension-shoot-dns-service/pkg/apis/service"
+	workloadidentityalicloud "github.com/gardener/gardener-extension-shoot-dns-service/pkg/apis/service/workloadidentity/alicloud"
+	workloadidentityopenstack "github.com/gardener/gardener-extension-shoot-dns-service/pkg/apis/service/workloadidentity/openstack"
 	service2 "github.com/gardener/gardener-extension-shoot-dns-service/pkg/service"
 )
 
-var supportedProviderTypes = []string{
-	"alicloud-dns",
-	"aws-route53",
-	"azure-dns", "azure-private-dns",
-	"cloudflare-dns",
-	"gdch-dns",
-	"google-clouddns",
-	"infoblox-dns",
-	"netlify-dns",
-	"openstack-designate",
-	"powerdns",
-	"remote",
-	"rfc2136",
-}
-
 // ResourceGetter is an interface that defines methods to get Kubernetes resources from the Garden cluster.
 type ResourceGetter interface {
 	// GetSecret retrieves a Kubernetes Secret by its name.
 	GetSecret(name string) (*corev1.Secret, error)
 	// GetWorkloadIdentity retrieves a WorkloadIdentity by its name.
 	GetWorkloadIdentity(name string) (*securityv1alpha1.WorkloadIdentity, error)
+	// GetCredentialsBinding retrieves a CredentialsBinding by its name.
+	GetCredentialsBinding(name string) (*securityv1alpha1.CredentialsBinding, error)
+	// InNamespace returns a ResourceGetter for the given namespace, e.g. for the credentials referenced by a CredentialsBinding.
+	InNamespace(namespace string) ResourceGetter
 	// GetInternalGCPWorkloadIdentityConfig returns the internal GCP Workload Identity configuration.
 	GetInternalGCPWorkloadIdentityConfig() config.InternalGCPWorkloadIdentityConfig
 }
 
+// ProviderConfigPath returns the field path of the providerConfig of the extension in the shoot manifest.
+func ProviderConfigPath() *field.Path {
+	return field.NewPath("spec", "extensions", "[@.type='"+service2.ExtensionType+"']", "providerConfig")
+}
+
 // ValidateDNSConfig validates the passed DNSConfig.
 // If resources != nil, it also validates if the referenced secrets are defined.
-func ValidateDNSConfig(config *service.DNSConfig, resources *[]core.NamedResourceReference, getter ResourceGetter) field.ErrorList {
+// If allowedProviderTypes is nil, the provider types returned by DefaultProviderTypes are allowed.
+// If shootDomain is set, it is the domain of the shoot served by the default or the primary provider, and warnings are
+// returned for provider domains overlapping it, e.g. for parent zones not excluding it. Warnings are also returned for
+// the usage of deprecated fields.
+func ValidateDNSConfig(config *service.DNSConfig, shootDomain string, resources *[]core.NamedResourceReference, getter ResourceGetter, allowedProviderTypes []string) (admission.Warnings, field.ErrorList) {
 	allErrs := field.ErrorList{}
 
 	if len(config.Providers) > 0 {
-		allErrs = append(allErrs, validateProviders(config.Providers, resources, getter)...)
+		if allowedProviderTypes == nil {
+			allowedProviderTypes = DefaultProviderTypes()
+		}
+		allErrs = append(allErrs, validateProviders(config.Providers, resources, getter, allowedProviderTypes)...)
 	}
-	return allErrs
+	if config.Hibernation != nil {
+		allErrs = append(allErrs, validateHibernationMode(*config.Hibernation)...)
+	}
+	return dnsConfigWarnings(config, shootDomain), allErrs
+}
+
+func dnsConfigWarnings(config *service.DNSConfig, shootDomain string) admission.Warnings {
+	var warnings admission.Warnings
+	path := ProviderConfigPath()
+	for i, p := range config.Providers {
+		// the field is set by the mutator if the providers are synchronised from the shoot spec
+		if ptr.Deref(p.SecretName, "") != "" && !ptr.Deref(config.SyncProvidersFromShootSpecDNS, false) {
+			warnings = append(warnings, fmt.Sprintf("%s: the field secretName is deprecated, please use the field credentials instead",
+				path.Index(i).Child("secretName")))
+		}
+		if shootDomain == "" || p.Domains == nil {
+			continue
+		}
+		for j, domain := range p.Domains.Include {
+			if domainSelectionsOverlap(domain, p.Domains, shootDomain, nil) {
+				warnings = append(warnings, fmt.Sprintf("%s: domain %q overlaps with the domain %q of the shoot (spec.dns.domain), please exclude it",
+					path.Index(i).Child("domains", "include").Index(j), domain, shootDomain))
+			}
+		}
+	}
+	return warnings
+}
+
+var supportedHibernationModes = []string{
+	string(service.HibernationModeDeleteRecords),
+	string(service.HibernationModeKeepRecords),
+	string(service.HibernationModeRedirect),
+}
+
+func validateHibernationMode(mode service.HibernationMode) field.ErrorList {
+	path := ProviderConfigPath().Child("hibernation")
+	if !slices.Contains(supportedHibernationModes, string(mode)) {
+		return field.ErrorList{field.NotSupported(path, mode, supportedHibernationModes)}
+	}
+	return nil
 }
 
-func validateProviders(providers []service.DNSProvider, presources *[]core.NamedResourceReference, getter ResourceGetter) field.ErrorList {
+func validateProviders(providers []service.DNSProvider, presources *[]core.NamedResourceReference, getter ResourceGetter, allowedProviderTypes []string) field.ErrorList {
 	allErrs := field.ErrorList{}
-	path := field.NewPath("spec", "extensions", "[@.type='"+service2.ExtensionType+"']", "providerConfig")
+	path := ProviderConfigPath()
 	for i, p := range providers {
 		if p.Type == nil || *p.Type == "" {
 			allErrs = append(allErrs, field.Required(path.Index(i).Child("type"), "provider type is required"))
-		} else if !isSupportedProviderType(*p.Type) {
+		} else if !slices.Contains(allowedProviderTypes, *p.Type) {
+			detail := "unsupported provider type. Valid types are: %s"
+			if slices.Contains(candidateProviderTypes, *p.Type) {
+				detail = "provider type is not allowed. Allowed types are: %s"
+			}
 			allErrs = append(allErrs, field.Invalid(path.Index(i).Child("type"), *p.Type,
-				fmt.Sprintf("unsupported provider type. Valid types are: %s", strings.Join(supportedProviderTypes, ", "))))
+				fmt.Sprintf(detail, strings.Join(allowedProviderTypes, ", "))))
 		}
+		allErrs = append(allErrs, validateProviderQuotasAndRateLimit(p, path.Index(i))...)
 		secretName := ptr.Deref(p.SecretName, "")
 		credentials := ptr.Deref(p.Credentials, "")
+		if credentialsBindingName := ptr.Deref(p.CredentialsBindingName, ""); credentialsBindingName != "" {
+			subPath := path.Index(i).Child("credentialsBindingName")
+			if secretName != "" || credentials != "" {
+				allErrs = append(allErrs, field.Invalid(subPath, credentialsBindingName, "only one of secretName, credentials or credentialsBindingName must be provided"))
+				continue
+			}
+			validateProviderCredentialsBinding(credentialsBindingName, ptr.Deref(p.Type, ""), p.ProviderConfig, path.Index(i), subPath, getter, &allErrs)
+			continue
+		}
 		if secretName == "" && credentials == "" {
 			allErrs = append(allErrs, field.Invalid(path.Index(i).Child("secretName"), "", "either secretName or credentials must be provided"))
 			allErrs = append(allErrs, field.Invalid(path.Index(i).Child("credentials"), "", "either secretName or credentials must be provided"))
@@ -108,20 +167,32 @@ func validateProviders(providers []service.DNSProvider, presources *[]core.Named
 				allErrs = append(allErrs, field.Invalid(subPath, refName, "incomplete resource reference at 'spec.resources'"))
 				continue
 			}
-			validateProviderSecretOrWorkloadIdentity(credentialRef, allowWorkloadIdentity, ptr.Deref(p.Type, ""), path.Index(i), subPath, getter, &allErrs)
+			validateProviderSecretOrWorkloadIdentity(credentialRef, allowWorkloadIdentity, ptr.Deref(p.Type, ""), p.ProviderConfig, path.Index(i), subPath, getter, &allErrs)
 		}
 	}
 	return allErrs
 }
 
-func isSupportedProviderType(providerType string) bool {
-	return slices.Contains(supportedProviderTypes, providerType)
+func validateProviderQuotasAndRateLimit(p service.DNSProvider, path *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if p.Quotas != nil && p.Quotas.Entries != nil && *p.Quotas.Entries < 0 {
+		allErrs = append(allErrs, field.Invalid(path.Child("quotas", "entries"), *p.Quotas.Entries, "must not be negative"))
+	}
+	if p.RateLimit != nil {
+		if p.RateLimit.RequestsPerDay < 1 {
+			allErrs = append(allErrs, field.Invalid(path.Child("rateLimit", "requestsPerDay"), p.RateLimit.RequestsPerDay, "must be greater than 0"))
+		}
+		if p.RateLimit.Burst != nil && *p.RateLimit.Burst < 0 {
+			allErrs = append(allErrs, field.Invalid(path.Child("rateLimit", "burst"), *p.RateLimit.Burst, "must not be negative"))
+		}
+	}
+	return allErrs
 }
 
-func validateProviderSecretOrWorkloadIdentity(resourceRef core.NamedResourceReference, allowWorkloadIdentity bool, providerType string, path, subPath *field.Path, getter ResourceGetter, allErrs *field.ErrorList) {
+func validateProviderSecretOrWorkloadIdentity(resourceRef core.NamedResourceReference, allowWorkloadIdentity bool, providerType string, providerConfig *runtime.RawExtension, path, subPath *field.Path, getter ResourceGetter, allErrs *field.ErrorList) {
 	switch {
 	case resourceRef.ResourceRef.Kind == "Secret" && resourceRef.ResourceRef.APIVersion == "v1":
-		validateProviderSecret(resourceRef.ResourceRef.Name, providerType, path, subPath, getter, allErrs)
+		validateProviderSecret(resourceRef.ResourceRef.Name, providerType, providerConfig, path, subPath, getter, allErrs)
 	case resourceRef.ResourceRef.Kind == "WorkloadIdentity" && resourceRef.ResourceRef.APIVersion == securityv1alpha1.SchemeGroupVersion.String():
 		if !allowWorkloadIdentity {
 			*allErrs = append(*allErrs, field.Invalid(subPath.Child("kind"), resourceRef.ResourceRef.Kind, "only kind 'Secret' resource references are allowed. To use WorkloadIdentity, please use 'credentials' field instead of 'secretName'"))
@@ -133,7 +204,35 @@ func validateProviderSecretOrWorkloadIdentity(resourceRef core.NamedResourceRefe
 	}
 }
 
-func validateProviderSecret(secretName, providerType string, path, subPath *field.Path, getter ResourceGetter, allErrs *field.ErrorList) {
+// validateProviderCredentialsBinding resolves the CredentialsBinding and validates the credentials it references.
+// The provider type of the CredentialsBinding must match the DNS provider type. The credentials may be located in
+// another namespace.
+func validateProviderCredentialsBinding(bindingName, providerType string, providerConfig *runtime.RawExtension, path, subPath *field.Path, getter ResourceGetter, allErrs *field.ErrorList) {
+	if getter == nil {
+		return
+	}
+	binding, err := getter.GetCredentialsBinding(bindingName)
+	if err != nil {
+		*allErrs = append(*allErrs, field.Invalid(subPath, bindingName, fmt.Sprintf("failed to get the CredentialsBinding: %s", err)))
+		return
+	}
+	if expected := CredentialsProviderType(providerType); providerType != "" && binding.Provider.Type != expected {
+		*allErrs = append(*allErrs, field.Invalid(subPath, bindingName,
+			fmt.Sprintf("the CredentialsBinding has the provider type %q, but providers of type %q need credentials of provider type %q", binding.Provider.Type, providerType, expected)))
+		return
+	}
+	credentialsRef := core.NamedResourceReference{
+		Name: bindingName,
+		ResourceRef: autoscalingv1.CrossVersionObjectReference{
+			Kind:       binding.CredentialsRef.Kind,
+			Name:       binding.CredentialsRef.Name,
+			APIVersion: binding.CredentialsRef.APIVersion,
+		},
+	}
+	validateProviderSecretOrWorkloadIdentity(credentialsRef, true, providerType, providerConfig, path, subPath.Child("credentialsRef"), getter.InNamespace(binding.CredentialsRef.Namespace), allErrs)
+}
+
+func validateProviderSecret(secretName, providerType string, providerConfig *runtime.RawExtension, path, subPath *field.Path, getter ResourceGetter, allErrs *field.ErrorList) {
 	if os.Getenv("DISABLE_SECRET_VALIDATION") == "true" {
 		return
 	}
@@ -149,12 +248,22 @@ func validateProviderSecret(secretName, providerType string, path, subPath *fiel
 			return
 		}
 		props := resources.GetSecretPropertiesFrom(secret)
-		if err := adapter.ValidateCredentialsAndProviderConfig(props, nil); err != nil {
+		if err := adapter.ValidateCredentialsAndProviderConfig(props, providerConfig); err != nil {
 			*allErrs = append(*allErrs, field.Invalid(subPath.Child("ref"), secretName, fmt.Sprintf("validation of secret data or provider config failed: %s", err)))
 		}
 	}
 }
 
+// ValidateSecretCredentials validates the credentials contained in the secret for a DNS provider of the given type
+// together with its provider specific configuration.
+func ValidateSecretCredentials(secret *corev1.Secret, providerType string, providerConfig *runtime.RawExtension) error {
+	adapter, err := getDNSHandlerAdapter(providerType)
+	if err != nil {
+		return err
+	}
+	return adapter.ValidateCredentialsAndProviderConfig(resources.GetSecretPropertiesFrom(secret), providerConfig)
+}
+
 func validateProviderWorkloadIdentity(workloadIdentityName, providerType string, path, subPath *field.Path, getter ResourceGetter, allErrs *field.ErrorList) {
 	if providerType != "" && getter != nil {
 		workloadIdentity, err := getter.GetWorkloadIdentity(workloadIdentityName)
@@ -176,6 +285,10 @@ func validateProviderWorkloadIdentity(workloadIdentityName, providerType string,
 			validateGCPWorkloadIdentity(workloadIdentityName, workloadIdentity, allErrs, subPath, getter)
 		case "azure-dns", "azure-private-dns":
 			validateAzureWorkloadIdentity(workloadIdentityName, workloadIdentity, allErrs, subPath)
+		case "openstack-designate":
+			validateOpenStackWorkloadIdentity(workloadIdentityName, workloadIdentity, allErrs, subPath)
+		case "alicloud-dns":
+			validateAlicloudWorkloadIdentity(workloadIdentityName, workloadIdentity, allErrs, subPath)
 		default:
 			*allErrs = append(*allErrs, field.Invalid(path.Child("type"), providerType,
 				fmt.Sprintf("WorkloadIdentity is not supported for provider type %q", providerType)))
@@ -183,6 +296,25 @@ func validateProviderWorkloadIdentity(workloadIdentityName, providerType string,
 	}
 }
 
+// WorkloadIdentityTargetSystemType returns the target system type of the WorkloadIdentities usable by DNS providers
+// of the given type, or an empty string if the provider type does not support WorkloadIdentities.
+func WorkloadIdentityTargetSystemType(providerType string) string {
+	switch providerType {
+	case "aws-route53":
+		return "aws"
+	case "google-clouddns":
+		return "gcp"
+	case "azure-dns", "azure-private-dns":
+		return "azure"
+	case "openstack-designate":
+		return "openstack"
+	case "alicloud-dns":
+		return "alicloud"
+	default:
+		return ""
+	}
+}
+
 func validateAWSWorkloadIdentity(workloadIdentityName string, workloadIdentity *securityv1alpha1.WorkloadIdentity, allErrs *field.ErrorList, subPath *field.Path) {
 	if workloadIdentity.Spec.TargetSystem.Type != "aws" {
 		*allErrs = append(*allErrs, field.Invalid(subPath.Child("ref"), workloadIdentityName,
@@ -262,6 +394,46 @@ func validateAzureWorkloadIdentity(workloadIdentityName string, workloadIdentity
 	}
 }
 
+func validateOpenStackWorkloadIdentity(workloadIdentityName string, workloadIdentity *securityv1alpha1.WorkloadIdentity, allErrs *field.ErrorList, subPath *field.Path) {
+	if workloadIdentity.Spec.TargetSystem.Type != "openstack" {
+		*allErrs = append(*allErrs, field.Invalid(subPath.Child("ref"), workloadIdentityName,
+			"the WorkloadIdentity provider must be 'openstack' for OpenStack Designate providers"))
+		return
+	}
+
+	var providerConfig workloadidentityopenstack.WorkloadIdentityConfig
+	if err := yaml.Unmarshal(workloadIdentity.Spec.TargetSystem.ProviderConfig.Raw, &providerConfig); err != nil {
+		*allErrs = append(*allErrs, field.Invalid(subPath.Child("ref"), workloadIdentityName,
+			fmt.Sprintf("failed to unmarshal the WorkloadIdentity providerConfig: %s", err)))
+		return
+	}
+
+	errList := workloadidentityopenstack.ValidateWorkloadIdentityConfig(&providerConfig, subPath.Child("ref"))
+	if len(errList) > 0 {
+		*allErrs = append(*allErrs, errList...)
+	}
+}
+
+func validateAlicloudWorkloadIdentity(workloadIdentityName string, workloadIdentity *securityv1alpha1.WorkloadIdentity, allErrs *field.ErrorList, subPath *field.Path) {
+	if workloadIdentity.Spec.TargetSystem.Type != "alicloud" {
+		*allErrs = append(*allErrs, field.Invalid(subPath.Child("ref"), workloadIdentityName,
+			"the WorkloadIdentity provider must be 'alicloud' for Alicloud DNS providers"))
+		return
+	}
+
+	var providerConfig workloadidentityalicloud.WorkloadIdentityConfig
+	if err := yaml.Unmarshal(workloadIdentity.Spec.TargetSystem.ProviderConfig.Raw, &providerConfig); err != nil {
+		*allErrs = append(*allErrs, field.Invalid(subPath.Child("ref"), workloadIdentityName,
+			fmt.Sprintf("failed to unmarshal the WorkloadIdentity providerConfig: %s", err)))
+		return
+	}
+
+	errList := workloadidentityalicloud.ValidateWorkloadIdentityConfig(&providerConfig, subPath.Child("ref"))
+	if len(errList) > 0 {
+		*allErrs = append(*allErrs, errList...)
+	}
+}
+
 func getDNSHandlerAdapter(providerType string) (provider.DNSHandlerAdapter, error) {
 	adaptor := compoundvalidation.GetAdaptor(providerType)
 	if adaptor != nil {
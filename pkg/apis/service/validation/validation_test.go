@@ -31,19 +31,26 @@ import (
 
 	"github.com/gardener/gardener-extension-shoot-dns-service/pkg/apis/service"
 	"github.com/gardener/gardener-extension-shoot-dns-service/pkg/apis/service/validation"
+	workloadidentityalicloud "github.com/gardener/gardener-extension-shoot-dns-service/pkg/apis/service/workloadidentity/alicloud"
+	workloadidentityopenstack "github.com/gardener/gardener-extension-shoot-dns-service/pkg/apis/service/workloadidentity/openstack"
 )
 
 var _ = Describe("Validation", func() {
 	var (
-		awsType          = "aws-route53"
-		gcpType          = "google-clouddns"
-		azureType        = "azure-dns"
-		secretName1      = "my-secret1"
-		secretName2      = "my-secret2"
-		awsWLIdentName   = "my-aws-workload-identity"
-		gcpWLIdentName   = "my-gcp-workload-identity"
-		azureWLIdentName = "my-azure-workload-identity"
-		configMapName    = "my-configmap"
+		awsType                = "aws-route53"
+		gcpType                = "google-clouddns"
+		azureType              = "azure-dns"
+		secretName1            = "my-secret1"
+		secretName2            = "my-secret2"
+		awsWLIdentName         = "my-aws-workload-identity"
+		gcpWLIdentName         = "my-gcp-workload-identity"
+		azureWLIdentName       = "my-azure-workload-identity"
+		openstackWLIdentName   = "my-openstack-workload-identity"
+		alicloudWLIdentName    = "my-alicloud-workload-identity"
+		openstackType          = "openstack-designate"
+		alicloudType           = "alicloud-dns"
+		configMapName          = "my-configmap"
+		credentialsBindingName = "shared-dns-binding"
 
 		awsWorkloadIdentity   *securityv1alpha1.WorkloadIdentity
 		gcpWorkloadIdentity   *securityv1alpha1.WorkloadIdentity
@@ -114,6 +121,22 @@ var _ = Describe("Validation", func() {
 					APIVersion: securityv1alpha1.SchemeGroupVersion.String(),
 				},
 			},
+			{
+				Name: openstackWLIdentName,
+				ResourceRef: v1.CrossVersionObjectReference{
+					Kind:       "WorkloadIdentity",
+					Name:       "org" + openstackWLIdentName,
+					APIVersion: securityv1alpha1.SchemeGroupVersion.String(),
+				},
+			},
+			{
+				Name: alicloudWLIdentName,
+				ResourceRef: v1.CrossVersionObjectReference{
+					Kind:       "WorkloadIdentity",
+					Name:       "org" + alicloudWLIdentName,
+					APIVersion: securityv1alpha1.SchemeGroupVersion.String(),
+				},
+			},
 			{
 				Name: configMapName,
 				ResourceRef: v1.CrossVersionObjectReference{
@@ -192,10 +215,20 @@ var _ = Describe("Validation", func() {
 
 	DescribeTable("#ValidateDNSConfig",
 		func(config service.DNSConfig, presources *[]core.NamedResourceReference, match gomegatypes.GomegaMatcher) {
-			err := validation.ValidateDNSConfig(&config, presources, nil)
+			_, err := validation.ValidateDNSConfig(&config, "", presources, nil, nil)
 			Expect(err).To(match)
 		},
 		Entry("empty", service.DNSConfig{}, nil, BeEmpty()),
+		Entry("valid hibernation mode", service.DNSConfig{
+			Hibernation: new(service.HibernationModeKeepRecords),
+		}, nil, BeEmpty()),
+		Entry("invalid hibernation mode", service.DNSConfig{
+			Hibernation: new(service.HibernationMode("sleep")),
+		}, nil, matchers.ConsistOfFields(Fields{
+			"Type":     Equal(field.ErrorTypeNotSupported),
+			"Field":    Equal("spec.extensions.[@.type='shoot-dns-service'].providerConfig.hibernation"),
+			"BadValue": Equal(service.HibernationMode("sleep")),
+		})),
 		Entry("valid", service.DNSConfig{
 			Providers: valid,
 		}, &resources, BeEmpty()),
@@ -222,6 +255,27 @@ var _ = Describe("Validation", func() {
 			"BadValue": Equal("dummy"),
 			"Detail":   Equal("unsupported provider type. Valid types are: alicloud-dns, aws-route53, azure-dns, azure-private-dns, cloudflare-dns, gdch-dns, google-clouddns, infoblox-dns, netlify-dns, openstack-designate, powerdns, remote, rfc2136"),
 		})),
+		Entry("invalid quotas and rate limit", service.DNSConfig{
+			Providers: modifyCopy(valid[1:], func(items []service.DNSProvider) {
+				items[0].Quotas = &service.DNSProviderQuotas{Entries: new(int32(-1))}
+				items[0].RateLimit = &service.DNSProviderRateLimit{RequestsPerDay: 0, Burst: new(int32(-1))}
+			}),
+		}, &resources, matchers.ConsistOfFields(
+			Fields{
+				"Type":     Equal(field.ErrorTypeInvalid),
+				"Field":    Equal("spec.extensions.[@.type='shoot-dns-service'].providerConfig[0].quotas.entries"),
+				"BadValue": Equal(int32(-1)),
+			},
+			Fields{
+				"Type":     Equal(field.ErrorTypeInvalid),
+				"Field":    Equal("spec.extensions.[@.type='shoot-dns-service'].providerConfig[0].rateLimit.requestsPerDay"),
+				"BadValue": Equal(int32(0)),
+			},
+			Fields{
+				"Type":     Equal(field.ErrorTypeInvalid),
+				"Field":    Equal("spec.extensions.[@.type='shoot-dns-service'].providerConfig[0].rateLimit.burst"),
+				"BadValue": Equal(int32(-1)),
+			})),
 		Entry("missing secret name", service.DNSConfig{
 			Providers: modifyCopy(valid[:1], func(items []service.DNSProvider) {
 				items[0].SecretName = nil
@@ -283,14 +337,233 @@ var _ = Describe("Validation", func() {
 				"Detail":   Equal("only Secret or WorkloadIdentity resource references are allowed"),
 			})))
 
+	DescribeTable("#ValidateDNSConfig - warnings",
+		func(config service.DNSConfig, defaultDomain string, match gomegatypes.GomegaMatcher) {
+			warnings, _ := validation.ValidateDNSConfig(&config, defaultDomain, nil, nil, nil)
+			Expect(warnings).To(match)
+		},
+		Entry("no warnings", service.DNSConfig{
+			Providers: []service.DNSProvider{
+				{Type: &awsType, Credentials: &secretName1, Domains: &service.DNSIncludeExclude{Include: []string{"example.com"}}},
+			},
+		}, "shoot.project.gardener.cloud", BeEmpty()),
+		Entry("deprecated secretName", service.DNSConfig{
+			Providers: []service.DNSProvider{
+				{Type: &awsType, Credentials: &secretName1},
+				{Type: &awsType, SecretName: &secretName2},
+			},
+		}, "", ConsistOf(
+			"spec.extensions.[@.type='shoot-dns-service'].providerConfig[1].secretName: the field secretName is deprecated, please use the field credentials instead",
+		)),
+		Entry("secretName of providers synchronised from the shoot spec", service.DNSConfig{
+			Providers: []service.DNSProvider{
+				{Type: &awsType, SecretName: &secretName2},
+			},
+			SyncProvidersFromShootSpecDNS: new(true),
+		}, "", BeEmpty()),
+		Entry("domains overlapping the domain of the shoot", service.DNSConfig{
+			Providers: []service.DNSProvider{
+				{Type: &awsType, Credentials: &secretName1, Domains: &service.DNSIncludeExclude{Include: []string{"example.com", "Shoot.Project.gardener.cloud."}}},
+				{Type: &awsType, Credentials: &secretName2, Domains: &service.DNSIncludeExclude{Include: []string{"sub.shoot.project.gardener.cloud", "project.gardener.cloud", "myshoot.project.gardener.cloud"}}},
+			},
+		}, "shoot.project.gardener.cloud", ConsistOf(
+			"spec.extensions.[@.type='shoot-dns-service'].providerConfig[0].domains.include[1]: domain \"Shoot.Project.gardener.cloud.\" overlaps with the domain \"shoot.project.gardener.cloud\" of the shoot (spec.dns.domain), please exclude it",
+			"spec.extensions.[@.type='shoot-dns-service'].providerConfig[1].domains.include[0]: domain \"sub.shoot.project.gardener.cloud\" overlaps with the domain \"shoot.project.gardener.cloud\" of the shoot (spec.dns.domain), please exclude it",
+			"spec.extensions.[@.type='shoot-dns-service'].providerConfig[1].domains.include[1]: domain \"project.gardener.cloud\" overlaps with the domain \"shoot.project.gardener.cloud\" of the shoot (spec.dns.domain), please exclude it",
+		)),
+		Entry("domain of the shoot excluded", service.DNSConfig{
+			Providers: []service.DNSProvider{
+				{Type: &awsType, Credentials: &secretName1, Domains: &service.DNSIncludeExclude{Include: []string{"gardener.cloud"}, Exclude: []string{"project.gardener.cloud"}}},
+			},
+		}, "shoot.project.gardener.cloud", BeEmpty()),
+	)
+
+	DescribeTable("#ValidateDomainSelections",
+		func(providers []service.DNSProvider, match gomegatypes.GomegaMatcher) {
+			Expect(validation.ValidateDomainSelections(&service.DNSConfig{Providers: providers}, "")).To(match)
+		},
+		Entry("no selections", []service.DNSProvider{
+			{Type: &awsType, Credentials: &secretName1},
+			{Type: &awsType, Credentials: &secretName2},
+		}, BeEmpty()),
+		Entry("valid selections", []service.DNSProvider{
+			{Type: &awsType, Credentials: &secretName1,
+				Domains: &service.DNSIncludeExclude{Include: []string{"example.com", "*.other.test."}, Exclude: []string{"sub.example.com", "*.internal.example.com"}},
+				Zones:   &service.DNSIncludeExclude{Include: []string{"Z12345"}}},
+			{Type: &awsType, Credentials: &secretName2,
+				Domains: &service.DNSIncludeExclude{Include: []string{"Sub.Example.com", "my.domain.test"}},
+				Zones:   &service.DNSIncludeExclude{Include: []string{"Z67890"}, Exclude: []string{"Z12345"}}},
+		}, BeEmpty()),
+		Entry("only excludes", []service.DNSProvider{
+			{Type: &awsType, Credentials: &secretName1, Domains: &service.DNSIncludeExclude{Exclude: []string{"example.com"}}},
+		}, BeEmpty()),
+		Entry("invalid domain names", []service.DNSProvider{
+			{Type: &awsType, Credentials: &secretName1, Domains: &service.DNSIncludeExclude{
+				Include: []string{"", "my_domain.test", "foo.*.test", "*", "example.com"},
+				Exclude: []string{"-sub.example.com", "sub*.example.com"},
+			}},
+		}, matchers.ConsistOfFields(
+			Fields{
+				"Type":  Equal(field.ErrorTypeRequired),
+				"Field": Equal("spec.extensions.[@.type='shoot-dns-service'].providerConfig[0].domains.include[0]"),
+			},
+			Fields{
+				"Type":     Equal(field.ErrorTypeInvalid),
+				"Field":    Equal("spec.extensions.[@.type='shoot-dns-service'].providerConfig[0].domains.include[1]"),
+				"BadValue": Equal("my_domain.test"),
+				"Detail":   ContainSubstring("RFC 1123 subdomain"),
+			},
+			Fields{
+				"Type":     Equal(field.ErrorTypeInvalid),
+				"Field":    Equal("spec.extensions.[@.type='shoot-dns-service'].providerConfig[0].domains.include[2]"),
+				"BadValue": Equal("foo.*.test"),
+				"Detail":   Equal("a wildcard is only allowed as the complete leftmost label"),
+			},
+			Fields{
+				"Type":     Equal(field.ErrorTypeInvalid),
+				"Field":    Equal("spec.extensions.[@.type='shoot-dns-service'].providerConfig[0].domains.include[3]"),
+				"BadValue": Equal("*"),
+				"Detail":   Equal("a wildcard is only allowed as the complete leftmost label"),
+			},
+			Fields{
+				"Type":     Equal(field.ErrorTypeInvalid),
+				"Field":    Equal("spec.extensions.[@.type='shoot-dns-service'].providerConfig[0].domains.exclude[0]"),
+				"BadValue": Equal("-sub.example.com"),
+				"Detail":   ContainSubstring("RFC 1123 subdomain"),
+			},
+			Fields{
+				"Type":     Equal(field.ErrorTypeInvalid),
+				"Field":    Equal("spec.extensions.[@.type='shoot-dns-service'].providerConfig[0].domains.exclude[1]"),
+				"BadValue": Equal("sub*.example.com"),
+				"Detail":   Equal("a wildcard is only allowed as the complete leftmost label"),
+			},
+		)),
+		Entry("duplicates and excludes outside of the includes", []service.DNSProvider{
+			{Type: &awsType, Credentials: &secretName1, Domains: &service.DNSIncludeExclude{
+				Include: []string{"example.com", "EXAMPLE.com."},
+				Exclude: []string{"sub.example.com", "sub.example.com", "example.com", "sub.other.test"},
+			}},
+		}, matchers.ConsistOfFields(
+			Fields{
+				"Type":     Equal(field.ErrorTypeDuplicate),
+				"Field":    Equal("spec.extensions.[@.type='shoot-dns-service'].providerConfig[0].domains.include[1]"),
+				"BadValue": Equal("EXAMPLE.com."),
+			},
+			Fields{
+				"Type":     Equal(field.ErrorTypeDuplicate),
+				"Field":    Equal("spec.extensions.[@.type='shoot-dns-service'].providerConfig[0].domains.exclude[1]"),
+				"BadValue": Equal("sub.example.com"),
+			},
+			Fields{
+				"Type":     Equal(field.ErrorTypeInvalid),
+				"Field":    Equal("spec.extensions.[@.type='shoot-dns-service'].providerConfig[0].domains.exclude[2]"),
+				"BadValue": Equal("example.com"),
+				"Detail":   Equal("must be a subdomain of an included domain"),
+			},
+			Fields{
+				"Type":     Equal(field.ErrorTypeInvalid),
+				"Field":    Equal("spec.extensions.[@.type='shoot-dns-service'].providerConfig[0].domains.exclude[3]"),
+				"BadValue": Equal("sub.other.test"),
+				"Detail":   Equal("must be a subdomain of an included domain"),
+			},
+		)),
+		Entry("invalid zones", []service.DNSProvider{
+			{Type: &awsType, Credentials: &secretName1, Zones: &service.DNSIncludeExclude{
+				Include: []string{"", "Z1 2", "Z3", "Z3", "Z4"},
+				Exclude: []string{"Z4"},
+			}},
+		}, matchers.ConsistOfFields(
+			Fields{
+				"Type":  Equal(field.ErrorTypeRequired),
+				"Field": Equal("spec.extensions.[@.type='shoot-dns-service'].providerConfig[0].zones.include[0]"),
+			},
+			Fields{
+				"Type":     Equal(field.ErrorTypeInvalid),
+				"Field":    Equal("spec.extensions.[@.type='shoot-dns-service'].providerConfig[0].zones.include[1]"),
+				"BadValue": Equal("Z1 2"),
+				"Detail":   Equal("zone ID must not contain whitespace"),
+			},
+			Fields{
+				"Type":     Equal(field.ErrorTypeDuplicate),
+				"Field":    Equal("spec.extensions.[@.type='shoot-dns-service'].providerConfig[0].zones.include[3]"),
+				"BadValue": Equal("Z3"),
+			},
+			Fields{
+				"Type":     Equal(field.ErrorTypeInvalid),
+				"Field":    Equal("spec.extensions.[@.type='shoot-dns-service'].providerConfig[0].zones.include[4]"),
+				"BadValue": Equal("Z4"),
+				"Detail":   Equal("zone is also excluded"),
+			},
+		)),
+		Entry("overlapping selections across providers", []service.DNSProvider{
+			{Type: &awsType, Credentials: &secretName1,
+				Domains: &service.DNSIncludeExclude{Include: []string{"example.com", "other.test"}, Exclude: []string{"sub.other.test"}},
+				Zones:   &service.DNSIncludeExclude{Include: []string{"Z1"}}},
+			{Type: &gcpType, Credentials: &secretName2,
+				Domains: &service.DNSIncludeExclude{Include: []string{"a.sub.other.test", "Example.com."}},
+				Zones:   &service.DNSIncludeExclude{Include: []string{"Z2"}}},
+			{Type: &azureType, Credentials: &secretName2,
+				Domains: &service.DNSIncludeExclude{Include: []string{"test", "*.a.example.com"}},
+				Zones:   &service.DNSIncludeExclude{Include: []string{"Z1", "Z3"}}},
+		}, matchers.ConsistOfFields(
+			Fields{
+				"Type":     Equal(field.ErrorTypeInvalid),
+				"Field":    Equal("spec.extensions.[@.type='shoot-dns-service'].providerConfig[1].domains.include[1]"),
+				"BadValue": Equal("Example.com."),
+				"Detail":   Equal("overlaps with the included domain \"example.com\" of spec.extensions.[@.type='shoot-dns-service'].providerConfig[0].domains.include[0]"),
+			},
+			Fields{
+				"Type":     Equal(field.ErrorTypeInvalid),
+				"Field":    Equal("spec.extensions.[@.type='shoot-dns-service'].providerConfig[2].domains.include[0]"),
+				"BadValue": Equal("test"),
+				"Detail":   Equal("overlaps with the included domain \"other.test\" of spec.extensions.[@.type='shoot-dns-service'].providerConfig[0].domains.include[1]"),
+			},
+			Fields{
+				"Type":     Equal(field.ErrorTypeInvalid),
+				"Field":    Equal("spec.extensions.[@.type='shoot-dns-service'].providerConfig[2].domains.include[1]"),
+				"BadValue": Equal("*.a.example.com"),
+				"Detail":   Equal("overlaps with the included domain \"example.com\" of spec.extensions.[@.type='shoot-dns-service'].providerConfig[0].domains.include[0]"),
+			},
+			Fields{
+				"Type":     Equal(field.ErrorTypeInvalid),
+				"Field":    Equal("spec.extensions.[@.type='shoot-dns-service'].providerConfig[2].zones.include[0]"),
+				"BadValue": Equal("Z1"),
+				"Detail":   Equal("zone is also included by spec.extensions.[@.type='shoot-dns-service'].providerConfig[0]"),
+			},
+		)),
+	)
+
+	DescribeTable("#ValidateDomainSelections - with shoot domain",
+		func(domains *service.DNSIncludeExclude, match gomegatypes.GomegaMatcher) {
+			providers := []service.DNSProvider{{Type: &awsType, Credentials: &secretName1, Domains: domains}}
+			Expect(validation.ValidateDomainSelections(&service.DNSConfig{Providers: providers}, "shoot.example.com")).To(match)
+		},
+		Entry("no selection", nil, BeEmpty()),
+		Entry("other domain", &service.DNSIncludeExclude{Include: []string{"other.example.com"}}, BeEmpty()),
+		Entry("excluded shoot domain", &service.DNSIncludeExclude{Include: []string{"example.com"}, Exclude: []string{"shoot.example.com"}}, BeEmpty()),
+		Entry("parent domain", &service.DNSIncludeExclude{Include: []string{"other.example.com", "example.com", "*.example.com"}}, BeEmpty()),
+		Entry("shoot domain", &service.DNSIncludeExclude{Include: []string{"example.com", "Shoot.example.com."}}, matchers.ConsistOfFields(Fields{
+			"Type":     Equal(field.ErrorTypeInvalid),
+			"Field":    Equal("spec.extensions.[@.type='shoot-dns-service'].providerConfig[0].domains.include[1]"),
+			"BadValue": Equal("Shoot.example.com."),
+			"Detail":   Equal("must not be the domain \"shoot.example.com\" of spec.dns.domain or one of its subdomains"),
+		})),
+		Entry("subdomain", &service.DNSIncludeExclude{Include: []string{"*.ingress.Shoot.example.com."}}, matchers.ConsistOfFields(Fields{
+			"Type":     Equal(field.ErrorTypeInvalid),
+			"Field":    Equal("spec.extensions.[@.type='shoot-dns-service'].providerConfig[0].domains.include[0]"),
+			"BadValue": Equal("*.ingress.Shoot.example.com."),
+			"Detail":   Equal("must not be the domain \"shoot.example.com\" of spec.dns.domain or one of its subdomains"),
+		})),
+	)
+
 	DescribeTable("#ValidateDNSConfig - with secret getter",
 		func(config service.DNSConfig, presources *[]core.NamedResourceReference, getter validation.ResourceGetter, match gomegatypes.GomegaMatcher, shouldBeIgnoredIfDisabled bool) {
-			err := validation.ValidateDNSConfig(&config, presources, getter)
+			_, err := validation.ValidateDNSConfig(&config, "", presources, getter, nil)
 			Expect(err).To(match)
 			if shouldBeIgnoredIfDisabled {
 				os.Setenv("DISABLE_SECRET_VALIDATION", "true")
 				defer os.Unsetenv("DISABLE_SECRET_VALIDATION")
-				err = validation.ValidateDNSConfig(&config, presources, getter)
+				_, err = validation.ValidateDNSConfig(&config, "", presources, getter, nil)
 				Expect(err).To(BeEmpty(), "validation should not fail when DISABLE_SECRET_VALIDATION is set to true")
 			}
 		},
@@ -409,6 +682,31 @@ var _ = Describe("Validation", func() {
 					"BadValue": Equal("orgmy-secret2"),
 					"Detail":   Equal("validation of secret data or provider config failed: validation failed for provider type aws-route53: property \"wrongKey\" is not allowed"),
 				}), true),
+		Entry("provider config validation errors",
+			service.DNSConfig{
+				Providers: modifyCopy(valid, func(items []service.DNSProvider) {
+					items[0].ProviderConfig = &runtime.RawExtension{Raw: []byte(`{"batchSize": 20}`)}
+					items[1].ProviderConfig = &runtime.RawExtension{Raw: []byte(`{"batchSize": 100}`)}
+				}),
+			}, &resources, secretResourceGetter(func(name string) (*corev1.Secret, error) {
+				switch name {
+				case "org" + secretName1, "org" + secretName2:
+					return &corev1.Secret{
+						Data: map[string][]byte{
+							"accessKeyID":     []byte("myAccessKeyId"),
+							"secretAccessKey": []byte("mySecretAccessKey"),
+						},
+					}, nil
+				default:
+					return nil, fmt.Errorf("unexpected secret name %q", name)
+				}
+			}), matchers.ConsistOfFields(
+				Fields{
+					"Type":     Equal(field.ErrorTypeInvalid),
+					"Field":    Equal("spec.extensions.[@.type='shoot-dns-service'].providerConfig[1].credentials.ref"),
+					"BadValue": Equal("orgmy-secret2"),
+					"Detail":   Equal("validation of secret data or provider config failed: invalid batch size 100, must be between 1 and 50"),
+				}), true),
 		Entry("secret not found",
 			service.DNSConfig{
 				Providers: valid,
@@ -497,9 +795,161 @@ var _ = Describe("Validation", func() {
 					"Detail":   Equal("the WorkloadIdentity resource does not contain a providerConfig"),
 				},
 			), false),
+		Entry("openstack and alicloud workload identities",
+			service.DNSConfig{
+				Providers: []service.DNSProvider{
+					{Type: &openstackType, Credentials: &openstackWLIdentName},
+					{Type: &alicloudType, Credentials: &alicloudWLIdentName},
+				},
+			}, &resources, wlResourceGetter(
+				func(name string) (*securityv1alpha1.WorkloadIdentity, error) {
+					switch name {
+					case "org" + openstackWLIdentName:
+						return makeWorkloadIdentity("openstack", workloadidentityopenstack.WorkloadIdentityConfig{
+							AuthURL:          "https://keystone.example.com/v3",
+							DomainName:       "default",
+							TenantName:       "dns",
+							IdentityProvider: "gardener",
+							Protocol:         "openid",
+						}), nil
+					case "org" + alicloudWLIdentName:
+						return makeWorkloadIdentity("alicloud", workloadidentityalicloud.WorkloadIdentityConfig{
+							RoleARN:         "acs:ram::123456789012:role/dns-admin",
+							OIDCProviderARN: "acs:ram::123456789012:oidc-provider/gardener",
+						}), nil
+					default:
+						return nil, fmt.Errorf("unexpected workload identity name %q", name)
+					}
+				},
+			), BeEmpty(), false),
+		Entry("invalid openstack and alicloud workload identities",
+			service.DNSConfig{
+				Providers: []service.DNSProvider{
+					{Type: &openstackType, Credentials: &openstackWLIdentName},
+					{Type: &alicloudType, Credentials: &alicloudWLIdentName},
+				},
+			}, &resources, wlResourceGetter(
+				func(name string) (*securityv1alpha1.WorkloadIdentity, error) {
+					switch name {
+					case "org" + openstackWLIdentName:
+						return makeWorkloadIdentity("openstack", workloadidentityopenstack.WorkloadIdentityConfig{
+							AuthURL:          "https://keystone.example.com/v3",
+							DomainName:       "default",
+							IdentityProvider: "gardener",
+							Protocol:         "openid",
+						}), nil
+					case "org" + alicloudWLIdentName:
+						return makeWorkloadIdentity("aws", workloadidentityaws.WorkloadIdentityConfig{RoleARN: "arn:aws:iam::123456789012:role/my-role"}), nil
+					default:
+						return nil, fmt.Errorf("unexpected workload identity name %q", name)
+					}
+				},
+			), matchers.ConsistOfFields(
+				Fields{
+					"Type":   Equal(field.ErrorTypeRequired),
+					"Field":  Equal("spec.extensions.[@.type='shoot-dns-service'].providerConfig[0].credentials.ref.tenantName"),
+					"Detail": Equal("tenantName is required"),
+				},
+				Fields{
+					"Type":     Equal(field.ErrorTypeInvalid),
+					"Field":    Equal("spec.extensions.[@.type='shoot-dns-service'].providerConfig[1].credentials.ref"),
+					"BadValue": Equal("org" + alicloudWLIdentName),
+					"Detail":   Equal("the WorkloadIdentity provider must be 'alicloud' for Alicloud DNS providers"),
+				},
+			), false),
+		Entry("credentials binding referencing a shared secret",
+			service.DNSConfig{
+				Providers: []service.DNSProvider{{Type: &awsType, CredentialsBindingName: &credentialsBindingName}},
+			}, &resources, credentialsBindingResourceGetter(
+				func(name string) (*securityv1alpha1.CredentialsBinding, error) {
+					if name != credentialsBindingName {
+						return nil, fmt.Errorf("credentials binding %s not found", name)
+					}
+					return makeCredentialsBinding("Secret", "v1", "shared-dns"), nil
+				},
+				func(name string) (*corev1.Secret, error) {
+					switch name {
+					case "shared/shared-dns":
+						return &corev1.Secret{
+							Data: map[string][]byte{
+								"accessKeyID":     []byte("myAccessKeyId"),
+								"secretAccessKey": []byte("mySecretAccessKey"),
+							},
+						}, nil
+					default:
+						return nil, fmt.Errorf("unexpected secret name %q", name)
+					}
+				},
+			), BeEmpty(), false),
+		Entry("credentials binding not found",
+			service.DNSConfig{
+				Providers: []service.DNSProvider{{Type: &awsType, CredentialsBindingName: new("unknown")}},
+			}, &resources, credentialsBindingResourceGetter(
+				func(name string) (*securityv1alpha1.CredentialsBinding, error) {
+					return nil, fmt.Errorf("credentials binding %s not found", name)
+				}, nil,
+			), matchers.ConsistOfFields(
+				Fields{
+					"Type":     Equal(field.ErrorTypeInvalid),
+					"Field":    Equal("spec.extensions.[@.type='shoot-dns-service'].providerConfig[0].credentialsBindingName"),
+					"BadValue": Equal("unknown"),
+					"Detail":   Equal("failed to get the CredentialsBinding: credentials binding unknown not found"),
+				},
+			), false),
+		Entry("credentials binding of another provider type",
+			service.DNSConfig{
+				Providers: []service.DNSProvider{{Type: &awsType, CredentialsBindingName: &credentialsBindingName}},
+			}, &resources, credentialsBindingResourceGetter(
+				func(string) (*securityv1alpha1.CredentialsBinding, error) {
+					binding := makeCredentialsBinding("Secret", "v1", "shared-dns")
+					binding.Provider.Type = "gcp"
+					return binding, nil
+				}, nil,
+			), matchers.ConsistOfFields(
+				Fields{
+					"Type":     Equal(field.ErrorTypeInvalid),
+					"Field":    Equal("spec.extensions.[@.type='shoot-dns-service'].providerConfig[0].credentialsBindingName"),
+					"BadValue": Equal(credentialsBindingName),
+					"Detail":   Equal(`the CredentialsBinding has the provider type "gcp", but providers of type "aws-route53" need credentials of provider type "aws"`),
+				},
+			), false),
+		Entry("credentials binding referencing an internal secret",
+			service.DNSConfig{
+				Providers: []service.DNSProvider{{Type: &awsType, CredentialsBindingName: &credentialsBindingName}},
+			}, &resources, credentialsBindingResourceGetter(
+				func(string) (*securityv1alpha1.CredentialsBinding, error) {
+					return makeCredentialsBinding("InternalSecret", "core.gardener.cloud/v1beta1", "shared-dns"), nil
+				}, nil,
+			), matchers.ConsistOfFields(
+				Fields{
+					"Type":     Equal(field.ErrorTypeInvalid),
+					"Field":    Equal("spec.extensions.[@.type='shoot-dns-service'].providerConfig[0].credentialsBindingName.credentialsRef.kind"),
+					"BadValue": Equal("InternalSecret"),
+					"Detail":   Equal("only Secret or WorkloadIdentity resource references are allowed"),
+				},
+			), false),
+		Entry("credentials binding and secret name",
+			service.DNSConfig{
+				Providers: []service.DNSProvider{{Type: &awsType, SecretName: &secretName1, CredentialsBindingName: &credentialsBindingName}},
+			}, &resources, credentialsBindingResourceGetter(nil, nil), matchers.ConsistOfFields(
+				Fields{
+					"Type":     Equal(field.ErrorTypeInvalid),
+					"Field":    Equal("spec.extensions.[@.type='shoot-dns-service'].providerConfig[0].credentialsBindingName"),
+					"BadValue": Equal(credentialsBindingName),
+					"Detail":   Equal("only one of secretName, credentials or credentialsBindingName must be provided"),
+				},
+			), false),
 	)
 })
 
+func makeCredentialsBinding(kind, apiVersion, name string) *securityv1alpha1.CredentialsBinding {
+	return &securityv1alpha1.CredentialsBinding{
+		ObjectMeta:     metav1.ObjectMeta{Name: "shared-dns-binding", Namespace: "garden-test"},
+		Provider:       securityv1alpha1.CredentialsBindingProvider{Type: "aws"},
+		CredentialsRef: corev1.ObjectReference{Kind: kind, APIVersion: apiVersion, Namespace: "shared", Name: name},
+	}
+}
+
 func modifyCopy(original []service.DNSProvider, modifier func([]service.DNSProvider)) []service.DNSProvider {
 	var array []service.DNSProvider
 	for _, p := range original {
@@ -527,9 +977,20 @@ func wlResourceGetter(
 	}
 }
 
+func credentialsBindingResourceGetter(
+	credentialsBindingGetter func(name string) (*securityv1alpha1.CredentialsBinding, error),
+	secretGetter func(name string) (*corev1.Secret, error),
+) validation.ResourceGetter {
+	return &testResourceGetter{
+		credentialsBindingGetter: credentialsBindingGetter,
+		secretGetter:             secretGetter,
+	}
+}
+
 type testResourceGetter struct {
 	secretGetter                      func(name string) (*corev1.Secret, error)
 	workloadIdentityGetter            func(name string) (*securityv1alpha1.WorkloadIdentity, error)
+	credentialsBindingGetter          func(name string) (*securityv1alpha1.CredentialsBinding, error)
 	internalGCPWorkloadIdentityConfig config.InternalGCPWorkloadIdentityConfig
 }
 
@@ -552,3 +1013,24 @@ func (r *testResourceGetter) GetWorkloadIdentity(name string) (*securityv1alpha1
 func (r *testResourceGetter) GetInternalGCPWorkloadIdentityConfig() config.InternalGCPWorkloadIdentityConfig {
 	return r.internalGCPWorkloadIdentityConfig
 }
+
+func (r *testResourceGetter) GetCredentialsBinding(name string) (*securityv1alpha1.CredentialsBinding, error) {
+	if r.credentialsBindingGetter == nil {
+		return nil, fmt.Errorf("credentialsBinding getter not set")
+	}
+	return r.credentialsBindingGetter(name)
+}
+
+// InNamespace returns a getter passing names qualified with the namespace to the getter functions.
+func (r *testResourceGetter) InNamespace(namespace string) validation.ResourceGetter {
+	qualified := &testResourceGetter{internalGCPWorkloadIdentityConfig: r.internalGCPWorkloadIdentityConfig}
+	if r.secretGetter != nil {
+		qualified.secretGetter = func(name string) (*corev1.Secret, error) { return r.secretGetter(namespace + "/" + name) }
+	}
+	if r.workloadIdentityGetter != nil {
+		qualified.workloadIdentityGetter = func(name string) (*securityv1alpha1.WorkloadIdentity, error) {
+			return r.workloadIdentityGetter(namespace + "/" + name)
+		}
+	}
+	return qualified
+}
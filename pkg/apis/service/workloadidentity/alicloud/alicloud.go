@@ -0,0 +1,89 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package alicloud
+
+import (
+	"fmt"
+	"regexp"
+
+	apivalidation "k8s.io/apimachinery/pkg/api/validation"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/yaml"
+)
+
+var (
+	roleARNRegex         = regexp.MustCompile(`^acs:ram::[0-9]+:role/[A-Za-z0-9.\-]+$`)
+	oidcProviderARNRegex = regexp.MustCompile(`^acs:ram::[0-9]+:oidc-provider/[A-Za-z0-9.\-]+$`)
+)
+
+// WorkloadIdentityConfig is the configuration of a WorkloadIdentity with target system type 'alicloud'.
+// The token of the workload identity is exchanged for temporary credentials of a RAM role with AssumeRoleWithOIDC.
+type WorkloadIdentityConfig struct {
+	metav1.TypeMeta
+
+	// RoleARN is the ARN of the RAM role to assume, e.g. 'acs:ram::123456789012:role/dns-admin'.
+	RoleARN string `json:"roleARN,omitempty"`
+	// OIDCProviderARN is the ARN of the OIDC identity provider registered for the issuer of the token,
+	// e.g. 'acs:ram::123456789012:oidc-provider/gardener'.
+	OIDCProviderARN string `json:"oidcProviderARN,omitempty"`
+}
+
+// DeepCopy returns a deep copy of the configuration.
+func (c *WorkloadIdentityConfig) DeepCopy() *WorkloadIdentityConfig {
+	if c == nil {
+		return nil
+	}
+	out := new(WorkloadIdentityConfig)
+	*out = *c
+	return out
+}
+
+// ValidateWorkloadIdentityConfig validates the configuration.
+func ValidateWorkloadIdentityConfig(config *WorkloadIdentityConfig, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if config.APIVersion != "alicloud.provider.extensions.gardener.cloud/v1alpha1" {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("apiVersion"), config.APIVersion, "apiVersion must be 'alicloud.provider.extensions.gardener.cloud/v1alpha1'"))
+	}
+	if config.Kind != "WorkloadIdentityConfig" {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("kind"), config.Kind, "kind must be 'WorkloadIdentityConfig'"))
+	}
+
+	if len(config.RoleARN) == 0 {
+		allErrs = append(allErrs, field.Required(fldPath.Child("roleARN"), "roleARN is required"))
+	} else if !roleARNRegex.MatchString(config.RoleARN) {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("roleARN"), config.RoleARN, "roleARN must have the format 'acs:ram::<account-id>:role/<role-name>'"))
+	}
+	if len(config.OIDCProviderARN) == 0 {
+		allErrs = append(allErrs, field.Required(fldPath.Child("oidcProviderARN"), "oidcProviderARN is required"))
+	} else if !oidcProviderARNRegex.MatchString(config.OIDCProviderARN) {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("oidcProviderARN"), config.OIDCProviderARN, "oidcProviderARN must have the format 'acs:ram::<account-id>:oidc-provider/<provider-name>'"))
+	}
+
+	return allErrs
+}
+
+// ValidateWorkloadIdentityConfigUpdate validates the update of the configuration.
+// The OIDC provider must not be changed, as it is bound to the issuer of the Garden cluster.
+func ValidateWorkloadIdentityConfigUpdate(oldConfig, newConfig *WorkloadIdentityConfig, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	allErrs = append(allErrs, apivalidation.ValidateImmutableField(newConfig.OIDCProviderARN, oldConfig.OIDCProviderARN, fldPath.Child("oidcProviderARN"))...)
+	allErrs = append(allErrs, ValidateWorkloadIdentityConfig(newConfig, fldPath)...)
+
+	return allErrs
+}
+
+// GetWorkloadIdentityConfig unmarshals and validates the configuration.
+func GetWorkloadIdentityConfig(configData []byte) (*WorkloadIdentityConfig, error) {
+	cfg := &WorkloadIdentityConfig{}
+	if err := yaml.Unmarshal(configData, cfg); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal workload identity config: %w", err)
+	}
+	if err := ValidateWorkloadIdentityConfig(cfg, field.NewPath("config")).ToAggregate(); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
@@ -0,0 +1,93 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package alicloud_test
+
+import (
+	. "github.com/gardener/gardener/pkg/utils/test/matchers"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	. "github.com/gardener/gardener-extension-shoot-dns-service/pkg/apis/service/workloadidentity/alicloud"
+)
+
+var _ = Describe("Alicloud WorkloadIdentityConfig", func() {
+	var workloadIdentityConfig *WorkloadIdentityConfig
+
+	BeforeEach(func() {
+		workloadIdentityConfig = &WorkloadIdentityConfig{
+			TypeMeta: metav1.TypeMeta{
+				APIVersion: "alicloud.provider.extensions.gardener.cloud/v1alpha1",
+				Kind:       "WorkloadIdentityConfig",
+			},
+			RoleARN:         "acs:ram::123456789012:role/dns-admin",
+			OIDCProviderARN: "acs:ram::123456789012:oidc-provider/gardener",
+		}
+	})
+
+	Describe("#ValidateWorkloadIdentityConfig", func() {
+		It("should validate the config successfully", func() {
+			Expect(ValidateWorkloadIdentityConfig(workloadIdentityConfig, field.NewPath("providerConfig"))).To(BeEmpty())
+		})
+
+		It("should require the ARNs", func() {
+			workloadIdentityConfig.RoleARN = ""
+			workloadIdentityConfig.OIDCProviderARN = ""
+			Expect(ValidateWorkloadIdentityConfig(workloadIdentityConfig, field.NewPath("providerConfig"))).To(ConsistOfFields(
+				Fields{
+					"Type":   Equal(field.ErrorTypeRequired),
+					"Field":  Equal("providerConfig.roleARN"),
+					"Detail": Equal("roleARN is required"),
+				},
+				Fields{
+					"Type":   Equal(field.ErrorTypeRequired),
+					"Field":  Equal("providerConfig.oidcProviderARN"),
+					"Detail": Equal("oidcProviderARN is required"),
+				},
+			))
+		})
+
+		It("should reject malformed ARNs", func() {
+			workloadIdentityConfig.Kind = "foo"
+			workloadIdentityConfig.RoleARN = "arn:aws:iam::123456789012:role/dns-admin"
+			workloadIdentityConfig.OIDCProviderARN = "acs:ram::123456789012:role/gardener"
+			Expect(ValidateWorkloadIdentityConfig(workloadIdentityConfig, field.NewPath("providerConfig"))).To(ConsistOfFields(
+				Fields{
+					"Type":  Equal(field.ErrorTypeInvalid),
+					"Field": Equal("providerConfig.kind"),
+				},
+				Fields{
+					"Type":  Equal(field.ErrorTypeInvalid),
+					"Field": Equal("providerConfig.roleARN"),
+				},
+				Fields{
+					"Type":  Equal(field.ErrorTypeInvalid),
+					"Field": Equal("providerConfig.oidcProviderARN"),
+				},
+			))
+		})
+	})
+
+	Describe("#ValidateWorkloadIdentityConfigUpdate", func() {
+		It("should allow changing the role", func() {
+			newConfig := workloadIdentityConfig.DeepCopy()
+			newConfig.RoleARN = "acs:ram::123456789012:role/dns-operator"
+			Expect(ValidateWorkloadIdentityConfigUpdate(workloadIdentityConfig, newConfig, field.NewPath("providerConfig"))).To(BeEmpty())
+		})
+
+		It("should not allow changing the OIDC provider", func() {
+			newConfig := workloadIdentityConfig.DeepCopy()
+			newConfig.OIDCProviderARN = "acs:ram::123456789012:oidc-provider/other"
+			Expect(ValidateWorkloadIdentityConfigUpdate(workloadIdentityConfig, newConfig, field.NewPath("providerConfig"))).To(ConsistOfFields(
+				Fields{
+					"Type":  Equal(field.ErrorTypeInvalid),
+					"Field": Equal("providerConfig.oidcProviderARN"),
+				},
+			))
+		})
+	})
+})
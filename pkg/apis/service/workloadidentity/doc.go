@@ -0,0 +1,7 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package workloadidentity contains the WorkloadIdentity configurations of the target systems which are not covered
+// by the external-dns-management project.
+package workloadidentity
@@ -0,0 +1,100 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package openstack
+
+import (
+	"fmt"
+	"net/url"
+
+	apivalidation "k8s.io/apimachinery/pkg/api/validation"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/yaml"
+)
+
+// WorkloadIdentityConfig is the configuration of a WorkloadIdentity with target system type 'openstack'.
+// The token of the workload identity is exchanged at the Keystone identity service using federation.
+type WorkloadIdentityConfig struct {
+	metav1.TypeMeta
+
+	// AuthURL is the URL of the Keystone identity service.
+	AuthURL string `json:"authURL,omitempty"`
+	// DomainName is the name of the domain of the project.
+	DomainName string `json:"domainName,omitempty"`
+	// TenantName is the name of the project.
+	TenantName string `json:"tenantName,omitempty"`
+	// IdentityProvider is the name of the identity provider registered in Keystone for the issuer of the token.
+	IdentityProvider string `json:"identityProvider,omitempty"`
+	// Protocol is the name of the federation protocol registered in Keystone, e.g. 'openid'.
+	Protocol string `json:"protocol,omitempty"`
+	// Region is the name of the region. It is optional.
+	Region string `json:"region,omitempty"`
+}
+
+// DeepCopy returns a deep copy of the configuration.
+func (c *WorkloadIdentityConfig) DeepCopy() *WorkloadIdentityConfig {
+	if c == nil {
+		return nil
+	}
+	out := new(WorkloadIdentityConfig)
+	*out = *c
+	return out
+}
+
+// ValidateWorkloadIdentityConfig validates the configuration.
+func ValidateWorkloadIdentityConfig(config *WorkloadIdentityConfig, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if config.APIVersion != "openstack.provider.extensions.gardener.cloud/v1alpha1" {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("apiVersion"), config.APIVersion, "apiVersion must be 'openstack.provider.extensions.gardener.cloud/v1alpha1'"))
+	}
+	if config.Kind != "WorkloadIdentityConfig" {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("kind"), config.Kind, "kind must be 'WorkloadIdentityConfig'"))
+	}
+
+	if len(config.AuthURL) == 0 {
+		allErrs = append(allErrs, field.Required(fldPath.Child("authURL"), "authURL is required"))
+	} else if u, err := url.Parse(config.AuthURL); err != nil || u.Scheme != "https" || u.Host == "" {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("authURL"), config.AuthURL, "authURL must be a valid https URL"))
+	}
+	if len(config.DomainName) == 0 {
+		allErrs = append(allErrs, field.Required(fldPath.Child("domainName"), "domainName is required"))
+	}
+	if len(config.TenantName) == 0 {
+		allErrs = append(allErrs, field.Required(fldPath.Child("tenantName"), "tenantName is required"))
+	}
+	if len(config.IdentityProvider) == 0 {
+		allErrs = append(allErrs, field.Required(fldPath.Child("identityProvider"), "identityProvider is required"))
+	}
+	if len(config.Protocol) == 0 {
+		allErrs = append(allErrs, field.Required(fldPath.Child("protocol"), "protocol is required"))
+	}
+
+	return allErrs
+}
+
+// ValidateWorkloadIdentityConfigUpdate validates the update of the configuration.
+// The project must not be changed, as the DNS zones are owned by it.
+func ValidateWorkloadIdentityConfigUpdate(oldConfig, newConfig *WorkloadIdentityConfig, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	allErrs = append(allErrs, apivalidation.ValidateImmutableField(newConfig.AuthURL, oldConfig.AuthURL, fldPath.Child("authURL"))...)
+	allErrs = append(allErrs, apivalidation.ValidateImmutableField(newConfig.DomainName, oldConfig.DomainName, fldPath.Child("domainName"))...)
+	allErrs = append(allErrs, apivalidation.ValidateImmutableField(newConfig.TenantName, oldConfig.TenantName, fldPath.Child("tenantName"))...)
+	allErrs = append(allErrs, ValidateWorkloadIdentityConfig(newConfig, fldPath)...)
+
+	return allErrs
+}
+
+// GetWorkloadIdentityConfig unmarshals and validates the configuration.
+func GetWorkloadIdentityConfig(configData []byte) (*WorkloadIdentityConfig, error) {
+	cfg := &WorkloadIdentityConfig{}
+	if err := yaml.Unmarshal(configData, cfg); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal workload identity config: %w", err)
+	}
+	if err := ValidateWorkloadIdentityConfig(cfg, field.NewPath("config")).ToAggregate(); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
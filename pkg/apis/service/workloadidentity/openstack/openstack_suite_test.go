@@ -0,0 +1,17 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package openstack_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestOpenStackWorkloadIdentity(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "OpenStack WorkloadIdentity Suite")
+}
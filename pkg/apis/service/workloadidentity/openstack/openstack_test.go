@@ -0,0 +1,116 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package openstack_test
+
+import (
+	. "github.com/gardener/gardener/pkg/utils/test/matchers"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	. "github.com/gardener/gardener-extension-shoot-dns-service/pkg/apis/service/workloadidentity/openstack"
+)
+
+var _ = Describe("OpenStack WorkloadIdentityConfig", func() {
+	var workloadIdentityConfig *WorkloadIdentityConfig
+
+	BeforeEach(func() {
+		workloadIdentityConfig = &WorkloadIdentityConfig{
+			TypeMeta: metav1.TypeMeta{
+				APIVersion: "openstack.provider.extensions.gardener.cloud/v1alpha1",
+				Kind:       "WorkloadIdentityConfig",
+			},
+			AuthURL:          "https://keystone.example.com/v3",
+			DomainName:       "default",
+			TenantName:       "dns",
+			IdentityProvider: "gardener",
+			Protocol:         "openid",
+		}
+	})
+
+	Describe("#ValidateWorkloadIdentityConfig", func() {
+		It("should validate the config successfully", func() {
+			Expect(ValidateWorkloadIdentityConfig(workloadIdentityConfig, field.NewPath("providerConfig"))).To(BeEmpty())
+		})
+
+		It("should contain all expected validation errors", func() {
+			workloadIdentityConfig.APIVersion = "foo"
+			workloadIdentityConfig.AuthURL = "http://keystone.example.com/v3"
+			workloadIdentityConfig.DomainName = ""
+			workloadIdentityConfig.TenantName = ""
+			workloadIdentityConfig.IdentityProvider = ""
+			workloadIdentityConfig.Protocol = ""
+			Expect(ValidateWorkloadIdentityConfig(workloadIdentityConfig, field.NewPath("providerConfig"))).To(ConsistOfFields(
+				Fields{
+					"Type":  Equal(field.ErrorTypeInvalid),
+					"Field": Equal("providerConfig.apiVersion"),
+				},
+				Fields{
+					"Type":   Equal(field.ErrorTypeInvalid),
+					"Field":  Equal("providerConfig.authURL"),
+					"Detail": Equal("authURL must be a valid https URL"),
+				},
+				Fields{
+					"Type":  Equal(field.ErrorTypeRequired),
+					"Field": Equal("providerConfig.domainName"),
+				},
+				Fields{
+					"Type":  Equal(field.ErrorTypeRequired),
+					"Field": Equal("providerConfig.tenantName"),
+				},
+				Fields{
+					"Type":  Equal(field.ErrorTypeRequired),
+					"Field": Equal("providerConfig.identityProvider"),
+				},
+				Fields{
+					"Type":  Equal(field.ErrorTypeRequired),
+					"Field": Equal("providerConfig.protocol"),
+				},
+			))
+		})
+	})
+
+	Describe("#ValidateWorkloadIdentityConfigUpdate", func() {
+		It("should allow changing the identity provider", func() {
+			newConfig := workloadIdentityConfig.DeepCopy()
+			newConfig.IdentityProvider = "gardener-new"
+			Expect(ValidateWorkloadIdentityConfigUpdate(workloadIdentityConfig, newConfig, field.NewPath("providerConfig"))).To(BeEmpty())
+		})
+
+		It("should not allow changing the project", func() {
+			newConfig := workloadIdentityConfig.DeepCopy()
+			newConfig.DomainName = "other"
+			newConfig.TenantName = "other"
+			Expect(ValidateWorkloadIdentityConfigUpdate(workloadIdentityConfig, newConfig, field.NewPath("providerConfig"))).To(ConsistOfFields(
+				Fields{
+					"Type":  Equal(field.ErrorTypeInvalid),
+					"Field": Equal("providerConfig.domainName"),
+				},
+				Fields{
+					"Type":  Equal(field.ErrorTypeInvalid),
+					"Field": Equal("providerConfig.tenantName"),
+				},
+			))
+		})
+	})
+
+	Describe("#GetWorkloadIdentityConfig", func() {
+		It("should unmarshal and validate the config", func() {
+			cfg, err := GetWorkloadIdentityConfig([]byte(`
+apiVersion: openstack.provider.extensions.gardener.cloud/v1alpha1
+kind: WorkloadIdentityConfig
+authURL: https://keystone.example.com/v3
+domainName: default
+tenantName: dns
+identityProvider: gardener
+protocol: openid
+`))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(cfg).To(Equal(workloadIdentityConfig))
+		})
+	})
+})
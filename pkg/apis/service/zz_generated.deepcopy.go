@@ -10,6 +10,7 @@
 package service
 
 import (
+	v1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
@@ -39,6 +40,11 @@ func (in *DNSConfig) DeepCopyInto(out *DNSConfig) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.Hibernation != nil {
+		in, out := &in.Hibernation, &out.Hibernation
+		*out = new(HibernationMode)
+		**out = **in
+	}
 	return
 }
 
@@ -60,6 +66,38 @@ func (in *DNSConfig) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DNSDisallowedProviderStatus) DeepCopyInto(out *DNSDisallowedProviderStatus) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSDisallowedProviderStatus.
+func (in *DNSDisallowedProviderStatus) DeepCopy() *DNSDisallowedProviderStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DNSDisallowedProviderStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DNSEntriesStatus) DeepCopyInto(out *DNSEntriesStatus) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSEntriesStatus.
+func (in *DNSEntriesStatus) DeepCopy() *DNSEntriesStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DNSEntriesStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DNSIncludeExclude) DeepCopyInto(out *DNSIncludeExclude) {
 	*out = *in
@@ -86,6 +124,22 @@ func (in *DNSIncludeExclude) DeepCopy() *DNSIncludeExclude {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DNSPolicyStatus) DeepCopyInto(out *DNSPolicyStatus) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSPolicyStatus.
+func (in *DNSPolicyStatus) DeepCopy() *DNSPolicyStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DNSPolicyStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DNSProvider) DeepCopyInto(out *DNSProvider) {
 	*out = *in
@@ -104,6 +158,11 @@ func (in *DNSProvider) DeepCopyInto(out *DNSProvider) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.CredentialsBindingName != nil {
+		in, out := &in.CredentialsBindingName, &out.CredentialsBindingName
+		*out = new(string)
+		**out = **in
+	}
 	if in.Type != nil {
 		in, out := &in.Type, &out.Type
 		*out = new(string)
@@ -114,6 +173,21 @@ func (in *DNSProvider) DeepCopyInto(out *DNSProvider) {
 		*out = new(DNSIncludeExclude)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Quotas != nil {
+		in, out := &in.Quotas, &out.Quotas
+		*out = new(DNSProviderQuotas)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RateLimit != nil {
+		in, out := &in.RateLimit, &out.RateLimit
+		*out = new(DNSProviderRateLimit)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ProviderConfig != nil {
+		in, out := &in.ProviderConfig, &out.ProviderConfig
+		*out = new(runtime.RawExtension)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -127,6 +201,64 @@ func (in *DNSProvider) DeepCopy() *DNSProvider {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DNSProviderQuotaUsage) DeepCopyInto(out *DNSProviderQuotaUsage) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSProviderQuotaUsage.
+func (in *DNSProviderQuotaUsage) DeepCopy() *DNSProviderQuotaUsage {
+	if in == nil {
+		return nil
+	}
+	out := new(DNSProviderQuotaUsage)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DNSProviderQuotas) DeepCopyInto(out *DNSProviderQuotas) {
+	*out = *in
+	if in.Entries != nil {
+		in, out := &in.Entries, &out.Entries
+		*out = new(int32)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSProviderQuotas.
+func (in *DNSProviderQuotas) DeepCopy() *DNSProviderQuotas {
+	if in == nil {
+		return nil
+	}
+	out := new(DNSProviderQuotas)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DNSProviderRateLimit) DeepCopyInto(out *DNSProviderRateLimit) {
+	*out = *in
+	if in.Burst != nil {
+		in, out := &in.Burst, &out.Burst
+		*out = new(int32)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSProviderRateLimit.
+func (in *DNSProviderRateLimit) DeepCopy() *DNSProviderRateLimit {
+	if in == nil {
+		return nil
+	}
+	out := new(DNSProviderRateLimit)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DNSProviderReplication) DeepCopyInto(out *DNSProviderReplication) {
 	*out = *in
@@ -142,3 +274,77 @@ func (in *DNSProviderReplication) DeepCopy() *DNSProviderReplication {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DNSProviderStatus) DeepCopyInto(out *DNSProviderStatus) {
+	*out = *in
+	if in.Message != nil {
+		in, out := &in.Message, &out.Message
+		*out = new(string)
+		**out = **in
+	}
+	if in.ErrorCodes != nil {
+		in, out := &in.ErrorCodes, &out.ErrorCodes
+		*out = make([]v1beta1.ErrorCode, len(*in))
+		copy(*out, *in)
+	}
+	if in.EntriesQuota != nil {
+		in, out := &in.EntriesQuota, &out.EntriesQuota
+		*out = new(DNSProviderQuotaUsage)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSProviderStatus.
+func (in *DNSProviderStatus) DeepCopy() *DNSProviderStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DNSProviderStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DNSStatus) DeepCopyInto(out *DNSStatus) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	if in.Providers != nil {
+		in, out := &in.Providers, &out.Providers
+		*out = make([]DNSProviderStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	out.Entries = in.Entries
+	if in.Policy != nil {
+		in, out := &in.Policy, &out.Policy
+		*out = new(DNSPolicyStatus)
+		**out = **in
+	}
+	if in.DisallowedProviders != nil {
+		in, out := &in.DisallowedProviders, &out.DisallowedProviders
+		*out = make([]DNSDisallowedProviderStatus, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSStatus.
+func (in *DNSStatus) DeepCopy() *DNSStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DNSStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DNSStatus) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
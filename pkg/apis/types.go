@@ -17,6 +17,8 @@ import (
 type DNSState struct {
 	metav1.TypeMeta
 	Entries []*DNSEntry
+	// RedirectedEntries contains the original targets of the DNS entries redirected during hibernation.
+	RedirectedEntries []*RedirectedDNSEntry
 }
 
 type DNSEntry struct {
@@ -25,3 +27,9 @@ type DNSEntry struct {
 	Annotations map[string]string
 	Spec        *v1alpha1.DNSEntrySpec
 }
+
+// RedirectedDNSEntry contains the original targets of a DNS entry redirected during hibernation.
+type RedirectedDNSEntry struct {
+	Name    string
+	Targets []string
+}
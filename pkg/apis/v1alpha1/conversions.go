@@ -0,0 +1,17 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha1
+
+import (
+	"k8s.io/apimachinery/pkg/conversion"
+
+	"github.com/gardener/gardener-extension-shoot-dns-service/pkg/apis"
+)
+
+// Convert_apis_DNSState_To_v1alpha1_DNSState converts the internal DNSState to the v1alpha1 version.
+// The redirected entries are dropped, as they are only supported since version v1alpha2.
+func Convert_apis_DNSState_To_v1alpha1_DNSState(in *apis.DNSState, out *DNSState, s conversion.Scope) error {
+	return autoConvert_apis_DNSState_To_v1alpha1_DNSState(in, out, s)
+}
@@ -40,7 +40,7 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*apis.DNSState)(nil), (*DNSState)(nil), func(a, b interface{}, scope conversion.Scope) error {
+	if err := s.AddConversionFunc((*apis.DNSState)(nil), (*DNSState)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_apis_DNSState_To_v1alpha1_DNSState(a.(*apis.DNSState), b.(*DNSState), scope)
 	}); err != nil {
 		return err
@@ -86,10 +86,6 @@ func Convert_v1alpha1_DNSState_To_apis_DNSState(in *DNSState, out *apis.DNSState
 
 func autoConvert_apis_DNSState_To_v1alpha1_DNSState(in *apis.DNSState, out *DNSState, s conversion.Scope) error {
 	out.Entries = *(*[]*DNSEntry)(unsafe.Pointer(&in.Entries))
+	// WARNING: in.RedirectedEntries requires manual conversion: does not exist in peer-type
 	return nil
 }
-
-// Convert_apis_DNSState_To_v1alpha1_DNSState is an autogenerated conversion function.
-func Convert_apis_DNSState_To_v1alpha1_DNSState(in *apis.DNSState, out *DNSState, s conversion.Scope) error {
-	return autoConvert_apis_DNSState_To_v1alpha1_DNSState(in, out, s)
-}
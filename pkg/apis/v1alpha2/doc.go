@@ -0,0 +1,14 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// +k8s:deepcopy-gen=package
+// +k8s:conversion-gen=github.com/gardener/gardener-extension-shoot-dns-service/pkg/apis
+// +k8s:openapi-gen=true
+// +k8s:defaulter-gen=TypeMeta
+
+//go:generate crd-ref-docs --source-path=. --config=../../../hack/api-reference/api.yaml --renderer=markdown --templates-dir=$GARDENER_HACK_DIR/api-reference/template --log-level=ERROR --output-path=../../../hack/api-reference/api-v1alpha2.md
+
+// Package v1alpha2 contains the version v1alpha2 of the DNS state stored in the extension status.
+// +groupName=dns.extensions.gardener.cloud
+package v1alpha2
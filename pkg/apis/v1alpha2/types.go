@@ -0,0 +1,42 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha2
+
+import (
+	"github.com/gardener/external-dns-management/pkg/apis/dns/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// DNSStateKind is the kind of the DNS state stored in the extension status.
+const DNSStateKind = "DNSState"
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// DNSState describes the set of DNS entries maintained by the dns shoot service
+// for a dedicated shoot cluster used to reconstruct the DNS entry objects after
+// a migration.
+// It is the version written by the extension. New fields of the state must only be added to this version
+// (or a later one), so that states written in the v1alpha1 version by older extension versions can still be read.
+type DNSState struct {
+	metav1.TypeMeta `json:",inline"`
+	Entries         []*DNSEntry `json:"entries,omitempty"`
+	// RedirectedEntries contains the original targets of the DNS entries redirected during hibernation.
+	RedirectedEntries []*RedirectedDNSEntry `json:"redirectedEntries,omitempty"`
+}
+
+// DNSEntry contains the relevant parts of a shoot DNS entry to recreate it on restore.
+type DNSEntry struct {
+	Name        string                 `json:"name"`
+	Labels      map[string]string      `json:"labels,omitempty"`
+	Annotations map[string]string      `json:"annotations,omitempty"`
+	Spec        *v1alpha1.DNSEntrySpec `json:"spec"`
+}
+
+// RedirectedDNSEntry contains the original targets of a DNS entry redirected during hibernation.
+type RedirectedDNSEntry struct {
+	Name    string   `json:"name"`
+	Targets []string `json:"targets,omitempty"`
+}
@@ -64,6 +64,17 @@ func (in *DNSState) DeepCopyInto(out *DNSState) {
 			}
 		}
 	}
+	if in.RedirectedEntries != nil {
+		in, out := &in.RedirectedEntries, &out.RedirectedEntries
+		*out = make([]*RedirectedDNSEntry, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(RedirectedDNSEntry)
+				(*in).DeepCopyInto(*out)
+			}
+		}
+	}
 	return
 }
 
@@ -84,3 +95,24 @@ func (in *DNSState) DeepCopyObject() runtime.Object {
 	}
 	return nil
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RedirectedDNSEntry) DeepCopyInto(out *RedirectedDNSEntry) {
+	*out = *in
+	if in.Targets != nil {
+		in, out := &in.Targets, &out.Targets
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RedirectedDNSEntry.
+func (in *RedirectedDNSEntry) DeepCopy() *RedirectedDNSEntry {
+	if in == nil {
+		return nil
+	}
+	out := new(RedirectedDNSEntry)
+	in.DeepCopyInto(out)
+	return out
+}
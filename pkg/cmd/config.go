@@ -0,0 +1,273 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"os"
+	"time"
+
+	healthcheckconfigv1alpha1 "github.com/gardener/gardener/extensions/pkg/apis/config/v1alpha1"
+	"github.com/gardener/gardener/extensions/pkg/util"
+	"github.com/go-logr/logr"
+	"github.com/spf13/pflag"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/rest"
+	componentbaseconfigv1alpha1 "k8s.io/component-base/config/v1alpha1"
+
+	apisconfig "github.com/gardener/gardener-extension-shoot-dns-service/pkg/apis/config"
+	"github.com/gardener/gardener-extension-shoot-dns-service/pkg/apis/config/loader"
+	configvalidation "github.com/gardener/gardener-extension-shoot-dns-service/pkg/apis/config/validation"
+	"github.com/gardener/gardener-extension-shoot-dns-service/pkg/controller/config"
+	"github.com/gardener/gardener-extension-shoot-dns-service/pkg/controller/healthcheck"
+)
+
+// ConfigOptions holds the options for the controller configuration file.
+type ConfigOptions struct {
+	ConfigFilePath       string
+	ConfigReloadInterval time.Duration
+	config               *ControllerConfig
+}
+
+// AddFlags implements Flagger.AddFlags.
+func (o *ConfigOptions) AddFlags(fs *pflag.FlagSet) {
+	fs.StringVar(&o.ConfigFilePath, "config", "", "path to the controller configuration file (settings of the file take precedence over the corresponding command line flags)")
+	fs.DurationVar(&o.ConfigReloadInterval, "config-reload-interval", 30*time.Second, "interval for checking the controller configuration file for changes (0 = no reload)")
+}
+
+// Complete implements Completer.Complete.
+func (o *ConfigOptions) Complete() error {
+	if o.ConfigReloadInterval < 0 {
+		return fmt.Errorf("invalid config-reload-interval: %s (must not be negative)", o.ConfigReloadInterval)
+	}
+	o.config = &ControllerConfig{
+		ConfigFilePath:       o.ConfigFilePath,
+		ConfigReloadInterval: o.ConfigReloadInterval,
+	}
+	if o.ConfigFilePath == "" {
+		return nil
+	}
+	data, err := os.ReadFile(o.ConfigFilePath)
+	if err != nil {
+		return fmt.Errorf("could not read controller configuration file: %w", err)
+	}
+	cfg, err := loadControllerConfiguration(data)
+	if err != nil {
+		return fmt.Errorf("invalid controller configuration file %s: %w", o.ConfigFilePath, err)
+	}
+	o.config.Config = cfg
+	o.config.checksum = sha256.Sum256(data)
+	return nil
+}
+
+// Completed returns the completed ConfigOptions. Only call this if `Complete` was successful.
+func (o *ConfigOptions) Completed() *ControllerConfig {
+	return o.config
+}
+
+func loadControllerConfiguration(data []byte) (*apisconfig.ControllerConfiguration, error) {
+	cfg, err := loader.Load(data)
+	if err != nil {
+		return nil, err
+	}
+	if errs := configvalidation.ValidateConfiguration(cfg); len(errs) > 0 {
+		return nil, errs.ToAggregate()
+	}
+	return cfg, nil
+}
+
+// ControllerConfig contains the loaded controller configuration file.
+type ControllerConfig struct {
+	ConfigFilePath       string
+	ConfigReloadInterval time.Duration
+	// Config is the loaded configuration. It is nil if no configuration file is given.
+	Config   *apisconfig.ControllerConfiguration
+	checksum [sha256.Size]byte
+}
+
+// ApplyClientConnection applies the client connection settings to the passed REST config.
+// Without configuration file, QPS and burst default to 100 and 130.
+func (c *ControllerConfig) ApplyClientConnection(restConfig *rest.Config) {
+	clientConnection := &componentbaseconfigv1alpha1.ClientConnectionConfiguration{
+		QPS:   100.0,
+		Burst: 130,
+	}
+	if c.Config != nil && c.Config.ClientConnection != nil {
+		clientConnection = c.Config.ClientConnection
+	}
+	util.ApplyClientConnectionConfigurationToRESTConfig(clientConnection, restConfig)
+}
+
+// Apply applies the quotas, next generation and timeout settings of the configuration file to the passed
+// DNS service configuration.
+func (c *ControllerConfig) Apply(cfg *config.DNSServiceConfig) {
+	if c.Config == nil {
+		return
+	}
+	applyControllerConfiguration(c.Config, cfg)
+}
+
+// ApplyHealthCheckConfig applies the health check settings of the configuration file to the passed health configuration.
+func (c *ControllerConfig) ApplyHealthCheckConfig(config *healthcheckconfigv1alpha1.HealthCheckConfig) {
+	if c.Config == nil || c.Config.HealthCheckConfig == nil || c.Config.HealthCheckConfig.SyncPeriod.Duration == 0 {
+		return
+	}
+	config.SyncPeriod = c.Config.HealthCheckConfig.SyncPeriod
+}
+
+// ApplyDNSEntriesCheckConfig applies the DNS entries health check settings of the configuration file to the passed
+// DNSEntry health check configuration.
+func (c *ControllerConfig) ApplyDNSEntriesCheckConfig(config *healthcheck.DNSEntriesCheckConfig) {
+	if c.Config == nil || c.Config.DNSEntriesHealthCheck == nil {
+		return
+	}
+	if c.Config.DNSEntriesHealthCheck.FailureThreshold != nil {
+		config.FailureThreshold = int(*c.Config.DNSEntriesHealthCheck.FailureThreshold)
+	}
+	if c.Config.DNSEntriesHealthCheck.MaxReportedFailures != nil {
+		config.MaxReportedFailures = int(*c.Config.DNSEntriesHealthCheck.MaxReportedFailures)
+	}
+}
+
+// NewReloader returns a runnable reloading the configuration file on changes, or nil if there is nothing to reload.
+// The settings of a reloaded file are applied to the given DNS service configuration of the command line flags.
+func (c *ControllerConfig) NewReloader(log logr.Logger, base config.DNSServiceConfig) *ConfigReloader {
+	if c.Config == nil || c.ConfigReloadInterval == 0 {
+		return nil
+	}
+	return &ConfigReloader{
+		log:      log.WithName("config-reloader"),
+		path:     c.ConfigFilePath,
+		interval: c.ConfigReloadInterval,
+		base:     base,
+		current:  c.Config,
+		checksum: c.checksum,
+	}
+}
+
+// applyControllerConfiguration applies the settings explicitly set in the configuration file, so that the values of
+// the command line flags are kept for all other settings.
+func applyControllerConfiguration(c *apisconfig.ControllerConfiguration, cfg *config.DNSServiceConfig) {
+	cfg.ApplyQuotaConfiguration(c.Quotas)
+	if ng := c.NextGeneration; ng != nil {
+		setIfNotNil(&cfg.UseNextGenerationController, ng.Enabled)
+		if ng.ZoneNameservers != nil {
+			cfg.NextGenerationControllerZoneNameservers = ng.ZoneNameservers
+		}
+	}
+	if t := c.Timeouts; t != nil {
+		setDurationIfNotNil(&cfg.Timeouts.ManagedResourceDeletion, t.ManagedResourceDeletion)
+		setDurationIfNotNil(&cfg.Timeouts.DNSProviderReady, t.DNSProviderReady)
+		setDurationIfNotNil(&cfg.Timeouts.DNSProviderDeletion, t.DNSProviderDeletion)
+		setDurationIfNotNil(&cfg.Timeouts.EntryReconciliation, t.EntryReconciliation)
+		setDurationIfNotNil(&cfg.Timeouts.EntryReconciliationMax, t.EntryReconciliationMax)
+	}
+}
+
+// reloadableSettings returns the reloadable settings of the configuration file applied to the given configuration
+// of the command line flags.
+func reloadableSettings(base config.DNSServiceConfig, c *apisconfig.ControllerConfiguration) config.ReloadableSettings {
+	cfg := base
+	applyControllerConfiguration(c, &cfg)
+	return config.ReloadableSettings{
+		DefaultExternalProviderEntriesQuota:      cfg.DefaultExternalProviderEntriesQuota,
+		DefaultExternalProviderEntriesQuotaMax:   cfg.DefaultExternalProviderEntriesQuotaMax,
+		DefaultExternalProviderEntriesQuotaRules: cfg.DefaultExternalProviderEntriesQuotaRules,
+		ProviderEntriesQuotaMax:                  cfg.ProviderEntriesQuotaMax,
+		ProviderRequestsPerDayMax:                cfg.ProviderRequestsPerDayMax,
+		ProviderBurstMax:                         cfg.ProviderBurstMax,
+		NextGenerationControllerZoneNameservers:  cfg.NextGenerationControllerZoneNameservers,
+		Timeouts:                                 cfg.Timeouts,
+	}
+}
+
+// ConfigReloader periodically checks the controller configuration file for changes. The quotas, the zone nameservers
+// and the timeouts of a changed file are applied on the next reconciliation of the extensions. Changes of the other
+// settings are only logged, as they need a restart to take effect.
+type ConfigReloader struct {
+	log      logr.Logger
+	path     string
+	interval time.Duration
+	base     config.DNSServiceConfig
+	current  *apisconfig.ControllerConfiguration
+	checksum [sha256.Size]byte
+}
+
+// NeedLeaderElection implements manager.LeaderElectionRunnable.
+func (r *ConfigReloader) NeedLeaderElection() bool {
+	return false
+}
+
+// Start implements manager.Runnable.
+func (r *ConfigReloader) Start(ctx context.Context) error {
+	wait.UntilWithContext(ctx, func(_ context.Context) { r.reload() }, r.interval)
+	return nil
+}
+
+func (r *ConfigReloader) reload() {
+	data, err := os.ReadFile(r.path)
+	if err != nil {
+		r.log.Error(err, "Could not read controller configuration file", "path", r.path)
+		return
+	}
+	checksum := sha256.Sum256(data)
+	if checksum == r.checksum {
+		return
+	}
+	// remember the checksum also for invalid files to report them only once
+	r.checksum = checksum
+
+	cfg, err := loadControllerConfiguration(data)
+	if err != nil {
+		r.log.Error(err, "Ignoring invalid controller configuration file", "path", r.path)
+		return
+	}
+	if changed := restartRequiredChanges(r.current, cfg); len(changed) > 0 {
+		r.log.Info("Changed settings of controller configuration file need a restart to take effect", "settings", changed)
+	}
+	config.SetReloadedSettings(reloadableSettings(r.base, cfg))
+	r.current = cfg
+	r.log.Info("Reloaded controller configuration file", "path", r.path)
+}
+
+func restartRequiredChanges(oldCfg, newCfg *apisconfig.ControllerConfiguration) []string {
+	var changed []string
+	if !equality.Semantic.DeepEqual(oldCfg.ClientConnection, newCfg.ClientConnection) {
+		changed = append(changed, "clientConnection")
+	}
+	if !equality.Semantic.DeepEqual(nextGenerationEnabled(oldCfg), nextGenerationEnabled(newCfg)) {
+		changed = append(changed, "nextGeneration.enabled")
+	}
+	if !equality.Semantic.DeepEqual(oldCfg.HealthCheckConfig, newCfg.HealthCheckConfig) {
+		changed = append(changed, "healthCheckConfig")
+	}
+	if !equality.Semantic.DeepEqual(oldCfg.DNSEntriesHealthCheck, newCfg.DNSEntriesHealthCheck) {
+		changed = append(changed, "dnsEntriesHealthCheck")
+	}
+	return changed
+}
+
+func nextGenerationEnabled(c *apisconfig.ControllerConfiguration) *bool {
+	if c.NextGeneration == nil {
+		return nil
+	}
+	return c.NextGeneration.Enabled
+}
+
+func setIfNotNil[T any](field *T, value *T) {
+	if value != nil {
+		*field = *value
+	}
+}
+
+func setDurationIfNotNil(field *time.Duration, value *metav1.Duration) {
+	if value != nil {
+		*field = value.Duration
+	}
+}
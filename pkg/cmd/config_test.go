@@ -0,0 +1,195 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"time"
+
+	healthcheckconfigv1alpha1 "github.com/gardener/gardener/extensions/pkg/apis/config/v1alpha1"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/gardener/gardener-extension-shoot-dns-service/pkg/controller/config"
+	"github.com/gardener/gardener-extension-shoot-dns-service/pkg/controller/healthcheck"
+)
+
+var _ = Describe("Config", func() {
+	const header = `apiVersion: shootdnsservice.extensions.config.gardener.cloud/v1alpha1
+kind: ControllerConfiguration
+`
+
+	var (
+		path string
+
+		// flagConfig is the DNS service configuration set by the command line flags
+		flagConfig config.DNSServiceConfig
+
+		complete = func(content string) *ControllerConfig {
+			GinkgoHelper()
+			Expect(os.WriteFile(path, []byte(header+content), 0600)).To(Succeed())
+			opts := &ConfigOptions{ConfigFilePath: path, ConfigReloadInterval: time.Second}
+			Expect(opts.Complete()).To(Succeed())
+			return opts.Completed()
+		}
+	)
+
+	BeforeEach(func() {
+		path = filepath.Join(GinkgoT().TempDir(), "config.yaml")
+		flagConfig = config.DNSServiceConfig{
+			DefaultExternalProviderEntriesQuota:    100,
+			DefaultExternalProviderEntriesQuotaMax: 500,
+			ProviderEntriesQuotaMax:                1000,
+			ProviderRequestsPerDayMax:              20000,
+			ProviderBurstMax:                       50,
+			UseNextGenerationController:            true,
+			NextGenerationControllerZoneNameservers: map[string]string{
+				"example.com": "ns1.example.com",
+			},
+		}
+	})
+
+	Describe("#Apply", func() {
+		It("should keep the flags without configuration file", func() {
+			opts := &ConfigOptions{}
+			Expect(opts.Complete()).To(Succeed())
+
+			cfg := flagConfig
+			opts.Completed().Apply(&cfg)
+			Expect(cfg).To(Equal(flagConfig))
+		})
+
+		It("should keep the flags for an empty configuration file", func() {
+			cfg := flagConfig
+			complete("").Apply(&cfg)
+			Expect(cfg).To(Equal(flagConfig))
+		})
+
+		It("should keep the flags for empty sections", func() {
+			cfg := flagConfig
+			complete("quotas: {}\nnextGeneration: {}\ntimeouts: {}\n").Apply(&cfg)
+			Expect(cfg).To(Equal(flagConfig))
+		})
+
+		It("should only overwrite the flags set in the configuration file", func() {
+			cfg := flagConfig
+			complete(`quotas:
+  defaultExternalProviderEntries: 200
+  providerBurstMax: 0
+  defaultExternalProviderEntriesRules:
+  - name: production
+    purposes: [production]
+    defaultExternalProviderEntries: 1000
+nextGeneration:
+  enabled: false
+timeouts:
+  dnsProviderReady: 5m
+`).Apply(&cfg)
+
+			expected := flagConfig
+			expected.DefaultExternalProviderEntriesQuota = 200
+			expected.ProviderBurstMax = 0
+			expected.UseNextGenerationController = false
+			expected.Timeouts.DNSProviderReady = 5 * time.Minute
+			Expect(cfg.DefaultExternalProviderEntriesQuotaRules).To(ConsistOf(And(
+				HaveField("Name", "production"),
+				HaveField("Quota", int32(1000)),
+				HaveField("QuotaMax", int32(0)),
+			)))
+			cfg.DefaultExternalProviderEntriesQuotaRules = nil
+			Expect(cfg).To(Equal(expected))
+		})
+
+		It("should overwrite the zone nameservers", func() {
+			cfg := flagConfig
+			complete("nextGeneration:\n  zoneNameservers:\n    example.org: ns1.example.org\n").Apply(&cfg)
+			Expect(cfg.NextGenerationControllerZoneNameservers).To(Equal(map[string]string{"example.org": "ns1.example.org"}))
+			Expect(cfg.UseNextGenerationController).To(BeTrue())
+		})
+	})
+
+	Describe("#ApplyHealthCheckConfig", func() {
+		var healthCheckConfig healthcheckconfigv1alpha1.HealthCheckConfig
+
+		BeforeEach(func() {
+			healthCheckConfig = healthcheckconfigv1alpha1.HealthCheckConfig{SyncPeriod: metav1.Duration{Duration: time.Minute}}
+		})
+
+		It("should keep the flag if not set in the configuration file", func() {
+			complete("dnsEntriesHealthCheck:\n  failureThreshold: 3\n").ApplyHealthCheckConfig(&healthCheckConfig)
+			Expect(healthCheckConfig.SyncPeriod.Duration).To(Equal(time.Minute))
+		})
+
+		It("should overwrite the flag if set in the configuration file", func() {
+			complete("healthCheckConfig:\n  syncPeriod: 2m\n").ApplyHealthCheckConfig(&healthCheckConfig)
+			Expect(healthCheckConfig.SyncPeriod.Duration).To(Equal(2 * time.Minute))
+		})
+	})
+
+	Describe("#ApplyDNSEntriesCheckConfig", func() {
+		var checkConfig healthcheck.DNSEntriesCheckConfig
+
+		BeforeEach(func() {
+			checkConfig = healthcheck.DNSEntriesCheckConfig{FailureThreshold: 2, MaxReportedFailures: 10}
+		})
+
+		It("should keep the flags if not set in the configuration file", func() {
+			complete("healthCheckConfig:\n  syncPeriod: 2m\n").ApplyDNSEntriesCheckConfig(&checkConfig)
+			Expect(checkConfig).To(Equal(healthcheck.DNSEntriesCheckConfig{FailureThreshold: 2, MaxReportedFailures: 10}))
+
+			complete("dnsEntriesHealthCheck: {}\n").ApplyDNSEntriesCheckConfig(&checkConfig)
+			Expect(checkConfig).To(Equal(healthcheck.DNSEntriesCheckConfig{FailureThreshold: 2, MaxReportedFailures: 10}))
+		})
+
+		It("should only overwrite the flags set in the configuration file", func() {
+			complete("dnsEntriesHealthCheck:\n  failureThreshold: 0\n").ApplyDNSEntriesCheckConfig(&checkConfig)
+			Expect(checkConfig).To(Equal(healthcheck.DNSEntriesCheckConfig{FailureThreshold: 0, MaxReportedFailures: 10}))
+		})
+	})
+
+	Describe("#ConfigReloader", func() {
+		It("should not reload without configuration file or reload interval", func() {
+			opts := &ConfigOptions{}
+			Expect(opts.Complete()).To(Succeed())
+			Expect(opts.Completed().NewReloader(GinkgoLogr, flagConfig)).To(BeNil())
+
+			controllerConfig := complete("")
+			controllerConfig.ConfigReloadInterval = 0
+			Expect(controllerConfig.NewReloader(GinkgoLogr, flagConfig)).To(BeNil())
+		})
+
+		It("should apply the reloaded settings to the flags", func() {
+			reloader := complete("quotas:\n  defaultExternalProviderEntries: 200\n").NewReloader(GinkgoLogr, flagConfig)
+			Expect(reloader).NotTo(BeNil())
+
+			Expect(os.WriteFile(path, []byte(header+`quotas:
+  providerEntriesMax: 2000
+timeouts:
+  entryReconciliation: 1m
+`), 0600)).To(Succeed())
+			reloader.reload()
+
+			cfg := config.WithReloadedSettings(flagConfig)
+			expected := flagConfig
+			// the quota removed from the file falls back to the flag
+			expected.ProviderEntriesQuotaMax = 2000
+			expected.Timeouts.EntryReconciliation = time.Minute
+			Expect(cfg).To(Equal(expected))
+		})
+
+		It("should keep the last valid settings for an invalid file", func() {
+			reloader := complete("").NewReloader(GinkgoLogr, flagConfig)
+			Expect(os.WriteFile(path, []byte(header+"quotas:\n  providerBurstMax: 10\n"), 0600)).To(Succeed())
+			reloader.reload()
+			Expect(config.WithReloadedSettings(flagConfig).ProviderBurstMax).To(BeEquivalentTo(10))
+
+			Expect(os.WriteFile(path, []byte(header+"quotas:\n  providerBurstMax: -1\n"), 0600)).To(Succeed())
+			reloader.reload()
+			Expect(config.WithReloadedSettings(flagConfig).ProviderBurstMax).To(BeEquivalentTo(10))
+		})
+	})
+})
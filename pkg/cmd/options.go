@@ -6,6 +6,7 @@ package cmd
 
 import (
 	"fmt"
+	"os"
 	"strings"
 	"time"
 
@@ -19,9 +20,12 @@ import (
 	"k8s.io/apimachinery/pkg/types"
 
 	admissioncmd "github.com/gardener/gardener-extension-shoot-dns-service/pkg/admission/cmd"
+	"github.com/gardener/gardener-extension-shoot-dns-service/pkg/apis/service/validation"
+	"github.com/gardener/gardener-extension-shoot-dns-service/pkg/controller/common"
 	"github.com/gardener/gardener-extension-shoot-dns-service/pkg/controller/config"
 	"github.com/gardener/gardener-extension-shoot-dns-service/pkg/controller/healthcheck"
 	"github.com/gardener/gardener-extension-shoot-dns-service/pkg/controller/lifecycle"
+	"github.com/gardener/gardener-extension-shoot-dns-service/pkg/controller/orphans"
 )
 
 // DNSServiceOptions holds options related to the dns service.
@@ -33,16 +37,36 @@ type DNSServiceOptions struct {
 	RemoteDefaultDomainSecret               string
 	DefaultExternalProviderEntriesQuota     int32
 	DefaultExternalProviderEntriesQuotaMax  int32
+	ProviderEntriesQuotaMax                 int32
+	ProviderRequestsPerDayMax               int32
+	ProviderBurstMax                        int32
 	GCPWorkloadIdentityOptions              admissioncmd.GCPWorkloadIdentityOptions
+	ProviderTypesOptions                    admissioncmd.ProviderTypesOptions
 	NextGenerationControllerZoneNameservers []string
 	UseNextGenerationController             bool
+	HibernationRedirectTargets              []string
+	LeakedRecordsNamespace                  string
+	OrphanGCInterval                        time.Duration
+	OrphanGCGracePeriod                     time.Duration
+	OrphanGCDelete                          bool
+	WriteStateEnvelope                      bool
 	config                                  *DNSServiceConfig
 }
 
 // HealthOptions holds options for health checks.
 type HealthOptions struct {
-	HealthCheckSyncPeriod time.Duration
-	config                *HealthConfig
+	HealthCheckSyncPeriod         time.Duration
+	DNSEntriesFailureThreshold    int
+	DNSEntriesMaxReportedFailures int
+	config                        *HealthConfig
+}
+
+// LifecycleOptions holds options for the reconciliation triggers of the lifecycle controller.
+type LifecycleOptions struct {
+	ResyncPeriod       time.Duration
+	ResyncJitterFactor float64
+	WatchDebounce      time.Duration
+	config             *LifecycleConfig
 }
 
 // AddFlags implements Flagger.AddFlags.
@@ -54,18 +78,46 @@ func (o *DNSServiceOptions) AddFlags(fs *pflag.FlagSet) {
 	fs.StringVar(&o.RemoteDefaultDomainSecret, "remote-default-domain-secret", "", "secret name for default 'external' DNSProvider DNS class used to filter DNS source resources in shoot clusters")
 	fs.Int32Var(&o.DefaultExternalProviderEntriesQuota, "default-external-provider-entries-quota", 0,
 		"DNS entries quota for the 'external' provider when using the default domain (0 = unlimited). "+
-			"Shoots can override this via annotation within limits set by --default-external-provider-entries-quota-max")
+			"Shoots can lower this via annotation, and raise it within limits set by --default-external-provider-entries-quota-max if verified by the admission webhook")
 	fs.Int32Var(&o.DefaultExternalProviderEntriesQuotaMax, "default-external-provider-entries-quota-max", 0,
 		"maximum allowed quota when shoots override via annotation 'service.dns.extensions.gardener.cloud/default-external-provider-entries-quota'. "+
 			"0 means the default quota is also the maximum (default). Prevents accidentally setting unreasonably high quotas.")
+	fs.Int32Var(&o.ProviderEntriesQuotaMax, "provider-entries-quota-max", 0,
+		"maximum DNS entries quota for additional providers given in the extension providerConfig (0 = unlimited). "+
+			"If set, it is also applied to providers without explicit quota.")
+	fs.Int32Var(&o.ProviderRequestsPerDayMax, "provider-ratelimit-requests-per-day-max", 0,
+		"maximum rate limit in requests per day for additional providers given in the extension providerConfig (0 = unlimited). "+
+			"If set, it is also applied to providers without explicit rate limit.")
+	fs.Int32Var(&o.ProviderBurstMax, "provider-ratelimit-burst-max", 0,
+		"maximum rate limit burst for additional providers given in the extension providerConfig (0 = unlimited)")
 	fs.StringSliceVar(&o.NextGenerationControllerZoneNameservers, "nextgen-zone-to-nameserver", nil, "static mapping from zone to nameserver (for testing), can be specified multiple times, e.g. --nextgen-zone-to-nameserver=example.com=ns1.example.com --nextgen-zone-to-nameserver=example.org=ns1.example.org")
 	fs.BoolVar(&o.UseNextGenerationController, "use-next-generation-controller", false, "enables deployment of the next-generation controller for all shoots (can still be disabled per shoot via extension providerConfig `useNextGenerationController: false`)")
+	fs.StringSliceVar(&o.HibernationRedirectTargets, "hibernation-redirect-target", nil, "target (IP address or domain name) the DNS entries of hibernated shoots are redirected to, if the DNSConfig specifies `hibernation: redirect`, can be specified multiple times")
+	fs.StringVar(&o.LeakedRecordsNamespace, "leaked-records-namespace", os.Getenv("LEADER_ELECTION_NAMESPACE"), "namespace of the inventory of DNS records possibly leaked by forced deletion of shoots (defaults to the namespace of the extension, no inventory if empty)")
+	fs.DurationVar(&o.OrphanGCInterval, "orphan-gc-interval", 0, "interval of the garbage collector for DNS records of shoots without Cluster resource in the seed (0 = disabled)")
+	fs.DurationVar(&o.OrphanGCGracePeriod, "orphan-gc-grace-period", time.Hour, "minimum age of DNS entries and inventories of leaked records to be considered as orphaned by the garbage collector")
+	fs.BoolVar(&o.OrphanGCDelete, "orphan-gc-delete", false, "enables the deletion of orphaned DNS records by the garbage collector (otherwise they are only reported)")
+	fs.BoolVar(&o.WriteStateEnvelope, "write-state-envelope", false, "enables writing the extension state as versioned envelope (must only be enabled if all seeds run a version able to read it)")
 	o.GCPWorkloadIdentityOptions.AddFlags(fs)
+	o.ProviderTypesOptions.AddFlags(fs)
 }
 
 // AddFlags implements Flagger.AddFlags.
 func (o *HealthOptions) AddFlags(fs *pflag.FlagSet) {
 	fs.DurationVar(&o.HealthCheckSyncPeriod, "healthcheck-sync-period", time.Second*30, "sync period for the health check controller")
+	fs.IntVar(&o.DNSEntriesFailureThreshold, "healthcheck-dns-entries-failure-threshold", 0,
+		"number of DNS entries in Error, Invalid or Stale state from which on the health check reports an unhealthy condition (0 = disabled)")
+	fs.IntVar(&o.DNSEntriesMaxReportedFailures, "healthcheck-dns-entries-max-reported-failures", 5,
+		"maximum number of failing DNS names listed in the health check condition message")
+}
+
+// AddFlags implements Flagger.AddFlags.
+func (o *LifecycleOptions) AddFlags(fs *pflag.FlagSet) {
+	fs.DurationVar(&o.ResyncPeriod, "lifecycle-resync-period", lifecycle.DefaultResyncPeriod, "period of the periodic reconciliation of the extension resources (0 = disabled)")
+	fs.Float64Var(&o.ResyncJitterFactor, "lifecycle-resync-jitter-factor", lifecycle.DefaultResyncJitterFactor,
+		"maximum factor the resync period is randomly extended by to spread the reconciliations of the extension resources")
+	fs.DurationVar(&o.WatchDebounce, "lifecycle-watch-debounce", lifecycle.DefaultWatchDebounce,
+		"delay for requeuing an extension resource on changes of its DNS providers, DNS entries or secrets, multiple changes within this delay are handled by a single reconciliation")
 }
 
 // Complete implements Completer.Complete.
@@ -90,6 +142,11 @@ func (o *DNSServiceOptions) Complete() error {
 		return err
 	}
 
+	providerTypeRestrictions, err := o.ProviderTypesOptions.Build()
+	if err != nil {
+		return err
+	}
+
 	zoneNameservers := make(map[string]string)
 	for _, mapping := range o.NextGenerationControllerZoneNameservers {
 		parts := strings.Split(mapping, "=")
@@ -102,6 +159,16 @@ func (o *DNSServiceOptions) Complete() error {
 		zoneNameservers[parts[0]] = parts[1]
 	}
 
+	if o.OrphanGCInterval < 0 || o.OrphanGCGracePeriod < 0 {
+		return fmt.Errorf("orphan-gc-interval and orphan-gc-grace-period must not be negative")
+	}
+
+	for _, target := range o.HibernationRedirectTargets {
+		if strings.TrimSpace(target) == "" {
+			return fmt.Errorf("hibernation-redirect-target cannot be empty")
+		}
+	}
+
 	o.config = &DNSServiceConfig{
 		SeedID:                                  o.SeedID,
 		DNSClass:                                o.DNSClass,
@@ -110,16 +177,54 @@ func (o *DNSServiceOptions) Complete() error {
 		RemoteDefaultDomainSecret:               remoteDefaultDomainSecret,
 		DefaultExternalProviderEntriesQuota:     o.DefaultExternalProviderEntriesQuota,
 		DefaultExternalProviderEntriesQuotaMax:  o.DefaultExternalProviderEntriesQuotaMax,
+		ProviderEntriesQuotaMax:                 o.ProviderEntriesQuotaMax,
+		ProviderRequestsPerDayMax:               o.ProviderRequestsPerDayMax,
+		ProviderBurstMax:                        o.ProviderBurstMax,
 		InternalGCPWorkloadIdentityConfig:       *gcpGCPWorkloadIdentityConfig,
+		ProviderTypeRestrictions:                providerTypeRestrictions,
 		NextGenerationControllerZoneNameservers: zoneNameservers,
 		UseNextGenerationController:             o.UseNextGenerationController,
+		HibernationRedirectTargets:              o.HibernationRedirectTargets,
+		LeakedRecordsNamespace:                  o.LeakedRecordsNamespace,
+		OrphanGC: config.OrphanGCConfig{
+			Interval:    o.OrphanGCInterval,
+			GracePeriod: o.OrphanGCGracePeriod,
+			Delete:      o.OrphanGCDelete,
+		},
+		WriteStateEnvelope: o.WriteStateEnvelope,
 	}
 	return nil
 }
 
 // Complete implements Completer.Complete.
 func (o *HealthOptions) Complete() error {
-	o.config = &HealthConfig{HealthCheckSyncPeriod: metav1.Duration{Duration: o.HealthCheckSyncPeriod}}
+	if o.DNSEntriesFailureThreshold < 0 {
+		return fmt.Errorf("invalid healthcheck-dns-entries-failure-threshold: %d (must not be negative)", o.DNSEntriesFailureThreshold)
+	}
+	if o.DNSEntriesMaxReportedFailures < 1 {
+		return fmt.Errorf("invalid healthcheck-dns-entries-max-reported-failures: %d (must be positive)", o.DNSEntriesMaxReportedFailures)
+	}
+	o.config = &HealthConfig{
+		HealthCheckSyncPeriod:         metav1.Duration{Duration: o.HealthCheckSyncPeriod},
+		DNSEntriesFailureThreshold:    o.DNSEntriesFailureThreshold,
+		DNSEntriesMaxReportedFailures: o.DNSEntriesMaxReportedFailures,
+	}
+	return nil
+}
+
+// Complete implements Completer.Complete.
+func (o *LifecycleOptions) Complete() error {
+	if o.ResyncPeriod < 0 || o.WatchDebounce < 0 {
+		return fmt.Errorf("lifecycle-resync-period and lifecycle-watch-debounce must not be negative")
+	}
+	if o.ResyncJitterFactor < 0 {
+		return fmt.Errorf("invalid lifecycle-resync-jitter-factor: %f (must not be negative)", o.ResyncJitterFactor)
+	}
+	o.config = &LifecycleConfig{
+		ResyncPeriod:       o.ResyncPeriod,
+		ResyncJitterFactor: o.ResyncJitterFactor,
+		WatchDebounce:      o.WatchDebounce,
+	}
 	return nil
 }
 
@@ -133,6 +238,11 @@ func (o *HealthOptions) Completed() *HealthConfig {
 	return o.config
 }
 
+// Completed returns the completed LifecycleOptions. Only call this if `Complete` was successful.
+func (o *LifecycleOptions) Completed() *LifecycleConfig {
+	return o.config
+}
+
 // DNSServiceConfig contains configuration information about the dns service.
 type DNSServiceConfig struct {
 	SeedID                                  string
@@ -142,9 +252,17 @@ type DNSServiceConfig struct {
 	RemoteDefaultDomainSecret               *types.NamespacedName
 	DefaultExternalProviderEntriesQuota     int32
 	DefaultExternalProviderEntriesQuotaMax  int32
+	ProviderEntriesQuotaMax                 int32
+	ProviderRequestsPerDayMax               int32
+	ProviderBurstMax                        int32
 	InternalGCPWorkloadIdentityConfig       dnsman2apisconfig.InternalGCPWorkloadIdentityConfig
+	ProviderTypeRestrictions                *validation.ProviderTypeRestrictions
 	NextGenerationControllerZoneNameservers map[string]string
 	UseNextGenerationController             bool
+	HibernationRedirectTargets              []string
+	LeakedRecordsNamespace                  string
+	OrphanGC                                config.OrphanGCConfig
+	WriteStateEnvelope                      bool
 }
 
 // Apply applies the DNSServiceOptions to the passed ControllerOptions instance.
@@ -156,14 +274,24 @@ func (c *DNSServiceConfig) Apply(cfg *config.DNSServiceConfig) {
 	cfg.RemoteDefaultDomainSecret = c.RemoteDefaultDomainSecret
 	cfg.DefaultExternalProviderEntriesQuota = c.DefaultExternalProviderEntriesQuota
 	cfg.DefaultExternalProviderEntriesQuotaMax = c.DefaultExternalProviderEntriesQuotaMax
+	cfg.ProviderEntriesQuotaMax = c.ProviderEntriesQuotaMax
+	cfg.ProviderRequestsPerDayMax = c.ProviderRequestsPerDayMax
+	cfg.ProviderBurstMax = c.ProviderBurstMax
 	cfg.InternalGCPWorkloadIdentityConfig = c.InternalGCPWorkloadIdentityConfig
+	cfg.ProviderTypeRestrictions = c.ProviderTypeRestrictions
 	cfg.NextGenerationControllerZoneNameservers = c.NextGenerationControllerZoneNameservers
 	cfg.UseNextGenerationController = c.UseNextGenerationController
+	cfg.HibernationRedirectTargets = c.HibernationRedirectTargets
+	cfg.LeakedRecordsNamespace = c.LeakedRecordsNamespace
+	cfg.OrphanGC = c.OrphanGC
+	common.WriteStateEnvelope = c.WriteStateEnvelope
 }
 
 // HealthConfig contains configuration information about the health check controller.
 type HealthConfig struct {
-	HealthCheckSyncPeriod metav1.Duration
+	HealthCheckSyncPeriod         metav1.Duration
+	DNSEntriesFailureThreshold    int
+	DNSEntriesMaxReportedFailures int
 }
 
 // ApplyHealthCheckConfig applies the `HealthConfig` to the passed health configurtaion.
@@ -171,11 +299,32 @@ func (c *HealthConfig) ApplyHealthCheckConfig(config *healthcheckconfigv1alpha1.
 	config.SyncPeriod = c.HealthCheckSyncPeriod
 }
 
+// ApplyDNSEntriesCheckConfig applies the `HealthConfig` to the passed DNSEntry health check configuration.
+func (c *HealthConfig) ApplyDNSEntriesCheckConfig(config *healthcheck.DNSEntriesCheckConfig) {
+	config.FailureThreshold = c.DNSEntriesFailureThreshold
+	config.MaxReportedFailures = c.DNSEntriesMaxReportedFailures
+}
+
+// LifecycleConfig contains the configuration of the reconciliation triggers of the lifecycle controller.
+type LifecycleConfig struct {
+	ResyncPeriod       time.Duration
+	ResyncJitterFactor float64
+	WatchDebounce      time.Duration
+}
+
+// Apply applies the `LifecycleConfig` to the passed lifecycle controller options.
+func (c *LifecycleConfig) Apply(opts *lifecycle.AddOptions) {
+	opts.ResyncPeriod = c.ResyncPeriod
+	opts.ResyncJitterFactor = c.ResyncJitterFactor
+	opts.WatchDebounce = c.WatchDebounce
+}
+
 // ControllerSwitches are the cmd.ControllerSwitches for the provider controllers.
 func ControllerSwitches() *cmd.SwitchOptions {
 	return cmd.NewSwitchOptions(
 		cmd.Switch(lifecycle.Name, lifecycle.AddToManager),
 		cmd.Switch(extensionshealthcheckcontroller.ControllerName, healthcheck.RegisterHealthChecks),
 		cmd.Switch(extensionsheartbeatcontroller.ControllerName, extensionsheartbeatcontroller.AddToManager),
+		cmd.Switch(orphans.Name, orphans.AddToManager),
 	)
 }
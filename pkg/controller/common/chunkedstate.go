@@ -0,0 +1,204 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package common
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	extapi "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
+	autoscalingv1 "k8s.io/api/autoscaling/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+const (
+	// StateChunkLabel is the label key for secrets containing a chunk of the extension state.
+	StateChunkLabel = "service.dns.extensions.gardener.cloud/state-chunk"
+
+	stateChunkResourcePrefix = "dns-state-chunk-"
+	stateChunkSecretPrefix   = "extension-shoot-dns-service-state-"
+	stateChunkDataKey        = "chunk"
+)
+
+var (
+	// MaxInlineStateSize is the maximum size of the encoded state stored inline in the extension status.
+	// If WriteStateEnvelope is enabled, larger states are split into chunks stored in secrets, which are referenced in
+	// the status resources of the extension and therefore migrated together with the state.
+	MaxInlineStateSize = 256 * 1024
+	// StateChunkSize is the maximum size of a single state chunk.
+	StateChunkSize = 512 * 1024
+)
+
+type chunkedEntriesState struct {
+	ChunkedState chunkedStateIndex `json:"chunkedState"`
+}
+
+type chunkedStateIndex struct {
+	// Size is the size of the reassembled encoded state.
+	Size int `json:"size"`
+	// Checksum is the SHA256 checksum of the reassembled encoded state.
+	Checksum string `json:"checksum"`
+	// Chunks is the ordered list of chunks.
+	Chunks []stateChunk `json:"chunks"`
+}
+
+type stateChunk struct {
+	// Name is the name of the resource reference in the status resources of the extension.
+	Name string `json:"name"`
+	// Checksum is the SHA256 checksum of the chunk data.
+	Checksum string `json:"checksum"`
+}
+
+// reassembleChunkedEntriesState reads the chunks referenced in the given chunked state and returns the
+// reassembled encoded state after verifying the checksums.
+func reassembleChunkedEntriesState(ctx context.Context, reader client.Reader, ext *extapi.Extension, data []byte) ([]byte, error) {
+	var chunked chunkedEntriesState
+	if err := json.Unmarshal(data, &chunked); err != nil {
+		return nil, fmt.Errorf("failed unmarshalling JSON to chunked entries state structure: %w", err)
+	}
+	index := chunked.ChunkedState
+
+	result := make([]byte, 0, index.Size)
+	for i, chunk := range index.Chunks {
+		ref := findResourceReference(ext.Status.Resources, chunk.Name)
+		if ref == nil {
+			return nil, fmt.Errorf("missing resource reference %q for state chunk %d", chunk.Name, i)
+		}
+		secret := &corev1.Secret{}
+		if err := reader.Get(ctx, client.ObjectKey{Namespace: ext.Namespace, Name: ref.ResourceRef.Name}, secret); err != nil {
+			return nil, fmt.Errorf("failed reading secret %q for state chunk %d: %w", ref.ResourceRef.Name, i, err)
+		}
+		chunkData := secret.Data[stateChunkDataKey]
+		if checksum := computeChecksum(chunkData); checksum != chunk.Checksum {
+			return nil, fmt.Errorf("checksum mismatch for state chunk %d in secret %q: expected %s, got %s", i, secret.Name, chunk.Checksum, checksum)
+		}
+		result = append(result, chunkData...)
+	}
+
+	if len(result) != index.Size {
+		return nil, fmt.Errorf("size mismatch of reassembled state: expected %d, got %d", index.Size, len(result))
+	}
+	if checksum := computeChecksum(result); checksum != index.Checksum {
+		return nil, fmt.Errorf("checksum mismatch of reassembled state: expected %s, got %s", index.Checksum, checksum)
+	}
+	return result, nil
+}
+
+// storeChunkedEntriesState writes the encoded state into chunk secrets if it exceeds MaxInlineStateSize.
+// It returns the data to be stored in the extension status and the status resources referencing the chunks.
+// The chunk secret names contain the checksum of the state, so that the chunks referenced by the current
+// extension status are never overwritten.
+// Like the state envelope, chunked states cannot be read by older versions of the extension, so that a control plane
+// migration or a rollback to such a version would lose the DNS entries. Therefore, states are only chunked if
+// WriteStateEnvelope is enabled.
+func storeChunkedEntriesState(ctx context.Context, c client.Client, ext *extapi.Extension, encoded []byte) ([]byte, []gardencorev1beta1.NamedResourceReference, error) {
+	resources := withoutStateChunkReferences(ext.Status.Resources)
+	if !WriteStateEnvelope || len(encoded) <= MaxInlineStateSize {
+		return encoded, resources, nil
+	}
+
+	checksum := computeChecksum(encoded)
+	index := chunkedStateIndex{
+		Size:     len(encoded),
+		Checksum: checksum,
+	}
+	for i := 0; i*StateChunkSize < len(encoded); i++ {
+		chunkData := encoded[i*StateChunkSize : min((i+1)*StateChunkSize, len(encoded))]
+		secret := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      fmt.Sprintf("%s%s-%d", stateChunkSecretPrefix, checksum[:10], i),
+				Namespace: ext.Namespace,
+			},
+		}
+		if _, err := controllerutil.CreateOrUpdate(ctx, c, secret, func() error {
+			metav1.SetMetaDataLabel(&secret.ObjectMeta, StateChunkLabel, "true")
+			secret.OwnerReferences = []metav1.OwnerReference{{
+				APIVersion: extapi.SchemeGroupVersion.String(),
+				Kind:       extapi.ExtensionResource,
+				Name:       ext.Name,
+				UID:        ext.UID,
+			}}
+			secret.Data = map[string][]byte{stateChunkDataKey: chunkData}
+			return nil
+		}); err != nil {
+			return nil, nil, fmt.Errorf("failed writing secret %q for state chunk %d: %w", secret.Name, i, err)
+		}
+
+		name := fmt.Sprintf("%s%d", stateChunkResourcePrefix, i)
+		index.Chunks = append(index.Chunks, stateChunk{Name: name, Checksum: computeChecksum(chunkData)})
+		resources = append(resources, gardencorev1beta1.NamedResourceReference{
+			Name: name,
+			ResourceRef: autoscalingv1.CrossVersionObjectReference{
+				APIVersion: "v1",
+				Kind:       "Secret",
+				Name:       secret.Name,
+			},
+		})
+	}
+
+	data, err := json.Marshal(&chunkedEntriesState{ChunkedState: index})
+	if err != nil {
+		return nil, nil, err
+	}
+	return data, resources, nil
+}
+
+// deleteObsoleteStateChunks deletes all chunk secrets in the namespace of the extension which are not referenced
+// in its status resources.
+func deleteObsoleteStateChunks(ctx context.Context, c client.Client, ext *extapi.Extension) error {
+	referenced := sets.New[string]()
+	for _, ref := range ext.Status.Resources {
+		if strings.HasPrefix(ref.Name, stateChunkResourcePrefix) {
+			referenced.Insert(ref.ResourceRef.Name)
+		}
+	}
+
+	secrets := &corev1.SecretList{}
+	if err := c.List(ctx, secrets, client.InNamespace(ext.Namespace), client.MatchingLabels{StateChunkLabel: "true"}); err != nil {
+		return err
+	}
+	for _, secret := range secrets.Items {
+		if referenced.Has(secret.Name) {
+			continue
+		}
+		if err := client.IgnoreNotFound(c.Delete(ctx, &secret)); err != nil {
+			return fmt.Errorf("failed deleting obsolete state chunk secret %q: %w", secret.Name, err)
+		}
+	}
+	return nil
+}
+
+func withoutStateChunkReferences(resources []gardencorev1beta1.NamedResourceReference) []gardencorev1beta1.NamedResourceReference {
+	var result []gardencorev1beta1.NamedResourceReference
+	for _, ref := range resources {
+		if !strings.HasPrefix(ref.Name, stateChunkResourcePrefix) {
+			result = append(result, ref)
+		}
+	}
+	return result
+}
+
+func findResourceReference(resources []gardencorev1beta1.NamedResourceReference, name string) *gardencorev1beta1.NamedResourceReference {
+	for i := range resources {
+		if resources[i].Name == name {
+			return &resources[i]
+		}
+	}
+	return nil
+}
+
+func computeChecksum(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,236 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package common
+
+import (
+	"context"
+	"fmt"
+
+	dnsv1alpha1 "github.com/gardener/external-dns-management/pkg/apis/dns/v1alpha1"
+	extensionscontroller "github.com/gardener/gardener/extensions/pkg/controller"
+	extapi "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/gardener/gardener-extension-shoot-dns-service/pkg/apis"
+)
+
+var _ = Describe("ChunkedState", func() {
+	const namespace = "shoot--foo--bar"
+
+	var (
+		ctx        context.Context
+		seedClient client.Client
+		ext        *extapi.Extension
+
+		oldMaxInlineStateSize int
+		oldStateChunkSize     int
+
+		newEntries = func(count int) []dnsv1alpha1.DNSEntry {
+			var entries []dnsv1alpha1.DNSEntry
+			for i := range count {
+				entries = append(entries, dnsv1alpha1.DNSEntry{
+					ObjectMeta: metav1.ObjectMeta{Name: fmt.Sprintf("entry-%d", i)},
+					Spec: dnsv1alpha1.DNSEntrySpec{
+						DNSName: fmt.Sprintf("e%d.foo.bar.external.example.com", i),
+						Targets: []string{fmt.Sprintf("%x.elb.eu-central-1.amazonaws.com", i*7919)},
+					},
+				})
+			}
+			return entries
+		}
+
+		updateState = func(entries []dnsv1alpha1.DNSEntry) {
+			handler, err := NewStateHandler(ctx, GinkgoLogr, seedClient, ext)
+			Expect(err).NotTo(HaveOccurred())
+			handler.EnsureEntries(entries)
+			Expect(handler.Update("test")).To(Succeed())
+		}
+
+		enableStateEnvelope = func() {
+			oldWriteStateEnvelope := WriteStateEnvelope
+			WriteStateEnvelope = true
+			DeferCleanup(func() { WriteStateEnvelope = oldWriteStateEnvelope })
+		}
+
+		listChunkSecrets = func() []corev1.Secret {
+			secrets := &corev1.SecretList{}
+			Expect(seedClient.List(ctx, secrets, client.InNamespace(namespace), client.MatchingLabels{StateChunkLabel: "true"})).To(Succeed())
+			return secrets.Items
+		}
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		scheme := runtime.NewScheme()
+		Expect(corev1.AddToScheme(scheme)).To(Succeed())
+		Expect(extensionscontroller.AddToScheme(scheme)).To(Succeed())
+		seedClient = fake.NewClientBuilder().WithScheme(scheme).WithStatusSubresource(&extapi.Extension{}).Build()
+
+		ext = &extapi.Extension{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "shoot-dns-service",
+				Namespace: namespace,
+			},
+		}
+		Expect(seedClient.Create(ctx, ext)).To(Succeed())
+
+		oldMaxInlineStateSize, oldStateChunkSize = MaxInlineStateSize, StateChunkSize
+		MaxInlineStateSize, StateChunkSize = 500, 100
+		DeferCleanup(func() {
+			MaxInlineStateSize, StateChunkSize = oldMaxInlineStateSize, oldStateChunkSize
+		})
+	})
+
+	It("should store a small state inline", func() {
+		updateState(newEntries(1))
+
+		Expect(DetectStateFormat(ext.Status.State.Raw)).To(Equal(StateFormatCompressed))
+		Expect(ext.Status.Resources).To(BeEmpty())
+		Expect(listChunkSecrets()).To(BeEmpty())
+	})
+
+	It("should write the v1alpha1 state readable by older versions by default", func() {
+		updateState(newEntries(1))
+
+		data, err := DecompressEntriesState(ext.Status.State.Raw)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(data)).To(ContainSubstring(`"apiVersion":"dns.extensions.gardener.cloud/v1alpha1"`))
+	})
+
+	It("should refuse redirected entries without the state envelope", func() {
+		handler, err := NewStateHandler(ctx, GinkgoLogr, seedClient, ext)
+		Expect(err).NotTo(HaveOccurred())
+		handler.EnsureEntries(newEntries(1))
+		handler.SetRedirectedEntries([]*apis.RedirectedDNSEntry{{Name: "entry-0", Targets: []string{"1.2.3.4"}}})
+		Expect(handler.Update("test")).To(MatchError("the state contains 1 redirected entries, which can only be stored if the state envelope is enabled"))
+	})
+
+	It("should write the v1alpha2 state with redirected entries in the state envelope", func() {
+		enableStateEnvelope()
+		handler, err := NewStateHandler(ctx, GinkgoLogr, seedClient, ext)
+		Expect(err).NotTo(HaveOccurred())
+		handler.EnsureEntries(newEntries(1))
+		handler.SetRedirectedEntries([]*apis.RedirectedDNSEntry{{Name: "entry-0", Targets: []string{"1.2.3.4"}}})
+		Expect(handler.Update("test")).To(Succeed())
+
+		Expect(DetectStateFormat(ext.Status.State.Raw)).To(Equal(StateFormatEnvelope))
+		data, _, err := DecodeEntriesState(ctx, seedClient, ext)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(data)).To(ContainSubstring(`"apiVersion":"dns.extensions.gardener.cloud/v1alpha2"`))
+		state, err := GetExtensionState(ctx, seedClient, ext)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(state.RedirectedEntries).To(ConsistOf(&apis.RedirectedDNSEntry{Name: "entry-0", Targets: []string{"1.2.3.4"}}))
+	})
+
+	It("should write the state envelope if enabled", func() {
+		enableStateEnvelope()
+
+		updateState(newEntries(1))
+		Expect(DetectStateFormat(ext.Status.State.Raw)).To(Equal(StateFormatEnvelope))
+
+		entries := newEntries(50)
+		updateState(entries)
+		Expect(DetectStateFormat(ext.Status.State.Raw)).To(Equal(StateFormatChunked))
+		state, err := GetExtensionState(ctx, seedClient, ext)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(state.Entries).To(HaveLen(len(entries)))
+	})
+
+	It("should not split a large state into chunks without the state envelope", func() {
+		updateState(newEntries(50))
+
+		Expect(DetectStateFormat(ext.Status.State.Raw)).To(Equal(StateFormatCompressed))
+		Expect(len(ext.Status.State.Raw)).To(BeNumerically(">", MaxInlineStateSize))
+		Expect(ext.Status.Resources).To(BeEmpty())
+		Expect(listChunkSecrets()).To(BeEmpty())
+	})
+
+	It("should rewrite a chunked state readable by older versions if the state envelope is disabled", func() {
+		enableStateEnvelope()
+		updateState(newEntries(50))
+		Expect(DetectStateFormat(ext.Status.State.Raw)).To(Equal(StateFormatChunked))
+
+		WriteStateEnvelope = false
+		handler, err := NewStateHandler(ctx, GinkgoLogr, seedClient, ext)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(handler.Update("test")).To(Succeed())
+		Expect(DetectStateFormat(ext.Status.State.Raw)).To(Equal(StateFormatCompressed))
+		Expect(ext.Status.Resources).To(BeEmpty())
+		Expect(listChunkSecrets()).To(BeEmpty())
+		state, err := GetExtensionState(ctx, seedClient, ext)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(state.Entries).To(HaveLen(50))
+	})
+
+	It("should split a large state into chunks and reassemble it", func() {
+		enableStateEnvelope()
+		entries := newEntries(50)
+		updateState(entries)
+
+		Expect(DetectStateFormat(ext.Status.State.Raw)).To(Equal(StateFormatChunked))
+		secrets := listChunkSecrets()
+		Expect(len(secrets)).To(BeNumerically(">", 1))
+		Expect(ext.Status.Resources).To(HaveLen(len(secrets)))
+
+		Expect(seedClient.Get(ctx, client.ObjectKeyFromObject(ext), ext)).To(Succeed())
+		state, err := GetExtensionState(ctx, seedClient, ext)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(state.Entries).To(HaveLen(len(entries)))
+		for i, entry := range state.Entries {
+			Expect(entry.Name).To(Equal(entries[i].Name))
+			Expect(entry.Spec).To(Equal(&entries[i].Spec))
+		}
+	})
+
+	It("should replace the chunks on update and delete obsolete ones", func() {
+		enableStateEnvelope()
+		updateState(newEntries(50))
+		oldSecrets := listChunkSecrets()
+
+		updateState(newEntries(40))
+		newSecrets := listChunkSecrets()
+		Expect(newSecrets).NotTo(BeEmpty())
+		for _, secret := range newSecrets {
+			Expect(oldSecrets).NotTo(ContainElement(HaveField("Name", secret.Name)))
+		}
+		state, err := GetExtensionState(ctx, seedClient, ext)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(state.Entries).To(HaveLen(40))
+
+		updateState(newEntries(1))
+		Expect(DetectStateFormat(ext.Status.State.Raw)).To(Equal(StateFormatEnvelope))
+		Expect(ext.Status.Resources).To(BeEmpty())
+		Expect(listChunkSecrets()).To(BeEmpty())
+	})
+
+	It("should detect a corrupted chunk", func() {
+		enableStateEnvelope()
+		updateState(newEntries(50))
+
+		secret := listChunkSecrets()[0]
+		secret.Data[stateChunkDataKey][0] ^= 0xff
+		Expect(seedClient.Update(ctx, &secret)).To(Succeed())
+
+		_, err := GetExtensionState(ctx, seedClient, ext)
+		Expect(err).To(MatchError(ContainSubstring("checksum mismatch for state chunk")))
+	})
+
+	It("should detect a missing chunk", func() {
+		enableStateEnvelope()
+		updateState(newEntries(50))
+
+		secret := listChunkSecrets()[0]
+		Expect(seedClient.Delete(ctx, &secret)).To(Succeed())
+
+		_, err := GetExtensionState(ctx, seedClient, ext)
+		Expect(err).To(MatchError(ContainSubstring("failed reading secret")))
+	})
+})
@@ -41,6 +41,7 @@ func CompressEntriesState(state []byte) ([]byte, error) {
 }
 
 // LooksLikeCompressedEntriesState checks if the given state data has the string compressedState in the first 20 bytes.
+// Deprecated: Use DetectStateFormat instead.
 func LooksLikeCompressedEntriesState(state []byte) bool {
 	if len(state) < len("compressedState") {
 		return false
@@ -101,8 +101,9 @@ func (h *ShootDNSEntriesHelper) DeleteAll() error {
 }
 
 // ForceDeleteAll forces deletion of DNSEntries by removing the finalizers first.
+// The optional beforeFinalizerRemoval function is called with the remaining entries before their finalizers are removed.
 // Warning: calling this method can result in leaked DNS record sets in the infrastructure and should only be used as last resort.
-func (h *ShootDNSEntriesHelper) ForceDeleteAll() error {
+func (h *ShootDNSEntriesHelper) ForceDeleteAll(beforeFinalizerRemoval func(entries []dnsapi.DNSEntry) error) error {
 	err := h.DeleteAll()
 	if err != nil {
 		return err
@@ -113,6 +114,12 @@ func (h *ShootDNSEntriesHelper) ForceDeleteAll() error {
 		return err
 	}
 
+	if beforeFinalizerRemoval != nil && len(entries) > 0 {
+		if err := beforeFinalizerRemoval(entries); err != nil {
+			return err
+		}
+	}
+
 	for _, entry := range entries {
 		patch := client.MergeFrom(entry.DeepCopy())
 		entry.SetFinalizers(nil)
@@ -0,0 +1,264 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package common
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"slices"
+	"strings"
+
+	dnsapi "github.com/gardener/external-dns-management/pkg/apis/dns/v1alpha1"
+	dnsman2dns "github.com/gardener/external-dns-management/pkg/dnsman2/dns"
+	"github.com/gardener/external-dns-management/pkg/dnsman2/dns/provider"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// LeakedRecordsLabel is the label key for config maps containing the inventory of DNS records which may have
+	// been leaked in the infrastructure by the forced deletion of the DNS entries of a shoot.
+	LeakedRecordsLabel = "service.dns.extensions.gardener.cloud/leaked-records"
+
+	leakedRecordsConfigMapPrefix   = "leaked-dns-records-"
+	leakedRecordsShootIDKey        = "shootID"
+	leakedRecordsShootNamespaceKey = "namespace"
+	leakedRecordsRecordsKey        = "records"
+)
+
+// LeakedDNSRecord is a DNS record set which may have been leaked in the infrastructure, as the finalizer
+// of its DNS entry has been removed on forced deletion.
+type LeakedDNSRecord struct {
+	// Entry is the name of the removed DNS entry.
+	Entry string `json:"entry"`
+	// DNSName is the fully qualified domain name of the record set.
+	DNSName string `json:"dnsName"`
+	// RecordType is the type of the record set, e.g. A, AAAA, CNAME or TXT.
+	RecordType string `json:"recordType"`
+	// Targets are the records of the record set.
+	Targets []string `json:"targets"`
+	// TTL is the time to live of the record set.
+	TTL int64 `json:"ttl,omitempty"`
+	// Provider is the name of the DNS provider which created the record set.
+	Provider string `json:"provider,omitempty"`
+	// ProviderType is the type of the DNS provider which created the record set.
+	ProviderType string `json:"providerType,omitempty"`
+	// Zone is the ID of the hosted zone containing the record set.
+	Zone string `json:"zone"`
+}
+
+// LeakedRecordsInventory is the inventory of possibly leaked DNS records of a shoot.
+type LeakedRecordsInventory struct {
+	// Name is the name of the config map storing the inventory.
+	Name string
+	// Namespace is the namespace of the config map storing the inventory.
+	Namespace string
+	// CreationTimestamp is the creation timestamp of the config map storing the inventory.
+	CreationTimestamp metav1.Time
+	// ShootID is the cluster identity of the shoot.
+	ShootID string
+	// ShootNamespace is the namespace of the shoot in the seed.
+	ShootNamespace string
+	// Records are the possibly leaked DNS records.
+	Records []LeakedDNSRecord
+}
+
+// LeakedRecordsFromEntries returns the DNS records provisioned for the given DNS entries according to their status.
+// Entries without zone in the status have not been provisioned and are skipped.
+func LeakedRecordsFromEntries(entries []dnsapi.DNSEntry) []LeakedDNSRecord {
+	var records []LeakedDNSRecord
+	for _, entry := range entries {
+		status := entry.Status
+		if status.Zone == nil || len(status.Targets) == 0 {
+			continue
+		}
+		dnsName := entry.Spec.DNSName
+		if status.DNSName != nil {
+			dnsName = *status.DNSName
+		}
+
+		targetsByType := map[string][]string{}
+		var types []string
+		for _, target := range status.Targets {
+			recordType := recordTypeOfTarget(target, len(entry.Spec.Text) > 0)
+			if _, ok := targetsByType[recordType]; !ok {
+				types = append(types, recordType)
+			}
+			targetsByType[recordType] = append(targetsByType[recordType], target)
+		}
+		for _, recordType := range types {
+			record := LeakedDNSRecord{
+				Entry:      entry.Name,
+				DNSName:    dnsName,
+				RecordType: recordType,
+				Targets:    targetsByType[recordType],
+				Zone:       *status.Zone,
+			}
+			if status.TTL != nil {
+				record.TTL = *status.TTL
+			}
+			if status.Provider != nil {
+				record.Provider = *status.Provider
+			}
+			if status.ProviderType != nil {
+				record.ProviderType = *status.ProviderType
+			}
+			records = append(records, record)
+		}
+	}
+	return records
+}
+
+func recordTypeOfTarget(target string, text bool) string {
+	if text {
+		return "TXT"
+	}
+	ip := net.ParseIP(target)
+	switch {
+	case ip == nil:
+		return "CNAME"
+	case ip.To4() != nil:
+		return "A"
+	default:
+		return "AAAA"
+	}
+}
+
+// LeakedRecordsConfigMapName returns the name of the config map storing the inventory of leaked DNS records of a shoot.
+func LeakedRecordsConfigMapName(shootID string) string {
+	return leakedRecordsConfigMapPrefix + ShortenID(strings.ToLower(shootID), 63)
+}
+
+// AddLeakedRecords adds the given records to the inventory of the shoot stored in a config map in the given namespace.
+// Records already contained in the inventory are replaced.
+func AddLeakedRecords(ctx context.Context, c client.Client, namespace, shootID, shootNamespace string, records []LeakedDNSRecord) error {
+	if len(records) == 0 {
+		return nil
+	}
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      LeakedRecordsConfigMapName(shootID),
+			Namespace: namespace,
+		},
+	}
+	_, err := controllerutil.CreateOrUpdate(ctx, c, cm, func() error {
+		existing, err := decodeLeakedRecords(cm)
+		if err != nil {
+			return err
+		}
+		for _, record := range records {
+			existing = slices.DeleteFunc(existing, func(r LeakedDNSRecord) bool { return r.sameRecordSet(record) })
+			existing = append(existing, record)
+		}
+		return encodeLeakedRecords(cm, shootID, shootNamespace, existing)
+	})
+	if err != nil {
+		return fmt.Errorf("failed writing inventory of leaked DNS records %s/%s: %w", namespace, cm.Name, err)
+	}
+	return nil
+}
+
+// ListLeakedRecords lists the inventories of leaked DNS records in the given namespace.
+func ListLeakedRecords(ctx context.Context, c client.Reader, namespace string) ([]LeakedRecordsInventory, error) {
+	list := &corev1.ConfigMapList{}
+	if err := c.List(ctx, list, client.InNamespace(namespace), client.MatchingLabels{LeakedRecordsLabel: "true"}); err != nil {
+		return nil, err
+	}
+	var result []LeakedRecordsInventory
+	for _, cm := range list.Items {
+		records, err := decodeLeakedRecords(&cm)
+		if err != nil {
+			return nil, fmt.Errorf("invalid inventory of leaked DNS records %s/%s: %w", cm.Namespace, cm.Name, err)
+		}
+		result = append(result, LeakedRecordsInventory{
+			Name:              cm.Name,
+			Namespace:         cm.Namespace,
+			CreationTimestamp: cm.CreationTimestamp,
+			ShootID:           cm.Data[leakedRecordsShootIDKey],
+			ShootNamespace:    cm.Data[leakedRecordsShootNamespaceKey],
+			Records:           records,
+		})
+	}
+	return result, nil
+}
+
+// UpdateLeakedRecords stores the records of the given inventory. The config map is deleted if no records are left.
+func UpdateLeakedRecords(ctx context.Context, c client.Client, inventory *LeakedRecordsInventory) error {
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      inventory.Name,
+			Namespace: inventory.Namespace,
+		},
+	}
+	if len(inventory.Records) == 0 {
+		return client.IgnoreNotFound(c.Delete(ctx, cm))
+	}
+	if err := c.Get(ctx, client.ObjectKeyFromObject(cm), cm); err != nil {
+		return err
+	}
+	if err := encodeLeakedRecords(cm, inventory.ShootID, inventory.ShootNamespace, inventory.Records); err != nil {
+		return err
+	}
+	return c.Update(ctx, cm)
+}
+
+func (r LeakedDNSRecord) sameRecordSet(other LeakedDNSRecord) bool {
+	return r.DNSName == other.DNSName && r.RecordType == other.RecordType && r.Zone == other.Zone
+}
+
+func decodeLeakedRecords(cm *corev1.ConfigMap) ([]LeakedDNSRecord, error) {
+	data := cm.Data[leakedRecordsRecordsKey]
+	if data == "" {
+		return nil, nil
+	}
+	var records []LeakedDNSRecord
+	if err := yaml.Unmarshal([]byte(data), &records); err != nil {
+		return nil, err
+	}
+	return records, nil
+}
+
+func encodeLeakedRecords(cm *corev1.ConfigMap, shootID, shootNamespace string, records []LeakedDNSRecord) error {
+	data, err := yaml.Marshal(records)
+	if err != nil {
+		return err
+	}
+	metav1.SetMetaDataLabel(&cm.ObjectMeta, LeakedRecordsLabel, "true")
+	cm.Data = map[string]string{
+		leakedRecordsShootIDKey:        shootID,
+		leakedRecordsShootNamespaceKey: shootNamespace,
+		leakedRecordsRecordsKey:        string(data),
+	}
+	return nil
+}
+
+// DeleteLeakedRecord deletes the record set of the given leaked record in the hosted zone using the DNS handler.
+func DeleteLeakedRecord(ctx context.Context, h provider.DNSHandler, zone provider.DNSHostedZone, record LeakedDNSRecord) error {
+	var records []*dnsman2dns.Record
+	for _, target := range record.Targets {
+		records = append(records, &dnsman2dns.Record{Value: target})
+	}
+	recordType := dnsman2dns.RecordType(record.RecordType)
+	requests := provider.NewChangeRequests(dnsman2dns.DNSSetName{DNSName: record.DNSName})
+	requests.Updates[recordType] = &provider.ChangeRequestUpdate{
+		Old: dnsman2dns.NewRecordSet(recordType, record.TTL, records),
+	}
+	return h.ExecuteRequests(ctx, zone, *requests)
+}
+
+// NoMetrics discards the request metrics of DNS handlers used for deleting leaked records.
+type NoMetrics struct{}
+
+var _ provider.Metrics = NoMetrics{}
+
+// AddGenericRequests implements provider.Metrics.
+func (NoMetrics) AddGenericRequests(_ provider.MetricsRequestType, _ int) {}
+
+// AddZoneRequests implements provider.Metrics.
+func (NoMetrics) AddZoneRequests(_ string, _ provider.MetricsRequestType, _ int) {}
@@ -0,0 +1,148 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package common
+
+import (
+	"context"
+
+	dnsv1alpha1 "github.com/gardener/external-dns-management/pkg/apis/dns/v1alpha1"
+	dnsman2dns "github.com/gardener/external-dns-management/pkg/dnsman2/dns"
+	"github.com/gardener/external-dns-management/pkg/dnsman2/dns/provider"
+	"github.com/gardener/external-dns-management/pkg/dnsman2/dns/provider/handler/local"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/util/flowcontrol"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var _ = Describe("LeakedRecords", func() {
+	const (
+		namespace = "extension-shoot-dns-service"
+		shootID   = "shoot--foo--bar-78897def-5208-4feb-b0f0-015950eadbb9-test-landscape"
+	)
+
+	var (
+		ctx        context.Context
+		seedClient client.Client
+
+		newEntry = func(name string, targets []string, text []string, zone *string) dnsv1alpha1.DNSEntry {
+			return dnsv1alpha1.DNSEntry{
+				ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "shoot--foo--bar"},
+				Spec: dnsv1alpha1.DNSEntrySpec{
+					DNSName: name + ".foo.bar.external.example.com",
+					Targets: targets,
+					Text:    text,
+				},
+				Status: dnsv1alpha1.DNSEntryStatus{
+					ProviderType: new("aws-route53"),
+					Provider:     new("shoot--foo--bar/external"),
+					Zone:         zone,
+					DNSName:      new(name + ".foo.bar.external.example.com"),
+					TTL:          new(int64(300)),
+					Targets:      append(targets, text...),
+				},
+			}
+		}
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		scheme := runtime.NewScheme()
+		Expect(corev1.AddToScheme(scheme)).To(Succeed())
+		seedClient = fake.NewClientBuilder().WithScheme(scheme).Build()
+	})
+
+	It("should determine the records of the entries from their status", func() {
+		records := LeakedRecordsFromEntries([]dnsv1alpha1.DNSEntry{
+			newEntry("a", []string{"1.2.3.4", "2001:db8::1"}, nil, new("Z1")),
+			newEntry("b", []string{"lb.example.com"}, nil, new("Z1")),
+			newEntry("c", nil, []string{"\"some text\""}, new("Z2")),
+			newEntry("d", []string{"1.2.3.5"}, nil, nil),
+		})
+
+		Expect(records).To(HaveLen(4))
+		Expect(records[0]).To(Equal(LeakedDNSRecord{
+			Entry:        "a",
+			DNSName:      "a.foo.bar.external.example.com",
+			RecordType:   "A",
+			Targets:      []string{"1.2.3.4"},
+			TTL:          300,
+			Provider:     "shoot--foo--bar/external",
+			ProviderType: "aws-route53",
+			Zone:         "Z1",
+		}))
+		Expect(records[1].RecordType).To(Equal("AAAA"))
+		Expect(records[1].Targets).To(Equal([]string{"2001:db8::1"}))
+		Expect(records[2].RecordType).To(Equal("CNAME"))
+		Expect(records[3].RecordType).To(Equal("TXT"))
+		Expect(records[3].Zone).To(Equal("Z2"))
+	})
+
+	It("should merge records into the inventory and delete it if empty", func() {
+		records := LeakedRecordsFromEntries([]dnsv1alpha1.DNSEntry{
+			newEntry("a", []string{"1.2.3.4"}, nil, new("Z1")),
+			newEntry("b", []string{"lb.example.com"}, nil, new("Z1")),
+		})
+		Expect(AddLeakedRecords(ctx, seedClient, namespace, shootID, "shoot--foo--bar", records)).To(Succeed())
+		updated := LeakedRecordsFromEntries([]dnsv1alpha1.DNSEntry{
+			newEntry("b", []string{"lb.example.com"}, nil, new("Z1")),
+		})
+		updated[0].TTL = 60
+		Expect(AddLeakedRecords(ctx, seedClient, namespace, shootID, "shoot--foo--bar", updated)).To(Succeed())
+
+		inventories, err := ListLeakedRecords(ctx, seedClient, namespace)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(inventories).To(HaveLen(1))
+		inventory := inventories[0]
+		Expect(inventory.Name).To(Equal(LeakedRecordsConfigMapName(shootID)))
+		Expect(inventory.ShootID).To(Equal(shootID))
+		Expect(inventory.ShootNamespace).To(Equal("shoot--foo--bar"))
+		Expect(inventory.Records).To(Equal([]LeakedDNSRecord{records[0], updated[0]}))
+
+		inventory.Records = inventory.Records[1:]
+		Expect(UpdateLeakedRecords(ctx, seedClient, &inventory)).To(Succeed())
+		inventories, err = ListLeakedRecords(ctx, seedClient, namespace)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(inventories).To(HaveLen(1))
+		Expect(inventories[0].Records).To(Equal([]LeakedDNSRecord{updated[0]}))
+
+		inventory.Records = nil
+		Expect(UpdateLeakedRecords(ctx, seedClient, &inventory)).To(Succeed())
+		inventories, err = ListLeakedRecords(ctx, seedClient, namespace)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(inventories).To(BeEmpty())
+	})
+
+	It("should delete the record set of a leaked record", func() {
+		dnsHandler, err := local.NewHandler(&provider.DNSHandlerConfig{
+			Log:         GinkgoLogr,
+			Config:      &runtime.RawExtension{Raw: []byte(`{"account":"leakedrecords-test","zones":[{"dnsName":"example.com"}]}`)},
+			Metrics:     NoMetrics{},
+			RateLimiter: flowcontrol.NewFakeAlwaysRateLimiter(),
+		})
+		Expect(err).NotTo(HaveOccurred())
+		DeferCleanup(dnsHandler.Release)
+		zones, err := dnsHandler.GetZones(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(zones).To(HaveLen(1))
+		zone := zones[0]
+		setName := dnsman2dns.DNSSetName{DNSName: "a.foo.bar.external.example.com"}
+		requests := provider.NewChangeRequests(setName)
+		requests.Updates[dnsman2dns.TypeA] = &provider.ChangeRequestUpdate{
+			New: dnsman2dns.NewRecordSet(dnsman2dns.TypeA, 300, []*dnsman2dns.Record{{Value: "1.2.3.4"}}),
+		}
+		Expect(dnsHandler.ExecuteRequests(ctx, zone, *requests)).To(Succeed())
+		mock := local.GetInMemoryMock("leakedrecords-test")
+		Expect(mock.GetRecordset(zone.ZoneID(), setName, dnsman2dns.TypeA)).NotTo(BeNil())
+
+		records := LeakedRecordsFromEntries([]dnsv1alpha1.DNSEntry{newEntry("a", []string{"1.2.3.4"}, nil, new(zone.ZoneID().ID))})
+		Expect(DeleteLeakedRecord(ctx, dnsHandler, zone, records[0])).To(Succeed())
+		Expect(mock.GetRecordset(zone.ZoneID(), setName, dnsman2dns.TypeA)).To(BeNil())
+	})
+})
@@ -14,6 +14,7 @@ import (
 	"github.com/gardener/external-dns-management/pkg/dns"
 	extapi "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
 	"github.com/go-logr/logr"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/serializer"
@@ -22,7 +23,9 @@ import (
 
 	"github.com/gardener/gardener-extension-shoot-dns-service/pkg/apis"
 	"github.com/gardener/gardener-extension-shoot-dns-service/pkg/apis/helper"
-	wireapi "github.com/gardener/gardener-extension-shoot-dns-service/pkg/apis/v1alpha1"
+	legacyapi "github.com/gardener/gardener-extension-shoot-dns-service/pkg/apis/v1alpha1"
+	wireapi "github.com/gardener/gardener-extension-shoot-dns-service/pkg/apis/v1alpha2"
+	"github.com/gardener/gardener-extension-shoot-dns-service/pkg/controller/metrics"
 )
 
 var (
@@ -33,20 +36,19 @@ func init() {
 	decoder = serializer.NewCodecFactory(helper.Scheme).UniversalDecoder()
 }
 
-func GetExtensionState(ext *extapi.Extension) (*apis.DNSState, error) {
+// GetExtensionState returns the DNS state stored in the extension status.
+// Chunked states are reassembled from the referenced secrets using the given reader.
+func GetExtensionState(ctx context.Context, reader client.Reader, ext *extapi.Extension) (*apis.DNSState, error) {
 	state := &apis.DNSState{}
-	if ext.Status.State != nil && ext.Status.State.Raw != nil {
-		data := ext.Status.State.Raw
-		if LooksLikeCompressedEntriesState(data) {
-			var err error
-			data, err = DecompressEntriesState(data)
-			if err != nil {
-				return state, fmt.Errorf("could not decompress extension state: %w", err)
-			}
-		}
-		if _, _, err := decoder.Decode(data, nil, state); err != nil {
-			return state, fmt.Errorf("could not decode extension state: %w", err)
-		}
+	data, entryCount, err := DecodeEntriesState(ctx, reader, ext)
+	if err != nil || data == nil {
+		return state, err
+	}
+	if _, _, err := decoder.Decode(data, nil, state); err != nil {
+		return state, fmt.Errorf("could not decode extension state: %w", err)
+	}
+	if entryCount >= 0 && entryCount != len(state.Entries) {
+		return state, fmt.Errorf("entry count mismatch of extension state: expected %d, got %d", entryCount, len(state.Entries))
 	}
 	return state, nil
 }
@@ -83,7 +85,14 @@ func NewStateHandler(ctx context.Context, log logr.Logger, client client.Client,
 		elem:   elem,
 		helper: NewShootDNSEntriesHelper(ctx, client, ext),
 	}
-	handler.state, err = GetExtensionState(ext)
+	handler.state, err = GetExtensionState(ctx, client, ext)
+	if err == nil && !WriteStateEnvelope && ext.Status.State != nil {
+		switch DetectStateFormat(ext.Status.State.Raw) {
+		case StateFormatEnvelope, StateFormatChunked:
+			// rewrite the state in the format readable by older versions, e.g. to prepare a rollback
+			handler.modified = true
+		}
+	}
 	return handler, err
 }
 
@@ -107,10 +116,28 @@ func (s *StateHandler) Refresh() (bool, error) {
 }
 
 // DropAllEntries removes all entries from the state.
+// The original targets of redirected entries are kept, as they are needed on wake-up of a hibernated shoot.
 func (s *StateHandler) DropAllEntries() {
-	if s.state == nil || !reflect.DeepEqual(s.state, &apis.DNSState{}) {
+	if s.state == nil || len(s.state.Entries) > 0 {
 		s.log.Info("dropping all entries from state", "namespace", s.ext.Namespace)
-		s.state = &apis.DNSState{}
+		var redirected []*apis.RedirectedDNSEntry
+		if s.state != nil {
+			redirected = s.state.RedirectedEntries
+		}
+		s.state = &apis.DNSState{RedirectedEntries: redirected}
+		s.modified = true
+	}
+}
+
+// RedirectedEntries returns the original targets of the entries redirected during hibernation.
+func (s *StateHandler) RedirectedEntries() []*apis.RedirectedDNSEntry {
+	return s.state.RedirectedEntries
+}
+
+// SetRedirectedEntries sets the original targets of the entries redirected during hibernation.
+func (s *StateHandler) SetRedirectedEntries(entries []*apis.RedirectedDNSEntry) {
+	if !reflect.DeepEqual(s.state.RedirectedEntries, entries) {
+		s.state.RedirectedEntries = entries
 		s.modified = true
 	}
 }
@@ -179,25 +206,16 @@ func (s *StateHandler) ensureEntryFor(entry *dnsapi.DNSEntry) bool {
 func (s *StateHandler) Update(reason string) error {
 	if s.modified || s.ext.Status.State == nil {
 		s.log.Info("updating modified state", "namespace", s.ext.Namespace, "extension", s.ext.Name, "reason", reason)
-		wire := &wireapi.DNSState{}
-		wire.APIVersion = wireapi.SchemeGroupVersion.String()
-		wire.Kind = wireapi.DNSStateKind
-		err := helper.Scheme.Convert(s.state, wire, nil)
-		if err != nil {
-			s.log.Error(err, "state conversion failed")
-			return err
-		}
 		if s.ext.Status.State == nil {
 			s.ext.Status.State = &runtime.RawExtension{}
 		}
-		data, err := json.Marshal(wire)
+		data, encoded, err := s.encodeState()
 		if err != nil {
-			s.log.Error(err, "marshalling failed")
 			return err
 		}
-		s.ext.Status.State.Raw, err = CompressEntriesState(data)
+		s.ext.Status.State.Raw, s.ext.Status.Resources, err = storeChunkedEntriesState(s.ctx, s.client, s.ext, encoded)
 		if err != nil {
-			s.log.Error(err, "compressing failed")
+			s.log.Error(err, "storing state chunks failed")
 			return err
 		}
 		s.ext.Status.State.Object = nil
@@ -207,6 +225,51 @@ func (s *StateHandler) Update(reason string) error {
 			return err
 		}
 		s.modified = false
+		metrics.ExtensionStateSize.WithLabelValues(s.ext.Namespace, metrics.EncodingUncompressed).Set(float64(len(data)))
+		metrics.ExtensionStateSize.WithLabelValues(s.ext.Namespace, metrics.EncodingCompressed).Set(float64(len(encoded)))
+		if err := deleteObsoleteStateChunks(s.ctx, s.client, s.ext); err != nil {
+			// obsolete chunks are deleted with the next update or together with the extension
+			s.log.Info("deleting obsolete state chunks failed", "error", err, "namespace", s.ext.Namespace)
+		}
 	}
 	return nil
 }
+
+// encodeState returns the marshalled DNSState and its encoding stored in the extension status.
+// Without WriteStateEnvelope, the state is written in the compressed format with the v1alpha1 DNSState, which can also
+// be read by older versions of the extension. As the v1alpha1 DNSState cannot store redirected entries, they are
+// refused in this case instead of dropping their original targets.
+func (s *StateHandler) encodeState() ([]byte, []byte, error) {
+	if !WriteStateEnvelope && len(s.state.RedirectedEntries) > 0 {
+		return nil, nil, fmt.Errorf("the state contains %d redirected entries, which can only be stored if the state envelope is enabled", len(s.state.RedirectedEntries))
+	}
+	var wire runtime.Object
+	if WriteStateEnvelope {
+		wire = &wireapi.DNSState{TypeMeta: metav1.TypeMeta{APIVersion: wireapi.SchemeGroupVersion.String(), Kind: wireapi.DNSStateKind}}
+	} else {
+		wire = &legacyapi.DNSState{TypeMeta: metav1.TypeMeta{APIVersion: legacyapi.SchemeGroupVersion.String(), Kind: legacyapi.DNSStateKind}}
+	}
+	if err := helper.Scheme.Convert(s.state, wire, nil); err != nil {
+		s.log.Error(err, "state conversion failed")
+		return nil, nil, err
+	}
+	data, err := json.Marshal(wire)
+	if err != nil {
+		s.log.Error(err, "marshalling failed")
+		return nil, nil, err
+	}
+	if !WriteStateEnvelope {
+		encoded, err := CompressEntriesState(data)
+		if err != nil {
+			s.log.Error(err, "compressing failed")
+			return nil, nil, err
+		}
+		return data, encoded, nil
+	}
+	envelope, err := EncodeStateEnvelope(data, len(s.state.Entries), DefaultStateCodec)
+	if err != nil {
+		s.log.Error(err, "encoding failed")
+		return nil, nil, err
+	}
+	return data, envelope, nil
+}
@@ -0,0 +1,238 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package common
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/andybalholm/brotli"
+	extapi "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
+	"github.com/klauspost/compress/zstd"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// StateCodec is the codec used for the data of a state envelope.
+type StateCodec string
+
+const (
+	// StateCodecBrotli compresses the state data with brotli.
+	StateCodecBrotli StateCodec = "brotli"
+	// StateCodecZstd compresses the state data with zstd.
+	StateCodecZstd StateCodec = "zstd"
+	// StateCodecNone stores the state data uncompressed.
+	StateCodecNone StateCodec = "none"
+
+	// StateEnvelopeFormatVersion is the format version of the state envelopes written by the extension.
+	StateEnvelopeFormatVersion = 1
+
+	checksumPrefixSHA256 = "sha256:"
+)
+
+var (
+	// DefaultStateCodec is the codec used for writing state envelopes.
+	DefaultStateCodec = StateCodecBrotli
+	// WriteStateEnvelope enables writing the state in the versioned envelope and splitting large states into chunks.
+	// Both are always read, but as older versions of the extension cannot read them, they must only be written once all
+	// seeds run a version supporting them. Otherwise, the DNS entries are lost on control plane migration to a seed
+	// running an older version or on a rollback.
+	WriteStateEnvelope = false
+)
+
+// StateFormat is the format of the raw state data stored in the extension status.
+type StateFormat string
+
+const (
+	// StateFormatUnknown is returned for data in none of the known formats.
+	StateFormatUnknown StateFormat = "unknown"
+	// StateFormatPlain is the uncompressed DNSState written by the first versions of the extension.
+	StateFormatPlain StateFormat = "plain"
+	// StateFormatCompressed is the brotli compressed DNSState without header.
+	StateFormatCompressed StateFormat = "compressed"
+	// StateFormatChunked is the index of a state stored in chunks.
+	StateFormatChunked StateFormat = "chunked"
+	// StateFormatEnvelope is the versioned state envelope.
+	StateFormatEnvelope StateFormat = "envelope"
+)
+
+// StateEnvelope is the versioned envelope of the DNSState stored in the extension status.
+type StateEnvelope struct {
+	// FormatVersion is the version of the envelope format.
+	FormatVersion int `json:"formatVersion"`
+	// Codec is the codec used for the data.
+	Codec StateCodec `json:"codec"`
+	// Checksum is the checksum of the uncompressed data in the form `sha256:<hex>`.
+	Checksum string `json:"checksum"`
+	// EntryCount is the number of DNS entries contained in the state.
+	EntryCount int `json:"entryCount"`
+	// Data is the encoded DNSState.
+	Data []byte `json:"data"`
+}
+
+type wrappedStateEnvelope struct {
+	StateEnvelope *StateEnvelope `json:"stateEnvelope"`
+}
+
+// stateFormatProbe contains the top level fields identifying the historical state formats.
+type stateFormatProbe struct {
+	StateEnvelope   json.RawMessage `json:"stateEnvelope"`
+	CompressedState json.RawMessage `json:"compressedState"`
+	ChunkedState    json.RawMessage `json:"chunkedState"`
+	Kind            string          `json:"kind"`
+}
+
+// DetectStateFormat determines the format of the given raw state data by its top level fields.
+func DetectStateFormat(data []byte) StateFormat {
+	var probe stateFormatProbe
+	if err := json.Unmarshal(data, &probe); err != nil {
+		return StateFormatUnknown
+	}
+	switch {
+	case probe.StateEnvelope != nil:
+		return StateFormatEnvelope
+	case probe.ChunkedState != nil:
+		return StateFormatChunked
+	case probe.CompressedState != nil:
+		return StateFormatCompressed
+	case probe.Kind != "":
+		return StateFormatPlain
+	default:
+		return StateFormatUnknown
+	}
+}
+
+// EncodeStateEnvelope encodes the given DNSState data with the given codec into a state envelope.
+func EncodeStateEnvelope(state []byte, entryCount int, codec StateCodec) ([]byte, error) {
+	data, err := encodeStateData(state, codec)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(&wrappedStateEnvelope{StateEnvelope: &StateEnvelope{
+		FormatVersion: StateEnvelopeFormatVersion,
+		Codec:         codec,
+		Checksum:      checksumPrefixSHA256 + computeChecksum(state),
+		EntryCount:    entryCount,
+		Data:          data,
+	}})
+}
+
+// DecodeStateEnvelope decodes the given state envelope and verifies the checksum of the DNSState data.
+func DecodeStateEnvelope(data []byte) (*StateEnvelope, []byte, error) {
+	var wrapped wrappedStateEnvelope
+	if err := json.Unmarshal(data, &wrapped); err != nil {
+		return nil, nil, fmt.Errorf("failed unmarshalling JSON to state envelope: %w", err)
+	}
+	envelope := wrapped.StateEnvelope
+	if envelope == nil {
+		return nil, nil, fmt.Errorf("missing state envelope")
+	}
+	if envelope.FormatVersion != StateEnvelopeFormatVersion {
+		return nil, nil, fmt.Errorf("unsupported state envelope format version %d", envelope.FormatVersion)
+	}
+	state, err := decodeStateData(envelope.Data, envelope.Codec)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !strings.HasPrefix(envelope.Checksum, checksumPrefixSHA256) {
+		return nil, nil, fmt.Errorf("unsupported checksum %q", envelope.Checksum)
+	}
+	if checksum := computeChecksum(state); checksum != strings.TrimPrefix(envelope.Checksum, checksumPrefixSHA256) {
+		return nil, nil, fmt.Errorf("checksum mismatch of state: expected %s, got %s%s", envelope.Checksum, checksumPrefixSHA256, checksum)
+	}
+	return envelope, state, nil
+}
+
+// DecodeEntriesState returns the DNSState data stored in the extension status in any of the historical formats.
+// Chunked states are reassembled from the referenced secrets using the given reader.
+// The returned entry count is -1 if the format does not contain it.
+func DecodeEntriesState(ctx context.Context, reader client.Reader, ext *extapi.Extension) ([]byte, int, error) {
+	if ext.Status.State == nil || ext.Status.State.Raw == nil {
+		return nil, -1, nil
+	}
+	data := ext.Status.State.Raw
+	format := DetectStateFormat(data)
+	if format == StateFormatChunked {
+		var err error
+		data, err = reassembleChunkedEntriesState(ctx, reader, ext, data)
+		if err != nil {
+			return nil, -1, fmt.Errorf("could not reassemble chunked extension state: %w", err)
+		}
+		format = DetectStateFormat(data)
+	}
+
+	switch format {
+	case StateFormatEnvelope:
+		envelope, state, err := DecodeStateEnvelope(data)
+		if err != nil {
+			return nil, -1, fmt.Errorf("could not decode state envelope: %w", err)
+		}
+		return state, envelope.EntryCount, nil
+	case StateFormatCompressed:
+		state, err := DecompressEntriesState(data)
+		if err != nil {
+			return nil, -1, fmt.Errorf("could not decompress extension state: %w", err)
+		}
+		return state, -1, nil
+	case StateFormatPlain:
+		return data, -1, nil
+	default:
+		return nil, -1, fmt.Errorf("unknown format of extension state")
+	}
+}
+
+func encodeStateData(state []byte, codec StateCodec) ([]byte, error) {
+	switch codec {
+	case StateCodecNone:
+		return state, nil
+	case StateCodecBrotli:
+		var buf bytes.Buffer
+		writer := brotli.NewWriter(&buf)
+		if _, err := writer.Write(state); err != nil {
+			return nil, fmt.Errorf("failed writing state data for brotli compression: %w", err)
+		}
+		if err := writer.Close(); err != nil {
+			return nil, fmt.Errorf("failed closing the brotli writer after compressing the state data: %w", err)
+		}
+		return buf.Bytes(), nil
+	case StateCodecZstd:
+		encoder, err := zstd.NewWriter(nil)
+		if err != nil {
+			return nil, err
+		}
+		defer encoder.Close()
+		return encoder.EncodeAll(state, nil), nil
+	default:
+		return nil, fmt.Errorf("unsupported state codec %q", codec)
+	}
+}
+
+func decodeStateData(data []byte, codec StateCodec) ([]byte, error) {
+	switch codec {
+	case StateCodecNone:
+		return data, nil
+	case StateCodecBrotli:
+		var state bytes.Buffer
+		if _, err := state.ReadFrom(brotli.NewReader(bytes.NewReader(data))); err != nil {
+			return nil, fmt.Errorf("failed reading state data for brotli decompression: %w", err)
+		}
+		return state.Bytes(), nil
+	case StateCodecZstd:
+		decoder, err := zstd.NewReader(nil)
+		if err != nil {
+			return nil, err
+		}
+		defer decoder.Close()
+		state, err := decoder.DecodeAll(data, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed reading state data for zstd decompression: %w", err)
+		}
+		return state, nil
+	default:
+		return nil, fmt.Errorf("unsupported state codec %q", codec)
+	}
+}
@@ -0,0 +1,125 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package common
+
+import (
+	"context"
+	"encoding/json"
+
+	extapi "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+var _ = Describe("StateEnvelope", func() {
+	const (
+		v1alpha1State = `{"apiVersion":"dns.extensions.gardener.cloud/v1alpha1","kind":"DNSState","entries":[{"name":"entry1","spec":{"dnsName":"a.example.com"}},{"name":"entry2","spec":{"dnsName":"b.example.com"}}]}`
+		v1alpha2State = `{"apiVersion":"dns.extensions.gardener.cloud/v1alpha2","kind":"DNSState","entries":[{"name":"entry1","spec":{"dnsName":"a.example.com"}},{"name":"entry2","spec":{"dnsName":"b.example.com"}}]}`
+	)
+
+	var extensionWithState = func(raw []byte) *extapi.Extension {
+		return &extapi.Extension{
+			Status: extapi.ExtensionStatus{
+				DefaultStatus: extapi.DefaultStatus{
+					State: &runtime.RawExtension{Raw: raw},
+				},
+			},
+		}
+	}
+
+	DescribeTable("should encode and decode the state envelope",
+		func(codec StateCodec) {
+			data, err := EncodeStateEnvelope([]byte(v1alpha2State), 2, codec)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(DetectStateFormat(data)).To(Equal(StateFormatEnvelope))
+
+			envelope, state, err := DecodeStateEnvelope(data)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(state)).To(Equal(v1alpha2State))
+			Expect(envelope.FormatVersion).To(Equal(StateEnvelopeFormatVersion))
+			Expect(envelope.Codec).To(Equal(codec))
+			Expect(envelope.EntryCount).To(Equal(2))
+			Expect(envelope.Checksum).To(HavePrefix("sha256:"))
+		},
+		Entry("brotli", StateCodecBrotli),
+		Entry("zstd", StateCodecZstd),
+		Entry("none", StateCodecNone),
+	)
+
+	It("should reject an unsupported codec", func() {
+		_, err := EncodeStateEnvelope([]byte(v1alpha2State), 2, "gzip")
+		Expect(err).To(MatchError(`unsupported state codec "gzip"`))
+	})
+
+	It("should detect a checksum mismatch", func() {
+		data, err := EncodeStateEnvelope([]byte(v1alpha2State), 2, StateCodecNone)
+		Expect(err).NotTo(HaveOccurred())
+		wrapped := &wrappedStateEnvelope{}
+		Expect(json.Unmarshal(data, wrapped)).To(Succeed())
+		wrapped.StateEnvelope.Data = []byte(v1alpha1State)
+		data, err = json.Marshal(wrapped)
+		Expect(err).NotTo(HaveOccurred())
+
+		_, _, err = DecodeStateEnvelope(data)
+		Expect(err).To(MatchError(ContainSubstring("checksum mismatch of state")))
+	})
+
+	It("should reject an unknown format version", func() {
+		_, _, err := DecodeStateEnvelope([]byte(`{"stateEnvelope":{"formatVersion":2,"codec":"none"}}`))
+		Expect(err).To(MatchError("unsupported state envelope format version 2"))
+	})
+
+	It("should detect the historical state formats", func() {
+		compressed, err := CompressEntriesState([]byte(v1alpha1State))
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(DetectStateFormat([]byte(v1alpha1State))).To(Equal(StateFormatPlain))
+		Expect(DetectStateFormat(compressed)).To(Equal(StateFormatCompressed))
+		Expect(DetectStateFormat([]byte(`{"chunkedState":{"size":1}}`))).To(Equal(StateFormatChunked))
+		Expect(DetectStateFormat([]byte(`{"foo":"bar"}`))).To(Equal(StateFormatUnknown))
+		Expect(DetectStateFormat([]byte(`compressedState`))).To(Equal(StateFormatUnknown))
+	})
+
+	Describe("#GetExtensionState", func() {
+		ctx := context.Background()
+
+		It("should read all historical formats", func() {
+			compressed, err := CompressEntriesState([]byte(v1alpha1State))
+			Expect(err).NotTo(HaveOccurred())
+			envelope, err := EncodeStateEnvelope([]byte(v1alpha2State), 2, StateCodecZstd)
+			Expect(err).NotTo(HaveOccurred())
+			v1alpha1Envelope, err := EncodeStateEnvelope([]byte(v1alpha1State), 2, StateCodecBrotli)
+			Expect(err).NotTo(HaveOccurred())
+
+			for _, raw := range [][]byte{[]byte(v1alpha1State), compressed, envelope, v1alpha1Envelope} {
+				state, err := GetExtensionState(ctx, nil, extensionWithState(raw))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(state.Entries).To(HaveLen(2))
+				Expect(state.Entries[0].Name).To(Equal("entry1"))
+				Expect(state.Entries[1].Spec.DNSName).To(Equal("b.example.com"))
+			}
+		})
+
+		It("should return an empty state if there is no state", func() {
+			state, err := GetExtensionState(ctx, nil, &extapi.Extension{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(state.Entries).To(BeEmpty())
+		})
+
+		It("should detect an entry count mismatch", func() {
+			envelope, err := EncodeStateEnvelope([]byte(v1alpha2State), 3, StateCodecBrotli)
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = GetExtensionState(ctx, nil, extensionWithState(envelope))
+			Expect(err).To(MatchError("entry count mismatch of extension state: expected 3, got 2"))
+		})
+
+		It("should fail for an unknown format", func() {
+			_, err := GetExtensionState(ctx, nil, extensionWithState([]byte(`{"foo":"bar"}`)))
+			Expect(err).To(MatchError("unknown format of extension state"))
+		})
+	})
+})
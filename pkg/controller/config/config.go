@@ -5,8 +5,15 @@
 package config
 
 import (
+	"cmp"
+	"sync/atomic"
+	"time"
+
 	"github.com/gardener/external-dns-management/pkg/dnsman2/apis/config"
 	"k8s.io/apimachinery/pkg/types"
+
+	policyv1alpha1 "github.com/gardener/gardener-extension-shoot-dns-service/pkg/apis/policy/v1alpha1"
+	"github.com/gardener/gardener-extension-shoot-dns-service/pkg/apis/service/validation"
 )
 
 // DNSService contains configuration for the lifecycle controller of the dns service.
@@ -14,14 +21,116 @@ var DNSService DNSServiceConfig
 
 // DNSServiceConfig contains configuration for the dns service.
 type DNSServiceConfig struct {
-	SeedID                                  string
-	DNSClass                                string
-	RemoteDefaultDomainSecret               *types.NamespacedName
-	ManageDNSProviders                      bool
-	ReplicateDNSProviders                   bool
-	DefaultExternalProviderEntriesQuota     int32
-	DefaultExternalProviderEntriesQuotaMax  int32
-	InternalGCPWorkloadIdentityConfig       config.InternalGCPWorkloadIdentityConfig
-	NextGenerationControllerZoneNameservers map[string]string
-	UseNextGenerationController             bool
+	SeedID                                   string
+	DNSClass                                 string
+	RemoteDefaultDomainSecret                *types.NamespacedName
+	ManageDNSProviders                       bool
+	ReplicateDNSProviders                    bool
+	DefaultExternalProviderEntriesQuota      int32
+	DefaultExternalProviderEntriesQuotaMax   int32
+	DefaultExternalProviderEntriesQuotaRules []QuotaRule
+	ProviderEntriesQuotaMax                  int32
+	ProviderRequestsPerDayMax                int32
+	ProviderBurstMax                         int32
+	InternalGCPWorkloadIdentityConfig        config.InternalGCPWorkloadIdentityConfig
+	ProviderTypeRestrictions                 *validation.ProviderTypeRestrictions
+	NextGenerationControllerZoneNameservers  map[string]string
+	UseNextGenerationController              bool
+	HibernationRedirectTargets               []string
+	LeakedRecordsNamespace                   string
+	OrphanGC                                 OrphanGCConfig
+	Timeouts                                 Timeouts
+}
+
+// QuotaRule selects the DNS entries quota of the default external provider for shoots by purpose, project and labels.
+type QuotaRule struct {
+	// Name is the name of the rule.
+	Name string
+	// Selector selects the shoots the rule applies to.
+	Selector policyv1alpha1.ShootSelector
+	// Quota is the DNS entries quota (0 = unlimited).
+	Quota int32
+	// QuotaMax is the maximum quota shoots can request via annotation (0 = same as Quota).
+	QuotaMax int32
+}
+
+// OrphanGCConfig contains configuration for the garbage collector of orphaned DNS records.
+type OrphanGCConfig struct {
+	// Interval is the interval of the garbage collection runs. The garbage collector is disabled if it is zero.
+	Interval time.Duration
+	// GracePeriod is the minimum age of DNS entries and inventories of leaked records to be considered as orphaned.
+	GracePeriod time.Duration
+	// Delete enables the deletion of orphaned DNS records. Otherwise, they are only reported.
+	Delete bool
+}
+
+const (
+	// DefaultManagedResourceDeletionTimeout is the default timeout for the deletion of the managed resources of a shoot.
+	DefaultManagedResourceDeletionTimeout = 2 * time.Minute
+	// DefaultDNSProviderReadyTimeout is the default timeout for a DNS provider to become ready.
+	DefaultDNSProviderReadyTimeout = 2 * time.Minute
+	// DefaultDNSProviderDeletionTimeout is the default timeout for the deletion of a DNS provider.
+	DefaultDNSProviderDeletionTimeout = 2 * time.Minute
+	// DefaultEntryReconciliationTimeout is the default minimum time to wait for the reconciliation of the DNS entries on restore.
+	DefaultEntryReconciliationTimeout = 3 * time.Minute
+	// DefaultEntryReconciliationMaxTimeout is the default maximum time to wait for the reconciliation of the DNS entries on restore.
+	DefaultEntryReconciliationMaxTimeout = 30 * time.Minute
+)
+
+// Timeouts contains the timeouts of the lifecycle controller. Zero values are replaced by the defaults.
+type Timeouts struct {
+	ManagedResourceDeletion time.Duration
+	DNSProviderReady        time.Duration
+	DNSProviderDeletion     time.Duration
+	EntryReconciliation     time.Duration
+	EntryReconciliationMax  time.Duration
+}
+
+// WithDefaults returns the timeouts with unset values replaced by their defaults.
+func (t Timeouts) WithDefaults() Timeouts {
+	return Timeouts{
+		ManagedResourceDeletion: cmp.Or(t.ManagedResourceDeletion, DefaultManagedResourceDeletionTimeout),
+		DNSProviderReady:        cmp.Or(t.DNSProviderReady, DefaultDNSProviderReadyTimeout),
+		DNSProviderDeletion:     cmp.Or(t.DNSProviderDeletion, DefaultDNSProviderDeletionTimeout),
+		EntryReconciliation:     cmp.Or(t.EntryReconciliation, DefaultEntryReconciliationTimeout),
+		EntryReconciliationMax:  cmp.Or(t.EntryReconciliationMax, DefaultEntryReconciliationMaxTimeout),
+	}
+}
+
+// ReloadableSettings contains the settings of the DNSServiceConfig which can be changed at runtime by reloading the
+// configuration file. They are applied on the next reconciliation of an extension.
+type ReloadableSettings struct {
+	DefaultExternalProviderEntriesQuota      int32
+	DefaultExternalProviderEntriesQuotaMax   int32
+	DefaultExternalProviderEntriesQuotaRules []QuotaRule
+	ProviderEntriesQuotaMax                  int32
+	ProviderRequestsPerDayMax                int32
+	ProviderBurstMax                         int32
+	NextGenerationControllerZoneNameservers  map[string]string
+	Timeouts                                 Timeouts
+}
+
+var reloadedSettings atomic.Pointer[ReloadableSettings]
+
+// SetReloadedSettings stores the settings of a reloaded configuration file.
+func SetReloadedSettings(settings ReloadableSettings) {
+	reloadedSettings.Store(&settings)
+}
+
+// WithReloadedSettings returns a copy of the given configuration with the settings of the last reload of the
+// configuration file applied, if any.
+func WithReloadedSettings(cfg DNSServiceConfig) DNSServiceConfig {
+	settings := reloadedSettings.Load()
+	if settings == nil {
+		return cfg
+	}
+	cfg.DefaultExternalProviderEntriesQuota = settings.DefaultExternalProviderEntriesQuota
+	cfg.DefaultExternalProviderEntriesQuotaMax = settings.DefaultExternalProviderEntriesQuotaMax
+	cfg.DefaultExternalProviderEntriesQuotaRules = settings.DefaultExternalProviderEntriesQuotaRules
+	cfg.ProviderEntriesQuotaMax = settings.ProviderEntriesQuotaMax
+	cfg.ProviderRequestsPerDayMax = settings.ProviderRequestsPerDayMax
+	cfg.ProviderBurstMax = settings.ProviderBurstMax
+	cfg.NextGenerationControllerZoneNameservers = settings.NextGenerationControllerZoneNameservers
+	cfg.Timeouts = settings.Timeouts
+	return cfg
 }
@@ -0,0 +1,102 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"fmt"
+
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	"k8s.io/utils/ptr"
+
+	apisconfig "github.com/gardener/gardener-extension-shoot-dns-service/pkg/apis/config"
+	policyv1alpha1 "github.com/gardener/gardener-extension-shoot-dns-service/pkg/apis/policy/v1alpha1"
+	policyhelper "github.com/gardener/gardener-extension-shoot-dns-service/pkg/apis/policy/v1alpha1/helper"
+)
+
+const (
+	// QuotaSourceDefault is the quota source for the configured default quota.
+	QuotaSourceDefault = "default"
+	// QuotaSourceRulePrefix is the prefix of the quota source for a quota rule of the controller configuration.
+	QuotaSourceRulePrefix = "rule:"
+)
+
+// QuotaLimits are the DNS entries quota of the default external provider of a shoot and the maximum quota the shoot
+// can request via annotation.
+type QuotaLimits struct {
+	// Quota is the DNS entries quota (0 = unlimited).
+	Quota int32
+	// QuotaMax is the maximum quota shoots can request via annotation (0 = same as Quota).
+	QuotaMax int32
+	// Source is the origin of the limits, `default` or `rule:<name>`.
+	Source string
+}
+
+// DefaultExternalProviderQuotaLimits returns the limits of the DNS entries quota of the default external provider for
+// the shoot of the given project. The first matching quota rule overwrites the default quota and its maximum.
+// It is used by both the lifecycle controller and the admission webhook, so that both apply the same limits.
+func (c DNSServiceConfig) DefaultExternalProviderQuotaLimits(project string, shoot *gardencorev1beta1.Shoot) (QuotaLimits, error) {
+	for _, rule := range c.DefaultExternalProviderEntriesQuotaRules {
+		matches, err := policyhelper.Matches(rule.Selector, project, shoot)
+		if err != nil {
+			return QuotaLimits{}, fmt.Errorf("invalid selector of quota rule %s: %w", rule.Name, err)
+		}
+		if matches {
+			return QuotaLimits{Quota: rule.Quota, QuotaMax: rule.QuotaMax, Source: QuotaSourceRulePrefix + rule.Name}, nil
+		}
+	}
+	return QuotaLimits{
+		Quota:    c.DefaultExternalProviderEntriesQuota,
+		QuotaMax: c.DefaultExternalProviderEntriesQuotaMax,
+		Source:   QuotaSourceDefault,
+	}, nil
+}
+
+// QuotaRulesSelectProjects returns true if a quota rule selects shoots by project.
+func (c DNSServiceConfig) QuotaRulesSelectProjects() bool {
+	for _, rule := range c.DefaultExternalProviderEntriesQuotaRules {
+		if len(rule.Selector.Projects) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// ApplyQuotaConfiguration applies the default external provider quotas and the quota limits of additional providers
+// explicitly set in the quota section of the controller configuration file.
+func (c *DNSServiceConfig) ApplyQuotaConfiguration(q *apisconfig.QuotaConfiguration) {
+	if q == nil {
+		return
+	}
+	if q.DefaultExternalProviderEntries != nil {
+		c.DefaultExternalProviderEntriesQuota = *q.DefaultExternalProviderEntries
+	}
+	if q.DefaultExternalProviderEntriesMax != nil {
+		c.DefaultExternalProviderEntriesQuotaMax = *q.DefaultExternalProviderEntriesMax
+	}
+	if q.ProviderEntriesMax != nil {
+		c.ProviderEntriesQuotaMax = *q.ProviderEntriesMax
+	}
+	if q.ProviderRequestsPerDayMax != nil {
+		c.ProviderRequestsPerDayMax = *q.ProviderRequestsPerDayMax
+	}
+	if q.ProviderBurstMax != nil {
+		c.ProviderBurstMax = *q.ProviderBurstMax
+	}
+	if q.DefaultExternalProviderEntriesRules != nil {
+		c.DefaultExternalProviderEntriesQuotaRules = nil
+		for _, rule := range q.DefaultExternalProviderEntriesRules {
+			c.DefaultExternalProviderEntriesQuotaRules = append(c.DefaultExternalProviderEntriesQuotaRules, QuotaRule{
+				Name: rule.Name,
+				Selector: policyv1alpha1.ShootSelector{
+					Projects:      rule.Projects,
+					Purposes:      rule.Purposes,
+					LabelSelector: rule.LabelSelector,
+				},
+				Quota:    rule.DefaultExternalProviderEntries,
+				QuotaMax: ptr.Deref(rule.DefaultExternalProviderEntriesMax, 0),
+			})
+		}
+	}
+}
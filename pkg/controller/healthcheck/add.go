@@ -23,6 +23,12 @@ import (
 // DefaultAddOptions contains configuration for the health check controller.
 var DefaultAddOptions = healthcheck.DefaultAddArgs{}
 
+// DefaultDNSEntriesCheckConfig contains the configuration for the DNSEntry health check.
+var DefaultDNSEntriesCheckConfig = DNSEntriesCheckConfig{
+	FailureThreshold:    1,
+	MaxReportedFailures: 5,
+}
+
 // RegisterHealthChecks registers health checks for each extension resource
 // HealthChecks are grouped by extension (e.g worker), extension.type (e.g aws) and  Health Check Type (e.g SystemComponentsHealthy)
 func RegisterHealthChecks(_ context.Context, mgr manager.Manager) error {
@@ -48,6 +54,14 @@ func RegisterHealthChecks(_ context.Context, mgr manager.Manager) error {
 				HealthCheck:   general.CheckManagedResource(lifecycle.SeedResourcesName),
 				PreCheckFunc:  preCheckFunc,
 			},
+			{
+				ConditionType: string(gardencorev1beta1.ShootControlPlaneHealthy),
+				HealthCheck:   CheckDNSProviders(),
+			},
+			{
+				ConditionType: string(gardencorev1beta1.ShootSystemComponentsHealthy),
+				HealthCheck:   CheckDNSEntries(DefaultDNSEntriesCheckConfig),
+			},
 		},
 		sets.New[gardencorev1beta1.ConditionType](),
 	)
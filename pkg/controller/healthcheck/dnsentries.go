@@ -0,0 +1,123 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package healthcheck
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	dnsv1alpha1 "github.com/gardener/external-dns-management/pkg/apis/dns/v1alpha1"
+	"github.com/gardener/gardener/extensions/pkg/controller/healthcheck"
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
+	"github.com/go-logr/logr"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/gardener/gardener-extension-shoot-dns-service/pkg/controller/common"
+)
+
+// dnsEntriesProgressingThreshold is the duration after which failing DNS entries let the condition fail.
+const dnsEntriesProgressingThreshold = 10 * time.Minute
+
+// DNSEntriesCheckConfig contains the configuration of the DNSEntry health check.
+type DNSEntriesCheckConfig struct {
+	// FailureThreshold is the number of failing DNS entries from which on the health check reports an unhealthy
+	// condition. A value of 0 disables the health check.
+	FailureThreshold int
+	// MaxReportedFailures is the maximum number of failing DNS entries listed in the condition message.
+	MaxReportedFailures int
+}
+
+// DNSEntriesHealthChecker contains all the information for the DNSEntry HealthCheck
+type DNSEntriesHealthChecker struct {
+	logger logr.Logger
+	client client.Client
+	config DNSEntriesCheckConfig
+}
+
+var (
+	_ healthcheck.HealthCheck  = (*DNSEntriesHealthChecker)(nil)
+	_ healthcheck.SourceClient = (*DNSEntriesHealthChecker)(nil)
+)
+
+// CheckDNSEntries is a healthCheck function to check the DNSEntries of the shoot in the Seed cluster.
+func CheckDNSEntries(config DNSEntriesCheckConfig) *DNSEntriesHealthChecker {
+	return &DNSEntriesHealthChecker{
+		config: config,
+	}
+}
+
+// InjectSourceClient injects the seed/source client
+func (healthChecker *DNSEntriesHealthChecker) InjectSourceClient(client client.Client) {
+	healthChecker.client = client
+}
+
+// SetLoggerSuffix injects the logger
+func (healthChecker *DNSEntriesHealthChecker) SetLoggerSuffix(provider, extension string) {
+	healthChecker.logger = log.Log.WithName("healthcheck-dns-entries").WithValues("provider", provider, "extension", extension)
+}
+
+// Check executes the health check
+func (healthChecker *DNSEntriesHealthChecker) Check(ctx context.Context, request types.NamespacedName) (*healthcheck.SingleCheckResult, error) {
+	if healthChecker.config.FailureThreshold <= 0 {
+		return &healthcheck.SingleCheckResult{
+			Status: gardencorev1beta1.ConditionTrue,
+		}, nil
+	}
+
+	ex := &extensionsv1alpha1.Extension{ObjectMeta: metav1.ObjectMeta{Namespace: request.Namespace, Name: request.Name}}
+	entries, err := common.NewShootDNSEntriesHelper(ctx, healthChecker.client, ex).List()
+	if err != nil {
+		err := fmt.Errorf("check DNS entries failed. Unable to list DNS entries in namespace %q: %w", request.Namespace, err)
+		healthChecker.logger.Error(err, "Health check failed")
+		return nil, err
+	}
+
+	return checkDNSEntries(entries, healthChecker.config), nil
+}
+
+func checkDNSEntries(entries []dnsv1alpha1.DNSEntry, config DNSEntriesCheckConfig) *healthcheck.SingleCheckResult {
+	var failing []dnsv1alpha1.DNSEntry
+	for _, entry := range entries {
+		switch entry.Status.State {
+		case dnsv1alpha1.STATE_ERROR, dnsv1alpha1.STATE_INVALID, dnsv1alpha1.STATE_STALE:
+			failing = append(failing, entry)
+		}
+	}
+
+	if len(failing) == 0 || len(failing) < config.FailureThreshold {
+		return &healthcheck.SingleCheckResult{
+			Status: gardencorev1beta1.ConditionTrue,
+		}
+	}
+
+	slices.SortFunc(failing, func(a, b dnsv1alpha1.DNSEntry) int {
+		return strings.Compare(a.Spec.DNSName, b.Spec.DNSName)
+	})
+	var details []string
+	for _, entry := range failing[:min(len(failing), max(config.MaxReportedFailures, 1))] {
+		detail := fmt.Sprintf("%s (%s)", entry.Spec.DNSName, entry.Status.State)
+		if msg := ptr.Deref(entry.Status.Message, ""); msg != "" {
+			detail += ": " + msg
+		}
+		details = append(details, detail)
+	}
+	if more := len(failing) - len(details); more > 0 {
+		details = append(details, fmt.Sprintf("and %d more", more))
+	}
+
+	return &healthcheck.SingleCheckResult{
+		Status:               gardencorev1beta1.ConditionProgressing,
+		Detail:               fmt.Sprintf("%d of %d DNS entries are failing: %s", len(failing), len(entries), strings.Join(details, "; ")),
+		ProgressingThreshold: new(dnsEntriesProgressingThreshold),
+	}
+}
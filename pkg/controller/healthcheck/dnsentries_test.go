@@ -0,0 +1,136 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package healthcheck
+
+import (
+	"context"
+	"fmt"
+
+	dnsv1alpha1 "github.com/gardener/external-dns-management/pkg/apis/dns/v1alpha1"
+	extensionscontroller "github.com/gardener/gardener/extensions/pkg/controller"
+	"github.com/gardener/gardener/extensions/pkg/controller/healthcheck"
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/gardener/gardener-extension-shoot-dns-service/pkg/controller/common"
+)
+
+var _ = Describe("DNSEntriesHealthChecker", func() {
+	const (
+		namespace       = "shoot--foo--bar"
+		clusterIdentity = "shoot--foo--bar-78897def-5208-4feb-b0f0-015950eadbb9-test-landscape"
+	)
+
+	var (
+		ctx        context.Context
+		seedClient client.Client
+		request    = types.NamespacedName{Namespace: namespace, Name: "shoot-dns-service"}
+
+		createEntry = func(name, state string, message *string, shootID string) {
+			entry := &dnsv1alpha1.DNSEntry{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      name,
+					Namespace: namespace,
+					Labels:    map[string]string{common.ShootDNSEntryLabelKey: shootID},
+				},
+				Spec: dnsv1alpha1.DNSEntrySpec{
+					DNSName: name + ".foo.bar.external.example.com",
+					Targets: []string{"1.2.3.4"},
+				},
+				Status: dnsv1alpha1.DNSEntryStatus{
+					State:   state,
+					Message: message,
+				},
+			}
+			Expect(seedClient.Create(ctx, entry)).To(Succeed())
+		}
+
+		check = func(config DNSEntriesCheckConfig) *healthcheck.SingleCheckResult {
+			checker := CheckDNSEntries(config)
+			checker.InjectSourceClient(seedClient)
+			checker.SetLoggerSuffix("", "shoot-dns-service")
+			result, err := checker.Check(ctx, request)
+			Expect(err).NotTo(HaveOccurred())
+			return result
+		}
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		scheme := runtime.NewScheme()
+		Expect(dnsv1alpha1.AddToScheme(scheme)).To(Succeed())
+		Expect(extensionscontroller.AddToScheme(scheme)).To(Succeed())
+		seedClient = fake.NewClientBuilder().WithScheme(scheme).Build()
+
+		cluster := &extensionsv1alpha1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Name: namespace},
+			Spec: extensionsv1alpha1.ClusterSpec{
+				Shoot: runtime.RawExtension{
+					Object: &gardencorev1beta1.Shoot{
+						TypeMeta: metav1.TypeMeta{
+							APIVersion: "core.gardener.cloud/v1beta1",
+							Kind:       "Shoot",
+						},
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      "bar",
+							Namespace: "garden-foo",
+						},
+						Status: gardencorev1beta1.ShootStatus{
+							ClusterIdentity: new(clusterIdentity),
+						},
+					},
+				},
+			},
+		}
+		Expect(seedClient.Create(ctx, cluster)).To(Succeed())
+
+		shootID := common.ShortenID(clusterIdentity, 63)
+		createEntry("a", dnsv1alpha1.STATE_READY, nil, shootID)
+		createEntry("b", dnsv1alpha1.STATE_PENDING, nil, shootID)
+		createEntry("other", dnsv1alpha1.STATE_ERROR, new("not my entry"), "other-shoot")
+	})
+
+	It("should report healthy if no entry is failing", func() {
+		Expect(check(DefaultDNSEntriesCheckConfig).Status).To(Equal(gardencorev1beta1.ConditionTrue))
+	})
+
+	It("should report the failing entries once the threshold is reached", func() {
+		shootID := common.ShortenID(clusterIdentity, 63)
+		createEntry("e", dnsv1alpha1.STATE_ERROR, new("no matching provider"), shootID)
+		createEntry("d", dnsv1alpha1.STATE_INVALID, new("invalid target"), shootID)
+		createEntry("c", dnsv1alpha1.STATE_STALE, nil, shootID)
+
+		Expect(check(DNSEntriesCheckConfig{FailureThreshold: 4, MaxReportedFailures: 5}).Status).To(Equal(gardencorev1beta1.ConditionTrue))
+
+		Expect(check(DNSEntriesCheckConfig{FailureThreshold: 3, MaxReportedFailures: 2})).To(PointTo(MatchFields(IgnoreExtras, Fields{
+			"Status":               Equal(gardencorev1beta1.ConditionProgressing),
+			"Detail":               Equal("3 of 5 DNS entries are failing: c.foo.bar.external.example.com (Stale); d.foo.bar.external.example.com (Invalid): invalid target; and 1 more"),
+			"ProgressingThreshold": PointTo(Equal(dnsEntriesProgressingThreshold)),
+		})))
+	})
+
+	It("should be disabled with a threshold of 0", func() {
+		createEntry("e", dnsv1alpha1.STATE_ERROR, nil, common.ShortenID(clusterIdentity, 63))
+
+		Expect(check(DNSEntriesCheckConfig{FailureThreshold: 0, MaxReportedFailures: 5}).Status).To(Equal(gardencorev1beta1.ConditionTrue))
+	})
+
+	It("should list all failing entries if below the maximum", func() {
+		shootID := common.ShortenID(clusterIdentity, 63)
+		for i := range 2 {
+			createEntry(fmt.Sprintf("f%d", i), dnsv1alpha1.STATE_ERROR, nil, shootID)
+		}
+
+		Expect(check(DefaultDNSEntriesCheckConfig).Detail).To(Equal("2 of 4 DNS entries are failing: f0.foo.bar.external.example.com (Error); f1.foo.bar.external.example.com (Error)"))
+	})
+})
@@ -0,0 +1,124 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package healthcheck
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	dnsv1alpha1 "github.com/gardener/external-dns-management/pkg/apis/dns/v1alpha1"
+	"github.com/gardener/gardener/extensions/pkg/controller/healthcheck"
+	v1beta1helper "github.com/gardener/gardener/pkg/api/core/v1beta1/helper"
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	v1beta1constants "github.com/gardener/gardener/pkg/apis/core/v1beta1/constants"
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/gardener/gardener-extension-shoot-dns-service/pkg/controller/lifecycle"
+)
+
+// dnsProvidersProgressingThreshold is the duration after which a DNS provider which is not ready yet lets the
+// condition fail.
+const dnsProvidersProgressingThreshold = 5 * time.Minute
+
+// DNSProvidersHealthChecker contains all the information for the DNSProvider HealthCheck
+type DNSProvidersHealthChecker struct {
+	logger logr.Logger
+	client client.Client
+}
+
+var (
+	_ healthcheck.HealthCheck  = (*DNSProvidersHealthChecker)(nil)
+	_ healthcheck.SourceClient = (*DNSProvidersHealthChecker)(nil)
+)
+
+// CheckDNSProviders is a healthCheck function to check the "external" and the additional DNSProviders
+// managed by the extension in the shoot namespace of the Seed cluster.
+func CheckDNSProviders() *DNSProvidersHealthChecker {
+	return &DNSProvidersHealthChecker{}
+}
+
+// InjectSourceClient injects the seed/source client
+func (healthChecker *DNSProvidersHealthChecker) InjectSourceClient(client client.Client) {
+	healthChecker.client = client
+}
+
+// SetLoggerSuffix injects the logger
+func (healthChecker *DNSProvidersHealthChecker) SetLoggerSuffix(provider, extension string) {
+	healthChecker.logger = log.Log.WithName("healthcheck-dns-providers").WithValues("provider", provider, "extension", extension)
+}
+
+// Check executes the health check
+func (healthChecker *DNSProvidersHealthChecker) Check(ctx context.Context, request types.NamespacedName) (*healthcheck.SingleCheckResult, error) {
+	providerList := &dnsv1alpha1.DNSProviderList{}
+	if err := healthChecker.client.List(ctx, providerList, client.InNamespace(request.Namespace)); err != nil {
+		err := fmt.Errorf("check DNS providers failed. Unable to list DNS providers in namespace %q: %w", request.Namespace, err)
+		healthChecker.logger.Error(err, "Health check failed")
+		return nil, err
+	}
+
+	return checkDNSProviders(providerList.Items), nil
+}
+
+func checkDNSProviders(providers []dnsv1alpha1.DNSProvider) *healthcheck.SingleCheckResult {
+	var (
+		failed  bool
+		details []string
+		codes   []gardencorev1beta1.ErrorCode
+		sorted  = slices.Clone(providers)
+	)
+
+	slices.SortFunc(sorted, func(a, b dnsv1alpha1.DNSProvider) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+	for _, provider := range sorted {
+		if !isManagedProvider(provider) {
+			continue
+		}
+		err := lifecycle.CheckDNSProvider(&provider)
+		if err == nil {
+			continue
+		}
+		if state := provider.Status.State; state == dnsv1alpha1.STATE_ERROR || state == dnsv1alpha1.STATE_INVALID {
+			failed = true
+		}
+		details = append(details, fmt.Sprintf("DNS provider %q (type %s) is not ready: %s", provider.Name, provider.Spec.Type, err))
+		for _, code := range v1beta1helper.ExtractErrorCodes(err) {
+			if !slices.Contains(codes, code) {
+				codes = append(codes, code)
+			}
+		}
+	}
+
+	if len(details) == 0 {
+		return &healthcheck.SingleCheckResult{
+			Status: gardencorev1beta1.ConditionTrue,
+		}
+	}
+
+	// providers which are still being reconciled only degrade the condition, providers in an error state let it fail
+	result := &healthcheck.SingleCheckResult{
+		Status:               gardencorev1beta1.ConditionProgressing,
+		Detail:               strings.Join(details, "; "),
+		Codes:                codes,
+		ProgressingThreshold: new(dnsProvidersProgressingThreshold),
+	}
+	if failed {
+		result.Status = gardencorev1beta1.ConditionFalse
+		result.ProgressingThreshold = nil
+	}
+	return result
+}
+
+// isManagedProvider returns true for the "external" and the additional DNS providers deployed by the extension.
+func isManagedProvider(provider dnsv1alpha1.DNSProvider) bool {
+	return provider.Name == lifecycle.ExternalDNSProviderName ||
+		provider.Labels[v1beta1constants.GardenRole] == lifecycle.DNSProviderRoleAdditional
+}
@@ -0,0 +1,114 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package healthcheck
+
+import (
+	"context"
+
+	dnsv1alpha1 "github.com/gardener/external-dns-management/pkg/apis/dns/v1alpha1"
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	v1beta1constants "github.com/gardener/gardener/pkg/apis/core/v1beta1/constants"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/gardener/gardener-extension-shoot-dns-service/pkg/controller/lifecycle"
+)
+
+var _ = Describe("DNSProvidersHealthChecker", func() {
+	const namespace = "shoot--foo--bar"
+
+	var (
+		ctx     context.Context
+		checker *DNSProvidersHealthChecker
+
+		newProvider = func(name, state string, message *string, labels map[string]string) *dnsv1alpha1.DNSProvider {
+			return &dnsv1alpha1.DNSProvider{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      name,
+					Namespace: namespace,
+					Labels:    labels,
+				},
+				Spec: dnsv1alpha1.DNSProviderSpec{
+					Type: "aws-route53",
+				},
+				Status: dnsv1alpha1.DNSProviderStatus{
+					State:   state,
+					Message: message,
+				},
+			}
+		}
+		additionalLabels = map[string]string{v1beta1constants.GardenRole: lifecycle.DNSProviderRoleAdditional}
+	)
+
+	setup := func(providers ...*dnsv1alpha1.DNSProvider) {
+		scheme := runtime.NewScheme()
+		Expect(dnsv1alpha1.AddToScheme(scheme)).To(Succeed())
+		builder := fake.NewClientBuilder().WithScheme(scheme)
+		for _, provider := range providers {
+			builder = builder.WithObjects(provider)
+		}
+		checker.InjectSourceClient(builder.Build())
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		checker = CheckDNSProviders()
+		checker.SetLoggerSuffix("", "shoot-dns-service")
+	})
+
+	It("should report healthy if all managed providers are ready", func() {
+		setup(
+			newProvider("external", dnsv1alpha1.STATE_READY, nil, nil),
+			newProvider("additional", dnsv1alpha1.STATE_READY, nil, additionalLabels),
+			newProvider("unmanaged", dnsv1alpha1.STATE_ERROR, new("foo"), nil),
+		)
+
+		result, err := checker.Check(ctx, types.NamespacedName{Namespace: namespace, Name: "shoot-dns-service"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.Status).To(Equal(gardencorev1beta1.ConditionTrue))
+	})
+
+	It("should report healthy if there are no providers", func() {
+		setup()
+
+		result, err := checker.Check(ctx, types.NamespacedName{Namespace: namespace, Name: "shoot-dns-service"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.Status).To(Equal(gardencorev1beta1.ConditionTrue))
+	})
+
+	It("should report progressing for providers not ready yet", func() {
+		setup(
+			newProvider("external", dnsv1alpha1.STATE_READY, nil, nil),
+			newProvider("additional", dnsv1alpha1.STATE_PENDING, nil, additionalLabels),
+		)
+
+		result, err := checker.Check(ctx, types.NamespacedName{Namespace: namespace, Name: "shoot-dns-service"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.Status).To(Equal(gardencorev1beta1.ConditionProgressing))
+		Expect(result.Detail).To(Equal(`DNS provider "additional" (type aws-route53) is not ready: state Pending`))
+		Expect(result.ProgressingThreshold).To(PointTo(Equal(dnsProvidersProgressingThreshold)))
+		Expect(result.Codes).To(BeEmpty())
+	})
+
+	It("should report failed with message and error codes for providers in error state", func() {
+		setup(
+			newProvider("external", dnsv1alpha1.STATE_ERROR, new("AccessDenied: permission missing"), nil),
+			newProvider("additional", dnsv1alpha1.STATE_PENDING, nil, additionalLabels),
+		)
+
+		result, err := checker.Check(ctx, types.NamespacedName{Namespace: namespace, Name: "shoot-dns-service"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.Status).To(Equal(gardencorev1beta1.ConditionFalse))
+		Expect(result.Detail).To(Equal(`DNS provider "additional" (type aws-route53) is not ready: state Pending; ` +
+			`DNS provider "external" (type aws-route53) is not ready: state Error: AccessDenied: permission missing`))
+		Expect(result.ProgressingThreshold).To(BeNil())
+		Expect(result.Codes).To(ConsistOf(gardencorev1beta1.ErrorInfraUnauthorized))
+	})
+})
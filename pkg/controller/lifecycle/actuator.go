@@ -46,9 +46,11 @@ import (
 	"github.com/gardener/gardener-extension-shoot-dns-service/imagevector"
 	"github.com/gardener/gardener-extension-shoot-dns-service/pkg/apis/helper"
 	apisservice "github.com/gardener/gardener-extension-shoot-dns-service/pkg/apis/service"
+	servicev1alpha1 "github.com/gardener/gardener-extension-shoot-dns-service/pkg/apis/service/v1alpha1"
 	"github.com/gardener/gardener-extension-shoot-dns-service/pkg/apis/service/validation"
 	"github.com/gardener/gardener-extension-shoot-dns-service/pkg/controller/common"
 	"github.com/gardener/gardener-extension-shoot-dns-service/pkg/controller/config"
+	"github.com/gardener/gardener-extension-shoot-dns-service/pkg/controller/metrics"
 	"github.com/gardener/gardener-extension-shoot-dns-service/pkg/service"
 )
 
@@ -69,17 +71,17 @@ const (
 	ShootDNSServiceUseRemoteDefaultDomainLabel = "service.dns.extensions.gardener.cloud/use-remote-default-domain"
 	// DropDNSEntriesStateOnMigration is the annotation key for dropping the state of DNSEntries during migration.
 	// Activated by setting the annotation value to "true".
-	// This may be helpful if the state is too large to be stored in the extension state.
-	// In this case, it may be a fall-back option, to set this annotation value to "true" and run the migration without
-	// DNSEntries in the extension status state. Nothing should be lost, but if source objects are deleted during the migration,
-	// the deletion of the DNS records cannot be guaranteed.
+	// Deprecated: Large states are split into chunks stored in secrets referenced in the status resources of the
+	// extension, so that dropping the state should not be needed anymore.
+	// If set, the migration runs without DNSEntries in the extension status state. Nothing should be lost, but if
+	// source objects are deleted during the migration, the deletion of the DNS records cannot be guaranteed.
 	DropDNSEntriesStateOnMigration = "drop-dns-entries-state-on-migration"
 	// ShootDNSServiceUseNextGenerationController is the label key for marking a seed to use the next generation DNS controller.
 	// The label values "true" or "false" specify the default value, if not specified otherwise in the DNSConfig with the field `useNextGenerationController`.
 	// The values "force-true" and "force-false" can be used to override the DNSConfig setting for all shoots in the seed.
-	ShootDNSServiceUseNextGenerationController = "service.dns.extensions.gardener.cloud/use-next-generation-controller"
+	ShootDNSServiceUseNextGenerationController = service.UseNextGenerationControllerSeedLabel
 	// ShootDNSServiceDefaultExternalProviderEntriesQuotaAnnotation is the annotation key to overwrite the DNSEntries quota for the default external provider.
-	ShootDNSServiceDefaultExternalProviderEntriesQuotaAnnotation = "service.dns.extensions.gardener.cloud/default-external-provider-entries-quota"
+	ShootDNSServiceDefaultExternalProviderEntriesQuotaAnnotation = service.DefaultExternalProviderEntriesQuotaAnnotation
 
 	// NextGenerationTargetClass is the target class for the next generation DNS controller.
 	NextGenerationTargetClass = "gardendns-next-gen"
@@ -88,7 +90,8 @@ const (
 type controllerMode int
 
 const (
-	// controllerModeNormal is the normal operating mode of the shoot-dns-service controller manager: all controller are enabled or scaled down if hibernated.
+	// controllerModeNormal is the normal operating mode of the shoot-dns-service controller manager: all controller are enabled.
+	// If the shoot is hibernated, the mode depends on the hibernation mode of the DNSConfig (see hibernatedControllerMode).
 	controllerModeNormal controllerMode = iota
 	// controllerModeCleaningUp is the mode where the shoot-dns-service controller manager is cleaning up DNS entries in the control plane: only control plane controllers are enabled.
 	controllerModeCleaningUp
@@ -96,6 +99,19 @@ const (
 	controllerModeScaledDown
 )
 
+func (m controllerMode) String() string {
+	switch m {
+	case controllerModeNormal:
+		return "normal"
+	case controllerModeCleaningUp:
+		return "cleaningUp"
+	case controllerModeScaledDown:
+		return "scaledDown"
+	default:
+		return strconv.Itoa(int(m))
+	}
+}
+
 type extensionContext struct {
 	ctx          context.Context
 	log          logr.Logger
@@ -103,18 +119,32 @@ type extensionContext struct {
 	dnsconfig    *apisservice.DNSConfig
 	globalConfig config.DNSServiceConfig
 	cluster      *controller.Cluster
+	policy       *appliedPolicy
 }
 
 func (exCtx *extensionContext) useNextGenerationController() bool {
+	value := ""
+	if exCtx.cluster != nil && exCtx.cluster.Seed != nil {
+		value = exCtx.cluster.Seed.Labels[ShootDNSServiceUseNextGenerationController]
+	}
+
+	if exCtx.policy != nil && exCtx.policy.settings.UseNextGenerationController != nil {
+		// a policy overwrites the global default and the default of the seed label, but not a forced value
+		switch value {
+		case "force-true":
+			return true
+		case "force-false":
+			return false
+		default:
+			return ptr.Deref(exCtx.dnsconfig.UseNextGenerationController, *exCtx.policy.settings.UseNextGenerationController)
+		}
+	}
+
 	if exCtx.globalConfig.UseNextGenerationController {
 		// if set globally, still allow to disable it in the extension provider config
 		return ptr.Deref(exCtx.dnsconfig.UseNextGenerationController, true)
 	}
 
-	value := ""
-	if exCtx.cluster != nil && exCtx.cluster.Seed != nil {
-		value = exCtx.cluster.Seed.Labels[ShootDNSServiceUseNextGenerationController]
-	}
 	switch value {
 	case "force-true":
 		return true
@@ -128,7 +158,7 @@ func (exCtx *extensionContext) useNextGenerationController() bool {
 }
 
 // NewActuator returns an actuator responsible for Extension resources.
-func NewActuator(c client.Client, scheme *runtime.Scheme, chartRenderer chartrenderer.Interface, config config.DNSServiceConfig,
+func NewActuator(c client.Client, watchClient client.WithWatch, scheme *runtime.Scheme, chartRenderer chartrenderer.Interface, config config.DNSServiceConfig,
 	managedResourcesAccess managedResourcesAccess,
 	shootClientAccess shootClientAccess,
 	newProviderDeployWaiterFactory *newProviderDeployWaiterFactory,
@@ -136,6 +166,7 @@ func NewActuator(c client.Client, scheme *runtime.Scheme, chartRenderer chartren
 ) extension.Actuator {
 	return &actuator{
 		client:                         c,
+		watchClient:                    watchClient,
 		config:                         config,
 		renderer:                       chartRenderer,
 		decoder:                        serializer.NewCodecFactory(scheme, serializer.EnableStrict).UniversalDecoder(),
@@ -147,10 +178,11 @@ func NewActuator(c client.Client, scheme *runtime.Scheme, chartRenderer chartren
 }
 
 type actuator struct {
-	config   config.DNSServiceConfig
-	client   client.Client
-	renderer chartrenderer.Interface
-	decoder  runtime.Decoder
+	config      config.DNSServiceConfig
+	client      client.Client
+	watchClient client.WithWatch
+	renderer    chartrenderer.Interface
+	decoder     runtime.Decoder
 
 	managedResourceAccess          managedResourcesAccess
 	shootClientAccess              shootClientAccess
@@ -186,7 +218,15 @@ func (a *actuator) Reconcile(ctx context.Context, log logr.Logger, ex *extension
 	if err := a.updateExtensionAnnotation(exCtx); err != nil {
 		return err
 	}
-	return a.createOrUpdateDNSProviders(exCtx)
+	err = a.createOrUpdateDNSProviders(exCtx)
+	if err == nil {
+		err = a.reconcileHibernationRedirect(exCtx)
+	}
+	// the provider status is also updated on failure, as it explains failing DNS providers
+	if statusErr := a.updateProviderStatus(exCtx); statusErr != nil {
+		log.Info("updating provider status failed", "error", statusErr, "namespace", ex.Namespace)
+	}
+	return err
 }
 
 func (a *actuator) extractDNSConfig(ex *extensionsv1alpha1.Extension) (*apisservice.DNSConfig, error) {
@@ -195,7 +235,7 @@ func (a *actuator) extractDNSConfig(ex *extensionsv1alpha1.Extension) (*apisserv
 		if _, _, err := a.decoder.Decode(ex.Spec.ProviderConfig.Raw, nil, dnsConfig); err != nil {
 			return nil, fmt.Errorf("failed to decode provider config: %+v", err)
 		}
-		if errs := validation.ValidateDNSConfig(dnsConfig, nil, nil); len(errs) > 0 {
+		if _, errs := validation.ValidateDNSConfig(dnsConfig, "", nil, nil, nil); len(errs) > 0 {
 			return nil, errs.ToAggregate()
 		}
 	}
@@ -247,7 +287,11 @@ func (a *actuator) Delete(ctx context.Context, log logr.Logger, ex *extensionsv1
 	if err != nil {
 		return err
 	}
-	return a.delete(exCtx, false)
+	if err := a.delete(exCtx, false); err != nil {
+		return err
+	}
+	metrics.DeleteShoot(ex.Namespace)
+	return nil
 }
 
 // ForceDelete the Extension resource.
@@ -270,7 +314,9 @@ func (a *actuator) ForceDelete(ctx context.Context, log logr.Logger, ex *extensi
 	}
 
 	entriesHelper := common.NewShootDNSEntriesHelper(ctx, a.client, ex)
-	if err := entriesHelper.ForceDeleteAll(); err != nil {
+	if err := entriesHelper.ForceDeleteAll(func(entries []dnsv1alpha1.DNSEntry) error {
+		return a.recordLeakedRecords(exCtx, entries)
+	}); err != nil {
 		return fmt.Errorf("force deletion of DNSEntries failed: %w", err)
 	}
 
@@ -285,6 +331,7 @@ func (a *actuator) ForceDelete(ctx context.Context, log logr.Logger, ex *extensi
 		}
 	}
 
+	metrics.DeleteShoot(ex.Namespace)
 	return nil
 }
 
@@ -329,7 +376,11 @@ func (a *actuator) Migrate(ctx context.Context, log logr.Logger, ex *extensionsv
 		}
 	}
 
-	return a.delete(exCtx, true)
+	if err := a.delete(exCtx, true); err != nil {
+		return err
+	}
+	metrics.DeleteShoot(ex.Namespace)
+	return nil
 }
 
 func (a *actuator) prepareExtensionContext(ctx context.Context, log logr.Logger, ex *extensionsv1alpha1.Extension) (extensionContext, error) {
@@ -343,13 +394,21 @@ func (a *actuator) prepareExtensionContext(ctx context.Context, log logr.Logger,
 		return extensionContext{}, err
 	}
 
+	policy, err := a.resolvePolicy(ctx, log, cluster)
+	if err != nil {
+		return extensionContext{}, err
+	}
+	globalConfig := config.WithReloadedSettings(a.config)
+	policy.applyTo(&globalConfig)
+
 	return extensionContext{
 		ctx:          ctx,
 		log:          log,
 		ex:           ex,
 		cluster:      cluster,
 		dnsconfig:    dnsConfig,
-		globalConfig: a.config,
+		globalConfig: globalConfig,
+		policy:       policy,
 	}, nil
 }
 
@@ -372,47 +431,6 @@ func (a *actuator) ignoreDNSEntriesForMigration(ctx context.Context, ex *extensi
 	return nil
 }
 
-func (a *actuator) waitForEntryReconciliation(ctx context.Context, log logr.Logger, ex *extensionsv1alpha1.Extension) error {
-	entriesHelper := common.NewShootDNSEntriesHelper(ctx, a.client, ex)
-	list, err := entriesHelper.List()
-	if err != nil {
-		return err
-	}
-
-	// annotate all entries with gardener.cloud/operation=reconcile
-	for _, entry := range list {
-		patch := client.MergeFrom(entry.DeepCopy())
-		if entry.Annotations == nil {
-			entry.Annotations = map[string]string{}
-		}
-		entry.Annotations[v1beta1constants.GardenerOperation] = v1beta1constants.GardenerOperationReconcile
-		delete(entry.Annotations, dns.AnnotationHardIgnore) // should not be needed as the DNSEntries have been recreated, but just to be sure
-		if err := client.IgnoreNotFound(a.client.Patch(ctx, &entry, patch)); err != nil {
-			return fmt.Errorf("failed to revert ignore DNS entry %q: %w", entry.Name, err)
-		}
-	}
-
-	// wait for all entries to be reconciled i.e., gardener.cloud/operation annotation is removed
-	start := time.Now()
-	for _, entry := range list {
-		for {
-			if err := a.client.Get(ctx, client.ObjectKeyFromObject(&entry), &entry); err != nil {
-				return err
-			}
-			if _, ok := entry.Annotations[v1beta1constants.GardenerOperation]; !ok {
-				log.Info("DNS entry reconciled", "entry", entry.Name)
-				break
-			}
-			if time.Since(start) > 3*time.Minute {
-				return fmt.Errorf("timeout waiting for DNS entry %q to be reconciled", entry.Name)
-			}
-			time.Sleep(1 * time.Second)
-		}
-	}
-
-	return nil
-}
-
 func (a *actuator) isManagingDNSProviders(dns *gardencorev1beta1.DNS) bool {
 	return a.config.ManageDNSProviders && dns != nil && dns.Domain != nil
 }
@@ -422,8 +440,10 @@ func (a *actuator) isHibernated(cluster *controller.Cluster) bool {
 	return hibernation != nil && hibernation.Enabled != nil && *hibernation.Enabled
 }
 
-func (a *actuator) createOrUpdateSeedResources(exCtx extensionContext, mode controllerMode) error {
-	var err error
+func (a *actuator) createOrUpdateSeedResources(exCtx extensionContext, mode controllerMode) (err error) {
+	defer func() {
+		metrics.Reconciliations.WithLabelValues(mode.String(), metrics.ResultOf(err)).Inc()
+	}()
 	namespace := exCtx.ex.Namespace
 
 	exCtx.log.Info("Creating/updating seed resources", "namespace", namespace)
@@ -447,12 +467,12 @@ func (a *actuator) createOrUpdateSeedResources(exCtx extensionContext, mode cont
 		a.config.SeedID = seedID
 	}
 
+	if mode == controllerModeNormal && a.isHibernated(exCtx.cluster) {
+		mode = a.hibernatedControllerMode(exCtx)
+	}
+
 	replicas := 1
 	switch mode {
-	case controllerModeNormal:
-		if a.isHibernated(exCtx.cluster) {
-			replicas = 0
-		}
 	case controllerModeCleaningUp:
 		if !exCtx.useNextGenerationController() {
 			replicas = 0
@@ -469,8 +489,9 @@ func (a *actuator) createOrUpdateSeedResources(exCtx extensionContext, mode cont
 		"shootId":                          shootID,
 		"seedId":                           seedID,
 		"dnsClass":                         a.config.DNSClass,
+		"logLevel":                         exCtx.policy.controllerLogLevel(),
 		"dnsProviderReplication": map[string]any{
-			"enabled": a.replicateDNSProviders(exCtx.dnsconfig),
+			"enabled": a.replicateDNSProviders(exCtx),
 		},
 		"nextGeneration": map[string]any{
 			"enabled":                           exCtx.useNextGenerationController(),
@@ -489,7 +510,7 @@ func (a *actuator) createOrUpdateSeedResources(exCtx extensionContext, mode cont
 				"allowedServiceAccountImpersonationURLRegExps": stringsList,
 			},
 		}
-		if env := a.buildNextGenerationEnv(); env != nil {
+		if env := buildNextGenerationEnv(exCtx.globalConfig.NextGenerationControllerZoneNameservers); env != nil {
 			chartValues["env"] = env
 		}
 	}
@@ -562,8 +583,8 @@ func (a *actuator) createOrUpdateDNSProviders(exCtx extensionContext) error {
 	namespace := exCtx.ex.Namespace
 	deployers := map[string]component.DeployWaiter{}
 
-	hibernated := a.isHibernated(exCtx.cluster)
-	if !hibernated {
+	keepRecords := a.keepsRecords(exCtx)
+	if keepRecords {
 		external, err := a.prepareDefaultExternalDNSProvider(exCtx)
 		if err != nil {
 			return err
@@ -573,11 +594,11 @@ func (a *actuator) createOrUpdateDNSProviders(exCtx extensionContext) error {
 		providers := map[string]*dnsv1alpha1.DNSProvider{}
 		providers[ExternalDNSProviderName] = nil // remember for deletion
 		if external != nil {
-			quota, err := getDefaultDomainQuota(a.config, exCtx.cluster)
+			quota, err := getDefaultDomainQuota(exCtx.globalConfig, exCtx.cluster, exCtx.policy)
 			if err != nil {
 				return err
 			}
-			providers[ExternalDNSProviderName] = buildDNSProviderWithQuota(external, namespace, ExternalDNSProviderName, "", quota)
+			providers[ExternalDNSProviderName] = buildDNSProviderWithQuota(external, namespace, ExternalDNSProviderName, "", quota.value, nil)
 		}
 
 		result = a.addAdditionalDNSProviders(providers, exCtx, result, resources)
@@ -595,7 +616,7 @@ func (a *actuator) createOrUpdateDNSProviders(exCtx extensionContext) error {
 		}
 	}
 
-	if err := a.addCleanupOfOldAdditionalProviders(deployers, exCtx, !hibernated); err != nil {
+	if err := a.addCleanupOfOldAdditionalProviders(deployers, exCtx, keepRecords); err != nil {
 		result = multierror.Append(result, err)
 	}
 
@@ -608,7 +629,7 @@ func (a *actuator) createOrUpdateDNSProviders(exCtx extensionContext) error {
 		return result
 	}
 
-	if !hibernated {
+	if keepRecords {
 		return nil
 	}
 
@@ -682,6 +703,10 @@ func (a *actuator) deployDNSProviders(ctx context.Context, dnsProviders map[stri
 
 func (a *actuator) addAdditionalDNSProviders(providers map[string]*dnsv1alpha1.DNSProvider, exCtx extensionContext, result error, resources []gardencorev1beta1.NamedResourceReference) error {
 	namespace := exCtx.ex.Namespace
+	disallowed := map[int]servicev1alpha1.DNSDisallowedProviderStatus{}
+	for _, status := range a.disallowedProviders(exCtx) {
+		disallowed[int(status.Index)] = status
+	}
 	for i, provider := range exCtx.dnsconfig.Providers {
 		p := provider
 
@@ -696,8 +721,16 @@ func (a *actuator) addAdditionalDNSProviders(providers map[string]*dnsv1alpha1.D
 			continue
 		}
 
-		resourceName := oneOf(p.SecretName, p.Credentials)
-		mappedSecretName, err := lookupReference(resources, resourceName, i)
+		resourceName := oneOf(p.SecretName, p.Credentials, p.CredentialsBindingName)
+		var (
+			mappedSecretName string
+			err              error
+		)
+		if p.CredentialsBindingName != nil {
+			mappedSecretName, err = lookupCredentialsBinding(exCtx.cluster.Shoot, *p.CredentialsBindingName, *providerType, i)
+		} else {
+			mappedSecretName, err = lookupReference(resources, resourceName, i)
+		}
 		if err != nil {
 			result = multierror.Append(result, err)
 			continue
@@ -706,6 +739,13 @@ func (a *actuator) addAdditionalDNSProviders(providers map[string]*dnsv1alpha1.D
 		providerName := fmt.Sprintf("%s-%s", *providerType, resourceName)
 		providers[providerName] = nil
 
+		if status, ok := disallowed[i]; ok {
+			// an already deployed provider is kept, as it is still referenced in the providers map.
+			// The provider is also reported in the provider status of the extension.
+			result = multierror.Append(result, fmt.Errorf("dns provider[%d] %s", i, status.Message))
+			continue
+		}
+
 		secret := &corev1.Secret{}
 		if err := a.client.Get(
 			exCtx.ctx,
@@ -716,16 +756,16 @@ func (a *actuator) addAdditionalDNSProviders(providers map[string]*dnsv1alpha1.D
 			continue
 		}
 
-		providers[providerName] = buildDNSProvider(&p, namespace, providerName, mappedSecretName)
+		providers[providerName] = buildDNSProvider(&p, namespace, providerName, mappedSecretName, exCtx.globalConfig)
 	}
 	return result
 }
 
-func buildDNSProvider(p *apisservice.DNSProvider, namespace, name string, mappedSecretName string) *dnsv1alpha1.DNSProvider {
-	return buildDNSProviderWithQuota(p, namespace, name, mappedSecretName, 0)
+func buildDNSProvider(p *apisservice.DNSProvider, namespace, name string, mappedSecretName string, cfg config.DNSServiceConfig) *dnsv1alpha1.DNSProvider {
+	return buildDNSProviderWithQuota(p, namespace, name, mappedSecretName, getProviderEntriesQuota(cfg, p), getProviderRateLimit(cfg, p))
 }
 
-func buildDNSProviderWithQuota(p *apisservice.DNSProvider, namespace, name string, mappedSecretName string, entriesQuota int32) *dnsv1alpha1.DNSProvider {
+func buildDNSProviderWithQuota(p *apisservice.DNSProvider, namespace, name string, mappedSecretName string, entriesQuota int32, rateLimit *dnsv1alpha1.RateLimit) *dnsv1alpha1.DNSProvider {
 	var includeDomains, excludeDomains, includeZones, excludeZones []string
 	if domains := p.Domains; domains != nil {
 		includeDomains = domains.Include
@@ -735,7 +775,7 @@ func buildDNSProviderWithQuota(p *apisservice.DNSProvider, namespace, name strin
 		includeZones = zones.Include
 		excludeZones = zones.Exclude
 	}
-	secretName := oneOf(p.SecretName, p.Credentials)
+	secretName := oneOf(p.SecretName, p.Credentials, p.CredentialsBindingName)
 	if mappedSecretName != "" {
 		secretName = mappedSecretName
 	}
@@ -756,7 +796,7 @@ func buildDNSProviderWithQuota(p *apisservice.DNSProvider, namespace, name strin
 		},
 		Spec: dnsv1alpha1.DNSProviderSpec{
 			Type:           *p.Type,
-			ProviderConfig: nil,
+			ProviderConfig: p.ProviderConfig,
 			SecretRef: &corev1.SecretReference{
 				Name:      secretName,
 				Namespace: namespace,
@@ -769,7 +809,8 @@ func buildDNSProviderWithQuota(p *apisservice.DNSProvider, namespace, name strin
 				Include: includeZones,
 				Exclude: excludeZones,
 			},
-			Quotas: quotas,
+			Quotas:    quotas,
+			RateLimit: rateLimit,
 		},
 	}
 }
@@ -791,6 +832,20 @@ func lookupReference(resources []gardencorev1beta1.NamedResourceReference, resou
 	return "", fmt.Errorf("dns provider[%d] secretName/credentials %s not found in referenced resources", index, resourceName)
 }
 
+// lookupCredentialsBinding returns the name of the secret in the seed containing the credentials of the
+// CredentialsBinding. Gardener only syncs the credentials of the shoot's own CredentialsBinding into the seed, other
+// CredentialsBindings are rejected. The provider type of the shoot must match the DNS provider type.
+func lookupCredentialsBinding(shoot *gardencorev1beta1.Shoot, bindingName, providerType string, index int) (string, error) {
+	if shoot == nil || ptr.Deref(shoot.Spec.CredentialsBindingName, "") != bindingName {
+		return "", fmt.Errorf("dns provider[%d] credentialsBindingName %s is not the CredentialsBinding of the shoot", index, bindingName)
+	}
+	if expectedType := validation.CredentialsProviderType(providerType); shoot.Spec.Provider.Type != expectedType {
+		return "", fmt.Errorf("dns provider[%d] credentialsBindingName %s has provider type %q, but providers of type %q need credentials of provider type %q",
+			index, bindingName, shoot.Spec.Provider.Type, providerType, expectedType)
+	}
+	return v1beta1constants.SecretNameCloudProvider, nil
+}
+
 func (a *actuator) prepareDefaultExternalDNSProvider(exCtx extensionContext) (*apisservice.DNSProvider, error) {
 	for _, provider := range exCtx.cluster.Shoot.Spec.DNS.Providers {
 		if provider.Primary != nil && *provider.Primary {
@@ -854,7 +909,7 @@ func (a *actuator) copyRemoteDefaultDomainSecret(ctx context.Context, namespace
 
 	secret := &corev1.Secret{}
 	secret.Namespace = namespace
-	secret.Name = "shoot-dns-service-remote-default-domains"
+	secret.Name = RemoteDefaultDomainsSecretName
 	_, err = controllerutils.CreateOrGetAndMergePatch(ctx, a.client, secret, func() error {
 		secret.Data = secretOrg.Data
 		return nil
@@ -865,11 +920,11 @@ func (a *actuator) copyRemoteDefaultDomainSecret(ctx context.Context, namespace
 	return secret.Name, err
 }
 
-func (a *actuator) replicateDNSProviders(dnsconfig *apisservice.DNSConfig) bool {
-	if dnsconfig != nil && dnsconfig.DNSProviderReplication != nil {
-		return dnsconfig.DNSProviderReplication.Enabled
+func (a *actuator) replicateDNSProviders(exCtx extensionContext) bool {
+	if exCtx.dnsconfig != nil && exCtx.dnsconfig.DNSProviderReplication != nil {
+		return exCtx.dnsconfig.DNSProviderReplication.Enabled
 	}
-	return a.config.ReplicateDNSProviders
+	return exCtx.globalConfig.ReplicateDNSProviders
 }
 
 func (a *actuator) deleteSeedResources(exCtx extensionContext, migrate, force bool) error {
@@ -902,7 +957,7 @@ func (a *actuator) deleteSeedResources(exCtx extensionContext, migrate, force bo
 		return err
 	}
 
-	timeoutCtx, cancel := context.WithTimeout(exCtx.ctx, 2*time.Minute)
+	timeoutCtx, cancel := context.WithTimeout(exCtx.ctx, exCtx.globalConfig.Timeouts.WithDefaults().ManagedResourceDeletion)
 	defer cancel()
 	if err := a.managedResourceAccess.WaitUntilDeleted(timeoutCtx, namespace, SeedResourcesName); err != nil {
 		return err
@@ -995,6 +1050,7 @@ func (a *actuator) deleteManagedDNSEntries(exCtx extensionContext) error {
 			return fmt.Errorf("deleting all DNSEntries in control plane failed: %w", err)
 		}
 		exCtx.log.Info("Waiting until all shoot DNS entries have been deleted", "component", service.ExtensionServiceName, "namespace", exCtx.ex.Namespace)
+		start := time.Now()
 		for range 7 {
 			waitTime := 5 * time.Second
 			if a.fastTestMode {
@@ -1009,6 +1065,12 @@ func (a *actuator) deleteManagedDNSEntries(exCtx extensionContext) error {
 				break
 			}
 		}
+		result := metrics.ResultSuccess
+		if len(list) > 0 {
+			result = metrics.ResultTimeout
+		}
+		metrics.DNSEntriesDeletionWaitSeconds.WithLabelValues(result).Observe(time.Since(start).Seconds())
+		metrics.DNSEntriesDeletionPending.WithLabelValues(exCtx.ex.Namespace).Set(float64(len(list)))
 		if len(list) > 0 {
 			details := a.collectProviderDetailsOnDeletingDNSEntries(exCtx.ctx, list)
 			err = fmt.Errorf("waiting until shoot DNS entries have been deleted: %s", details)
@@ -1079,7 +1141,7 @@ func (a *actuator) createOrUpdateShootResources(exCtx extensionContext) error {
 	chartValues := map[string]any{
 		"serviceName": service.ServiceName,
 		"dnsProviderReplication": map[string]any{
-			"enabled": a.replicateDNSProviders(exCtx.dnsconfig),
+			"enabled": a.replicateDNSProviders(exCtx),
 		},
 		"nextGeneration": map[string]any{
 			"enabled": exCtx.useNextGenerationController(),
@@ -1096,11 +1158,16 @@ func (a *actuator) deleteShootResources(ctx context.Context, namespace string) e
 		return err
 	}
 
-	timeoutCtx, cancel := context.WithTimeout(ctx, 2*time.Minute)
+	timeoutCtx, cancel := context.WithTimeout(ctx, a.timeouts().ManagedResourceDeletion)
 	defer cancel()
 	return a.managedResourceAccess.WaitUntilDeleted(timeoutCtx, namespace, ShootResourcesName)
 }
 
+// timeouts returns the timeouts of the lifecycle controller including the settings of a reloaded configuration file.
+func (a *actuator) timeouts() config.Timeouts {
+	return config.WithReloadedSettings(a.config).Timeouts.WithDefaults()
+}
+
 var regexpPortSuffix = regexp.MustCompile(`:[0-9]+$`)
 
 // ensurePortInNameserver adds the default DNS port (:53) to a nameserver address if no port is specified.
@@ -1111,13 +1178,13 @@ func ensurePortInNameserver(nameserver string) string {
 	return nameserver
 }
 
-func (a *actuator) buildNextGenerationEnv() []map[string]string {
-	if len(a.config.NextGenerationControllerZoneNameservers) == 0 {
+func buildNextGenerationEnv(zoneNameservers map[string]string) []map[string]string {
+	if len(zoneNameservers) == 0 {
 		return nil
 	}
 
 	var nextGenerationEnv []map[string]string
-	for k, v := range a.config.NextGenerationControllerZoneNameservers {
+	for k, v := range zoneNameservers {
 		nextGenerationEnv = append(nextGenerationEnv, map[string]string{
 			"name":  "DNSMAN_NAMESERVER_" + strings.ToUpper(strings.ReplaceAll(strings.TrimRight(k, "."), ".", "_")),
 			"value": ensurePortInNameserver(v),
@@ -1191,33 +1258,122 @@ func oneOf(strs ...*string) string {
 	return ""
 }
 
+// defaultDomainQuota is the resolved DNS entries quota of the default external provider.
+type defaultDomainQuota struct {
+	// value is the quota, 0 if quotas are disabled.
+	value int32
+	// source is the origin of the quota as reported in the provider status.
+	source string
+	// message explains a deviation of the quota from the requested one, it is reported in the provider status.
+	message string
+}
+
+const (
+	// quotaSourceAnnotation is the quota source for a quota requested by shoot annotation.
+	quotaSourceAnnotation = "annotation"
+	// quotaSourcePolicyPrefix is the prefix of the quota source for a quota set by a ShootDNSServicePolicy.
+	quotaSourcePolicyPrefix = "policy:"
+)
+
 // getDefaultDomainQuota calculates the DNS entries quota for the default external provider.
-// It returns the configured default quota, which can be overridden via the shoot annotation
-// 'service.dns.extensions.gardener.cloud/default-external-provider-entries-quota'.
-// The annotation value is validated and constrained by DefaultExternalProviderEntriesQuotaMax.
-// Returns 0 if quotas are disabled (DefaultExternalProviderEntriesQuota == 0).
-func getDefaultDomainQuota(cfg config.DNSServiceConfig, cluster *controller.Cluster) (int32, error) {
-	quota := cfg.DefaultExternalProviderEntriesQuota
-	if quota == 0 {
-		return 0, nil // quotas not enabled
-	}
-	// Allow overwriting the default quota up to the maximum quota given by `--default-external-provider-entries-quota-max` via annotation on the shoot, e.g. `service.dns.extensions.gardener.cloud/default-external-provider-entries-quota: "100"`
-	if annotatedValue := cluster.Shoot.Annotations[ShootDNSServiceDefaultExternalProviderEntriesQuotaAnnotation]; annotatedValue != "" {
-		parsedQuota, err := strconv.ParseInt(annotatedValue, 10, 32)
-		if err != nil {
-			return 0, fmt.Errorf("failed to parse default external provider entries quota %s (shoot annotation %s): %w", annotatedValue, ShootDNSServiceDefaultExternalProviderEntriesQuotaAnnotation, err)
-		}
-		if parsedQuota < 1 {
-			return 0, fmt.Errorf("invalid default external provider entries quota %s (shoot annotation %s)", annotatedValue, ShootDNSServiceDefaultExternalProviderEntriesQuotaAnnotation)
-		}
-		maxQuota := cfg.DefaultExternalProviderEntriesQuotaMax
-		if maxQuota == 0 {
-			maxQuota = quota // restrict to default quota if no maximum quota is configured via flag, to avoid accidentally setting an unreasonably high quota via annotation
+// The default quota and its maximum are given by the first matching quota rule of the configuration or the global
+// settings, and can be overwritten by the applied ShootDNSServicePolicy.
+// The shoot annotation 'service.dns.extensions.gardener.cloud/default-external-provider-entries-quota' can always lower
+// the quota. It only raises the quota (up to the maximum) if the admission webhook verified that the requesting user
+// is allowed to do so, otherwise the annotation is ignored. A verified quota exceeding the maximum is limited to the
+// maximum, as the configuration or the applied policy may have changed since the verification.
+// Returns 0 if quotas are disabled (default quota == 0).
+func getDefaultDomainQuota(cfg config.DNSServiceConfig, cluster *controller.Cluster, policy *appliedPolicy) (defaultDomainQuota, error) {
+	limits, err := cfg.DefaultExternalProviderQuotaLimits(validation.ProjectNameFromTechnicalID(cluster.Shoot.Status.TechnicalID, cluster.Shoot.Name), cluster.Shoot)
+	if err != nil {
+		return defaultDomainQuota{}, err
+	}
+	quota := defaultDomainQuota{value: limits.Quota, source: limits.Source}
+	maxQuota := limits.QuotaMax
+	if policy != nil {
+		if policy.settings.DefaultExternalProviderEntriesQuota != nil {
+			quota = defaultDomainQuota{value: *policy.settings.DefaultExternalProviderEntriesQuota, source: quotaSourcePolicyPrefix + policy.name + "/" + policy.rule}
 		}
-		if parsedQuota > int64(maxQuota) {
-			return 0, fmt.Errorf("annotated default external provider entries quota %d (shoot annotation %s) exceeds maximum allowed quota %d", parsedQuota, ShootDNSServiceDefaultExternalProviderEntriesQuotaAnnotation, maxQuota)
+		if policy.settings.DefaultExternalProviderEntriesQuotaMax != nil {
+			maxQuota = *policy.settings.DefaultExternalProviderEntriesQuotaMax
 		}
-		quota = int32(parsedQuota)
 	}
-	return quota, nil
+	if quota.value == 0 {
+		return quota, nil // quotas not enabled
+	}
+
+	// Allow overwriting the default quota via annotation on the shoot, e.g. `service.dns.extensions.gardener.cloud/default-external-provider-entries-quota: "100"`
+	annotatedValue := cluster.Shoot.Annotations[ShootDNSServiceDefaultExternalProviderEntriesQuotaAnnotation]
+	if annotatedValue == "" {
+		return quota, nil
+	}
+	parsedQuota, err := strconv.ParseInt(annotatedValue, 10, 32)
+	if err != nil {
+		return defaultDomainQuota{}, fmt.Errorf("failed to parse default external provider entries quota %s (shoot annotation %s): %w", annotatedValue, ShootDNSServiceDefaultExternalProviderEntriesQuotaAnnotation, err)
+	}
+	if parsedQuota < 1 {
+		return defaultDomainQuota{}, fmt.Errorf("invalid default external provider entries quota %s (shoot annotation %s)", annotatedValue, ShootDNSServiceDefaultExternalProviderEntriesQuotaAnnotation)
+	}
+	if parsedQuota > int64(quota.value) && cluster.Shoot.Annotations[service.DefaultExternalProviderEntriesQuotaVerifiedAnnotation] != annotatedValue {
+		// raising the quota needs the verification by the admission webhook
+		return quota, nil
+	}
+	if maxQuota == 0 {
+		maxQuota = quota.value // restrict to default quota if no maximum quota is configured, to avoid accidentally setting an unreasonably high quota via annotation
+	}
+	if parsedQuota > int64(maxQuota) {
+		return defaultDomainQuota{
+			value:   maxQuota,
+			source:  quotaSourceAnnotation,
+			message: fmt.Sprintf("annotated quota %d (shoot annotation %s) exceeds the maximum allowed quota %d, the maximum is applied", parsedQuota, ShootDNSServiceDefaultExternalProviderEntriesQuotaAnnotation, maxQuota),
+		}, nil
+	}
+	return defaultDomainQuota{value: int32(parsedQuota), source: quotaSourceAnnotation}, nil
+}
+
+// getProviderEntriesQuota calculates the DNS entries quota for an additional provider.
+// The quota given in the provider configuration is bounded by ProviderEntriesQuotaMax.
+// If no quota is given, the maximum is used. Returns 0 if no quota applies.
+func getProviderEntriesQuota(cfg config.DNSServiceConfig, p *apisservice.DNSProvider) int32 {
+	var quota int32
+	if p.Quotas != nil && p.Quotas.Entries != nil {
+		quota = *p.Quotas.Entries
+	}
+	return boundedByMax(quota, cfg.ProviderEntriesQuotaMax)
+}
+
+// getProviderRateLimit calculates the rate limit for an additional provider.
+// The rate limit given in the provider configuration is bounded by ProviderRequestsPerDayMax and ProviderBurstMax.
+// If no rate limit is given, the maximums are used. Returns nil if no rate limit applies.
+func getProviderRateLimit(cfg config.DNSServiceConfig, p *apisservice.DNSProvider) *dnsv1alpha1.RateLimit {
+	var requestsPerDay, burst int32
+	if p.RateLimit != nil {
+		requestsPerDay = p.RateLimit.RequestsPerDay
+		burst = ptr.Deref(p.RateLimit.Burst, servicev1alpha1.DefaultRateLimitBurst)
+	} else {
+		burst = servicev1alpha1.DefaultRateLimitBurst
+	}
+	requestsPerDay = boundedByMax(requestsPerDay, cfg.ProviderRequestsPerDayMax)
+	if requestsPerDay <= 0 {
+		return nil
+	}
+	if cfg.ProviderBurstMax > 0 && burst > cfg.ProviderBurstMax {
+		burst = cfg.ProviderBurstMax
+	}
+	return &dnsv1alpha1.RateLimit{
+		RequestsPerDay: int(requestsPerDay),
+		Burst:          int(burst),
+	}
+}
+
+// boundedByMax returns the value limited to the maximum. A maximum of 0 means unlimited.
+// If the value is not set (<= 0), the maximum is returned.
+func boundedByMax(value, maximum int32) int32 {
+	if maximum <= 0 {
+		return value
+	}
+	if value <= 0 || value > maximum {
+		return maximum
+	}
+	return value
 }
@@ -5,18 +5,37 @@
 package lifecycle
 
 import (
+	dnsv1alpha1 "github.com/gardener/external-dns-management/pkg/apis/dns/v1alpha1"
 	extensionscontroller "github.com/gardener/gardener/extensions/pkg/controller"
 	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
+	policyv1alpha1 "github.com/gardener/gardener-extension-shoot-dns-service/pkg/apis/policy/v1alpha1"
+	apisservice "github.com/gardener/gardener-extension-shoot-dns-service/pkg/apis/service"
 	"github.com/gardener/gardener-extension-shoot-dns-service/pkg/controller/config"
+	"github.com/gardener/gardener-extension-shoot-dns-service/pkg/service"
 )
 
 var _ = Describe("getDefaultDomainQuota", func() {
+	newCluster := func(annotations map[string]string) *extensionscontroller.Cluster {
+		return &extensionscontroller.Cluster{
+			Shoot: &gardencorev1beta1.Shoot{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "test-shoot",
+					Namespace:   "garden-test",
+					Annotations: annotations,
+				},
+				Spec: gardencorev1beta1.ShootSpec{
+					Purpose: new(gardencorev1beta1.ShootPurposeProduction),
+				},
+			},
+		}
+	}
+
 	DescribeTable("should return correct quota",
-		func(defaultQuota, maxQuota int32, annotation *string, expectedQuota int32, expectError bool, errorSubstring string) {
+		func(defaultQuota, maxQuota int32, annotation *string, verified bool, expectedQuota int32, expectError bool, errorSubstring string) {
 			// Set up config
 			cfg := config.DNSServiceConfig{
 				DefaultExternalProviderEntriesQuota:    defaultQuota,
@@ -24,23 +43,18 @@ var _ = Describe("getDefaultDomainQuota", func() {
 			}
 
 			// Create cluster with optional annotation
-			cluster := &extensionscontroller.Cluster{
-				Shoot: &gardencorev1beta1.Shoot{
-					ObjectMeta: metav1.ObjectMeta{
-						Name:      "test-shoot",
-						Namespace: "garden-test",
-					},
-				},
-			}
-
+			var annotations map[string]string
 			if annotation != nil {
-				cluster.Shoot.Annotations = map[string]string{
+				annotations = map[string]string{
 					ShootDNSServiceDefaultExternalProviderEntriesQuotaAnnotation: *annotation,
 				}
+				if verified {
+					annotations[service.DefaultExternalProviderEntriesQuotaVerifiedAnnotation] = *annotation
+				}
 			}
 
 			// Call function
-			quota, err := getDefaultDomainQuota(cfg, cluster)
+			quota, err := getDefaultDomainQuota(cfg, newCluster(annotations), nil)
 
 			// Verify results
 			if expectError {
@@ -50,22 +64,157 @@ var _ = Describe("getDefaultDomainQuota", func() {
 				}
 			} else {
 				Expect(err).NotTo(HaveOccurred())
-				Expect(quota).To(Equal(expectedQuota))
+				Expect(quota.value).To(Equal(expectedQuota))
+			}
+		},
+		Entry("quotas disabled - no annotation", int32(0), int32(0), nil, false, int32(0), false, ""),
+		Entry("quotas disabled - with annotation", int32(0), int32(0), new("50"), true, int32(0), false, ""),
+		Entry("default quota - no annotation", int32(100), int32(0), nil, false, int32(100), false, ""),
+		Entry("default quota - with valid annotation within default", int32(100), int32(0), new("80"), false, int32(80), false, ""),
+		Entry("default quota - with valid annotation exceeding default without max", int32(100), int32(0), new("150"), true, int32(100), false, ""),
+		Entry("default quota with max - valid annotation within max", int32(100), int32(200), new("150"), true, int32(150), false, ""),
+		Entry("default quota with max - unverified annotation within max", int32(100), int32(200), new("150"), false, int32(100), false, ""),
+		Entry("default quota with max - annotation equals max", int32(100), int32(200), new("200"), true, int32(200), false, ""),
+		Entry("default quota with max - annotation exceeds max", int32(100), int32(200), new("250"), true, int32(200), false, ""),
+		Entry("default quota with max - unverified annotation exceeds max", int32(100), int32(200), new("250"), false, int32(100), false, ""),
+		Entry("annotation with invalid format", int32(100), int32(0), new("invalid"), false, int32(0), true, "failed to parse"),
+		Entry("annotation with negative value", int32(100), int32(0), new("-10"), false, int32(0), true, "invalid default external provider entries quota"),
+		Entry("annotation with zero value", int32(100), int32(0), new("0"), false, int32(0), true, "invalid default external provider entries quota"),
+		Entry("annotation with empty string - returns default", int32(100), int32(0), new(""), false, int32(100), false, ""),
+		Entry("small default quota - annotation within limit", int32(10), int32(0), new("5"), false, int32(5), false, ""),
+		Entry("small default quota - annotation exceeds limit", int32(10), int32(0), new("15"), true, int32(10), false, ""),
+	)
+
+	It("should ignore the verification of another annotation value", func() {
+		cfg := config.DNSServiceConfig{DefaultExternalProviderEntriesQuota: 100, DefaultExternalProviderEntriesQuotaMax: 200}
+		quota, err := getDefaultDomainQuota(cfg, newCluster(map[string]string{
+			ShootDNSServiceDefaultExternalProviderEntriesQuotaAnnotation:  "180",
+			service.DefaultExternalProviderEntriesQuotaVerifiedAnnotation: "150",
+		}), nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(quota).To(Equal(defaultDomainQuota{value: 100, source: "default"}))
+	})
+
+	It("should limit a verified quota to the maximum of the applied policy", func() {
+		// the admission webhook does not know the policies of the seed
+		cfg := config.DNSServiceConfig{DefaultExternalProviderEntriesQuota: 100, DefaultExternalProviderEntriesQuotaMax: 1000}
+		policy := &appliedPolicy{
+			name:     "policy",
+			rule:     "rule",
+			settings: policyv1alpha1.ShootDNSServiceSettings{DefaultExternalProviderEntriesQuotaMax: new(int32(500))},
+		}
+		quota, err := getDefaultDomainQuota(cfg, newCluster(map[string]string{
+			ShootDNSServiceDefaultExternalProviderEntriesQuotaAnnotation:  "800",
+			service.DefaultExternalProviderEntriesQuotaVerifiedAnnotation: "800",
+		}), policy)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(quota).To(Equal(defaultDomainQuota{
+			value:   500,
+			source:  "annotation",
+			message: "annotated quota 800 (shoot annotation service.dns.extensions.gardener.cloud/default-external-provider-entries-quota) exceeds the maximum allowed quota 500, the maximum is applied",
+		}))
+	})
+
+	Describe("sources", func() {
+		var cfg config.DNSServiceConfig
+
+		BeforeEach(func() {
+			cfg = config.DNSServiceConfig{
+				DefaultExternalProviderEntriesQuota: 100,
+				DefaultExternalProviderEntriesQuotaRules: []config.QuotaRule{
+					{
+						Name:     "evaluation",
+						Selector: policyv1alpha1.ShootSelector{Purposes: []gardencorev1beta1.ShootPurpose{gardencorev1beta1.ShootPurposeEvaluation}},
+						Quota:    10,
+					},
+					{
+						Name:     "production",
+						Selector: policyv1alpha1.ShootSelector{Purposes: []gardencorev1beta1.ShootPurpose{gardencorev1beta1.ShootPurposeProduction}},
+						Quota:    500,
+						QuotaMax: 1000,
+					},
+				},
+			}
+		})
+
+		It("should use the default quota if no rule matches", func() {
+			cfg.DefaultExternalProviderEntriesQuotaRules = cfg.DefaultExternalProviderEntriesQuotaRules[:1]
+			Expect(getDefaultDomainQuota(cfg, newCluster(nil), nil)).To(Equal(defaultDomainQuota{value: 100, source: "default"}))
+		})
+
+		It("should use the first matching quota rule", func() {
+			Expect(getDefaultDomainQuota(cfg, newCluster(nil), nil)).To(Equal(defaultDomainQuota{value: 500, source: "rule:production"}))
+		})
+
+		It("should use the maximum of the matching quota rule for verified annotations", func() {
+			Expect(getDefaultDomainQuota(cfg, newCluster(map[string]string{
+				ShootDNSServiceDefaultExternalProviderEntriesQuotaAnnotation:  "800",
+				service.DefaultExternalProviderEntriesQuotaVerifiedAnnotation: "800",
+			}), nil)).To(Equal(defaultDomainQuota{value: 800, source: "annotation"}))
+		})
+
+		It("should prefer the quota of the policy", func() {
+			policy := &appliedPolicy{
+				name:     "policy",
+				rule:     "rule",
+				settings: policyv1alpha1.ShootDNSServiceSettings{DefaultExternalProviderEntriesQuota: new(int32(50))},
+			}
+			Expect(getDefaultDomainQuota(cfg, newCluster(nil), policy)).To(Equal(defaultDomainQuota{value: 50, source: "policy:policy/rule"}))
+		})
+
+		It("should select the rules by the project of the technical ID", func() {
+			cfg.DefaultExternalProviderEntriesQuotaRules[0].Selector = policyv1alpha1.ShootSelector{Projects: []string{"test"}}
+			cluster := newCluster(nil)
+			// projects may use a custom namespace, which does not contain the project name
+			cluster.Shoot.Namespace = "custom-namespace"
+			cluster.Shoot.Status.TechnicalID = "shoot--test--test-shoot"
+			Expect(getDefaultDomainQuota(cfg, cluster, nil)).To(Equal(defaultDomainQuota{value: 10, source: "rule:evaluation"}))
+		})
+	})
+})
+
+var _ = Describe("getProviderEntriesQuota", func() {
+	DescribeTable("should return bounded quota",
+		func(maxQuota int32, quota *int32, expectedQuota int32) {
+			cfg := config.DNSServiceConfig{ProviderEntriesQuotaMax: maxQuota}
+			p := &apisservice.DNSProvider{}
+			if quota != nil {
+				p.Quotas = &apisservice.DNSProviderQuotas{Entries: quota}
+			}
+			Expect(getProviderEntriesQuota(cfg, p)).To(Equal(expectedQuota))
+		},
+		Entry("no maximum - no quota", int32(0), nil, int32(0)),
+		Entry("no maximum - with quota", int32(0), new(int32(500)), int32(500)),
+		Entry("maximum - no quota", int32(100), nil, int32(100)),
+		Entry("maximum - quota within maximum", int32(100), new(int32(50)), int32(50)),
+		Entry("maximum - quota exceeds maximum", int32(100), new(int32(150)), int32(100)),
+	)
+})
+
+var _ = Describe("getProviderRateLimit", func() {
+	DescribeTable("should return bounded rate limit",
+		func(maxRequestsPerDay, maxBurst int32, rateLimit *apisservice.DNSProviderRateLimit, expected *dnsv1alpha1.RateLimit) {
+			cfg := config.DNSServiceConfig{
+				ProviderRequestsPerDayMax: maxRequestsPerDay,
+				ProviderBurstMax:          maxBurst,
 			}
+			p := &apisservice.DNSProvider{RateLimit: rateLimit}
+			Expect(getProviderRateLimit(cfg, p)).To(Equal(expected))
 		},
-		Entry("quotas disabled - no annotation", int32(0), int32(0), nil, int32(0), false, ""),
-		Entry("quotas disabled - with annotation", int32(0), int32(0), new("50"), int32(0), false, ""),
-		Entry("default quota - no annotation", int32(100), int32(0), nil, int32(100), false, ""),
-		Entry("default quota - with valid annotation within default", int32(100), int32(0), new("80"), int32(80), false, ""),
-		Entry("default quota - with valid annotation exceeding default without max", int32(100), int32(0), new("150"), int32(0), true, "exceeds maximum allowed quota 100"),
-		Entry("default quota with max - valid annotation within max", int32(100), int32(200), new("150"), int32(150), false, ""),
-		Entry("default quota with max - annotation equals max", int32(100), int32(200), new("200"), int32(200), false, ""),
-		Entry("default quota with max - annotation exceeds max", int32(100), int32(200), new("250"), int32(0), true, "exceeds maximum allowed quota 200"),
-		Entry("annotation with invalid format", int32(100), int32(0), new("invalid"), int32(0), true, "failed to parse"),
-		Entry("annotation with negative value", int32(100), int32(0), new("-10"), int32(0), true, "invalid default external provider entries quota"),
-		Entry("annotation with zero value", int32(100), int32(0), new("0"), int32(0), true, "invalid default external provider entries quota"),
-		Entry("annotation with empty string - returns default", int32(100), int32(0), new(""), int32(100), false, ""),
-		Entry("small default quota - annotation within limit", int32(10), int32(0), new("5"), int32(5), false, ""),
-		Entry("small default quota - annotation exceeds limit", int32(10), int32(0), new("15"), int32(0), true, "exceeds maximum allowed quota 10"),
+		Entry("no maximum - no rate limit", int32(0), int32(0), nil, nil),
+		Entry("no maximum - with rate limit", int32(0), int32(0),
+			&apisservice.DNSProviderRateLimit{RequestsPerDay: 1000, Burst: new(int32(5))},
+			&dnsv1alpha1.RateLimit{RequestsPerDay: 1000, Burst: 5}),
+		Entry("no maximum - with rate limit without burst", int32(0), int32(0),
+			&apisservice.DNSProviderRateLimit{RequestsPerDay: 1000},
+			&dnsv1alpha1.RateLimit{RequestsPerDay: 1000, Burst: 20}),
+		Entry("maximum - no rate limit", int32(2000), int32(10), nil,
+			&dnsv1alpha1.RateLimit{RequestsPerDay: 2000, Burst: 10}),
+		Entry("maximum - rate limit within maximum", int32(2000), int32(10),
+			&apisservice.DNSProviderRateLimit{RequestsPerDay: 1000, Burst: new(int32(5))},
+			&dnsv1alpha1.RateLimit{RequestsPerDay: 1000, Burst: 5}),
+		Entry("maximum - rate limit exceeds maximum", int32(2000), int32(10),
+			&apisservice.DNSProviderRateLimit{RequestsPerDay: 5000, Burst: new(int32(50))},
+			&dnsv1alpha1.RateLimit{RequestsPerDay: 2000, Burst: 10}),
 	)
 })
@@ -6,6 +6,7 @@ package lifecycle
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"regexp"
 	"strings"
@@ -23,6 +24,7 @@ import (
 	"github.com/go-logr/logr"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
 	autoscalingv1 "k8s.io/api/autoscaling/v1"
 	corev1 "k8s.io/api/core/v1"
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
@@ -38,8 +40,11 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 	"sigs.k8s.io/yaml"
 
+	"github.com/gardener/gardener-extension-shoot-dns-service/pkg/apis"
+	policyv1alpha1 "github.com/gardener/gardener-extension-shoot-dns-service/pkg/apis/policy/v1alpha1"
 	apisservice "github.com/gardener/gardener-extension-shoot-dns-service/pkg/apis/service"
 	servicev1alpha1 "github.com/gardener/gardener-extension-shoot-dns-service/pkg/apis/service/v1alpha1"
+	"github.com/gardener/gardener-extension-shoot-dns-service/pkg/controller/common"
 	"github.com/gardener/gardener-extension-shoot-dns-service/pkg/controller/config"
 )
 
@@ -253,7 +258,7 @@ var _ = Describe("Actuator", func() {
 		log                    logr.Logger
 		scheme                 *runtime.Scheme
 		decoder                runtime.Decoder
-		seedClient             client.Client
+		seedClient             client.WithWatch
 		shootClient            client.Client
 		dnsServiceConfig       config.DNSServiceConfig
 		managedResourcesAccess *testManagedResourcesAccess
@@ -262,6 +267,7 @@ var _ = Describe("Actuator", func() {
 		cluster                *extensionsv1alpha1.Cluster
 		providerExternal       *dnsv1alpha1.DNSProvider
 		providerAdditional     *dnsv1alpha1.DNSProvider
+		expectedLogLevel       string
 
 		prepareControlPlane = func(useNextGenerationController bool) {
 			GinkgoHelper()
@@ -318,7 +324,7 @@ var _ = Describe("Actuator", func() {
 			}
 			Expect(seedClient.Create(ctx, providerSecret)).To(Succeed(), "failed to create fake referenced provider secret")
 
-			actuator = NewActuator(seedClient, scheme, nil, dnsServiceConfig,
+			actuator = NewActuator(seedClient, seedClient, scheme, nil, dnsServiceConfig,
 				managedResourcesAccess,
 				&testShootClientAccess{shootClient: shootClient, expectedNamespace: "shoot--foo--bar"},
 				&newProviderDeployWaiterFactory{client: seedClient, waitInterval: ptr.To(20 * time.Millisecond)},
@@ -384,6 +390,7 @@ genericTokenKubeconfigSecretName: generic-token-kubeconfig
 images:
   dns-controller-manager: '...'
   dns-controller-manager-next-generation: '...'
+logLevel: %s
 nextGeneration:
   dnsClass: gardendns-next-gen
   enabled: %t
@@ -393,7 +400,7 @@ seedId: test-seed
 serviceName: shoot-dns-service
 shootId: shoot--foo--bar-78897def-5208-4feb-b0f0-015950eadbb9-test-landscape
 targetClusterSecret: shoot-access-extension-shoot-dns-service
-%s`, useNextGenerationController, restrictToControlPlaneControllers, replicas, workloadIdentityValues))
+%s`, expectedLogLevel, useNextGenerationController, restrictToControlPlaneControllers, replicas, workloadIdentityValues))
 			}
 		}
 
@@ -619,6 +626,65 @@ targetClusterSecret: shoot-access-extension-shoot-dns-service
 			runReconcile(targetClass)
 		}
 
+		setHibernationMode = func(mode servicev1alpha1.HibernationMode) {
+			cluster.Spec.Shoot.Object.(*gardencorev1beta1.Shoot).Spec.Extensions[0].ProviderConfig.Object.(*servicev1alpha1.DNSConfig).Hibernation = &mode
+		}
+
+		checkEntryTargets = func(namespace, name string, targets ...string) {
+			GinkgoHelper()
+			entry := &dnsv1alpha1.DNSEntry{}
+			Expect(seedClient.Get(ctx, client.ObjectKey{Namespace: namespace, Name: name}, entry)).To(Succeed(), "expected DNSEntry to be existing")
+			Expect(entry.Spec.Targets).To(Equal(targets), "unexpected DNSEntry targets")
+		}
+
+		checkRedirectedEntriesState = func(redirectedEntries ...*apis.RedirectedDNSEntry) {
+			GinkgoHelper()
+			Expect(seedClient.Get(ctx, client.ObjectKeyFromObject(ex), ex)).To(Succeed(), "failed to get extension resource")
+			state, err := common.GetExtensionState(ctx, seedClient, ex)
+			Expect(err).ToNot(HaveOccurred(), "failed to get extension state")
+			Expect(state.RedirectedEntries).To(Equal(redirectedEntries), "unexpected redirected entries in extension state")
+		}
+
+		checkHibernationKeepingRecords = func(useNextGenerationController bool, mode servicev1alpha1.HibernationMode) {
+			targetClass, expectedReplicasOnCleaningUp := specialConfigValues(useNextGenerationController)
+
+			createReplicatedProvider("shoot--foo--bar", "source-provider", useNextGenerationController, "source-provider-secret", "aws-route53")
+			createEntry("shoot--foo--bar", "some-entry", useNextGenerationController, "foo.bar.external.example.com")
+
+			By("Hibernation")
+			hibernateShoot(true)
+
+			managedResourcesAccess.ExpectCreateOrUpdate("shoot--foo--bar", "extension-shoot-dns-service-shoot",
+				checkShootValues(useNextGenerationController))
+			managedResourcesAccess.ExpectCreateOrUpdate("shoot--foo--bar", "extension-shoot-dns-service-seed",
+				checkSeedValues(expectedReplicasOnCleaningUp, useNextGenerationController, true))
+			runReconcile(targetClass)
+
+			checkEntryExisting("shoot--foo--bar", "some-entry")
+			checkProviderExisting("shoot--foo--bar", "source-provider")
+			checkProviderExisting("shoot--foo--bar", providerExternal.Name)
+			checkProviderExisting("shoot--foo--bar", providerAdditional.Name)
+			if mode == servicev1alpha1.HibernationModeRedirect {
+				checkEntryTargets("shoot--foo--bar", "some-entry", "10.0.0.1")
+				checkRedirectedEntriesState(&apis.RedirectedDNSEntry{Name: "some-entry", Targets: []string{"1.2.3.4"}})
+			} else {
+				checkEntryTargets("shoot--foo--bar", "some-entry", "1.2.3.4")
+				checkRedirectedEntriesState()
+			}
+
+			By("Wake-up")
+			hibernateShoot(false)
+
+			managedResourcesAccess.ExpectCreateOrUpdate("shoot--foo--bar", "extension-shoot-dns-service-shoot",
+				checkShootValues(useNextGenerationController))
+			managedResourcesAccess.ExpectCreateOrUpdate("shoot--foo--bar", "extension-shoot-dns-service-seed",
+				checkSeedValues(1, useNextGenerationController, false))
+			runReconcile(targetClass)
+
+			checkEntryTargets("shoot--foo--bar", "some-entry", "1.2.3.4")
+			checkRedirectedEntriesState()
+		}
+
 		prepareShootCRDsAndCRs = func() {
 			GinkgoHelper()
 			Expect(dnsapp.DeployCRDsWithClient(ctx, log, shootClient, &dnsapisconfig.DNSManagerConfiguration{
@@ -718,6 +784,17 @@ targetClusterSecret: shoot-access-extension-shoot-dns-service
 			createReplicatedProvider("shoot--foo--bar", "source-provider", useNextGenerationController, "source-provider-secret", "aws-route53")
 			createEntry("shoot--foo--bar", "some-entry", useNextGenerationController, "foo.bar.external.example.com")
 			createEntry("shoot--foo--bar", "stuck-entry", useNextGenerationController, "stuck.bar.external.example.com", "dns.gardener.cloud/dummy-finalizer")
+			stuckEntry := &dnsv1alpha1.DNSEntry{}
+			Expect(seedClient.Get(ctx, client.ObjectKey{Namespace: "shoot--foo--bar", Name: "stuck-entry"}, stuckEntry)).To(Succeed())
+			stuckEntry.Status = dnsv1alpha1.DNSEntryStatus{
+				ProviderType: new("aws-route53"),
+				Provider:     new("shoot--foo--bar/source-provider"),
+				Zone:         new("Z1234"),
+				DNSName:      new("stuck.bar.external.example.com"),
+				TTL:          new(int64(120)),
+				Targets:      []string{"1.2.3.4"},
+			}
+			Expect(seedClient.Update(ctx, stuckEntry)).To(Succeed())
 
 			By("Force Delete")
 			managedResourcesAccess.ExpectCreateOrUpdate("shoot--foo--bar", "extension-shoot-dns-service-seed",
@@ -753,6 +830,24 @@ targetClusterSecret: shoot-access-extension-shoot-dns-service
 			reconcileCtx, cancel := context.WithTimeout(ctx, 100*time.Second)
 			Expect(actuator.ForceDelete(reconcileCtx, log, ex)).To(Succeed())
 			cancel()
+
+			By("Check inventory of leaked records")
+			inventories, err := common.ListLeakedRecords(ctx, seedClient, "extension-shoot-dns-service")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(inventories).To(ConsistOf(MatchFields(IgnoreExtras, Fields{
+				"ShootID":        Equal("shoot--foo--bar-78897def-5208-4feb-b0f0-015950eadbb9-test-landscape"),
+				"ShootNamespace": Equal("shoot--foo--bar"),
+				"Records": ConsistOf(common.LeakedDNSRecord{
+					Entry:        "stuck-entry",
+					DNSName:      "stuck.bar.external.example.com",
+					RecordType:   "A",
+					Targets:      []string{"1.2.3.4"},
+					TTL:          120,
+					Provider:     "shoot--foo--bar/source-provider",
+					ProviderType: "aws-route53",
+					Zone:         "Z1234",
+				}),
+			})))
 		}
 
 		checkDNSEntriesIgnored = func(*mockManagedResource) {
@@ -767,11 +862,13 @@ targetClusterSecret: shoot-access-extension-shoot-dns-service
 		ctx = context.Background()
 		logf.SetLogger(logger.MustNewZapLogger(logger.DebugLevel, logger.FormatJSON, zap.WriteTo(GinkgoWriter)))
 		log = logf.Log.WithName("test")
+		expectedLogLevel = "info"
 		dnsServiceConfig = config.DNSServiceConfig{
-			SeedID:                "test-seed",
-			DNSClass:              "source-class",
-			ManageDNSProviders:    true,
-			ReplicateDNSProviders: true,
+			SeedID:                 "test-seed",
+			DNSClass:               "source-class",
+			ManageDNSProviders:     true,
+			ReplicateDNSProviders:  true,
+			LeakedRecordsNamespace: "extension-shoot-dns-service",
 			InternalGCPWorkloadIdentityConfig: dnsapisconfig.InternalGCPWorkloadIdentityConfig{
 				AllowedTokenURLs: []string{"https://sts.googleapis.com/v1/token"},
 				AllowedServiceAccountImpersonationURLRegExps: []*regexp.Regexp{regexp.MustCompile(`^https://iamcredentials\.googleapis\.com/v1/projects/-/serviceAccounts/.+:generateAccessToken$`)},
@@ -785,6 +882,7 @@ targetClusterSecret: shoot-access-extension-shoot-dns-service
 		Expect(servicev1alpha1.AddToScheme(scheme)).NotTo(HaveOccurred())
 		Expect(extensionscontroller.AddToScheme(scheme)).NotTo(HaveOccurred())
 		Expect(apiextensionsv1.AddToScheme(scheme)).NotTo(HaveOccurred())
+		Expect(policyv1alpha1.AddToScheme(scheme)).NotTo(HaveOccurred())
 
 		decoder = serializer.NewCodecFactory(scheme).UniversalDecoder()
 
@@ -851,6 +949,7 @@ targetClusterSecret: shoot-access-extension-shoot-dns-service
 						},
 						Status: gardencorev1beta1.ShootStatus{
 							ClusterIdentity: new("shoot--foo--bar-78897def-5208-4feb-b0f0-015950eadbb9-test-landscape"),
+							TechnicalID:     "shoot--foo--bar",
 						},
 					},
 				},
@@ -909,6 +1008,54 @@ targetClusterSecret: shoot-access-extension-shoot-dns-service
 			checkStandardReconciliation(true)
 			checkHibernation(true)
 		})
+
+		It("should keep the DNS records of a hibernated shoot with hibernation mode keepRecords", func() {
+			setHibernationMode(servicev1alpha1.HibernationModeKeepRecords)
+			checkStandardReconciliation(false)
+			checkHibernationKeepingRecords(false, servicev1alpha1.HibernationModeKeepRecords)
+		})
+
+		It("should redirect the DNS records of a hibernated shoot with hibernation mode redirect", func() {
+			oldWriteStateEnvelope := common.WriteStateEnvelope
+			common.WriteStateEnvelope = true
+			DeferCleanup(func() { common.WriteStateEnvelope = oldWriteStateEnvelope })
+			dnsServiceConfig.HibernationRedirectTargets = []string{"10.0.0.1"}
+			setHibernationMode(servicev1alpha1.HibernationModeRedirect)
+			checkStandardReconciliation(true)
+			checkHibernationKeepingRecords(true, servicev1alpha1.HibernationModeRedirect)
+		})
+
+		It("should apply the first matching rule of a ShootDNSServicePolicy", func() {
+			Expect(seedClient.Create(ctx, &policyv1alpha1.ShootDNSServicePolicy{
+				ObjectMeta: metav1.ObjectMeta{Name: "policy"},
+				Spec: policyv1alpha1.ShootDNSServicePolicySpec{
+					Rules: []policyv1alpha1.ShootDNSServicePolicyRule{
+						{
+							Name:     "other-project",
+							Selector: policyv1alpha1.ShootSelector{Projects: []string{"other"}},
+							Settings: policyv1alpha1.ShootDNSServiceSettings{ControllerLogLevel: new("error")},
+						},
+						{
+							Name:     "evaluation",
+							Selector: policyv1alpha1.ShootSelector{Purposes: []gardencorev1beta1.ShootPurpose{gardencorev1beta1.ShootPurposeEvaluation}},
+							Settings: policyv1alpha1.ShootDNSServiceSettings{
+								ControllerLogLevel:   new("debug"),
+								AllowedProviderTypes: []string{"aws-route53"},
+							},
+						},
+					},
+				},
+			})).To(Succeed())
+			expectedLogLevel = "debug"
+			checkStandardReconciliation(false)
+
+			Expect(seedClient.Get(ctx, client.ObjectKeyFromObject(ex), ex)).To(Succeed())
+			Expect(ex.Status.ProviderStatus).NotTo(BeNil())
+			status := &servicev1alpha1.DNSStatus{}
+			Expect(json.Unmarshal(ex.Status.ProviderStatus.Raw, status)).To(Succeed())
+			Expect(status.Policy).To(Equal(&servicev1alpha1.DNSPolicyStatus{Name: "policy", Rule: "evaluation"}))
+			Expect(status.DisallowedProviders).To(BeEmpty())
+		})
 	})
 
 	Describe("#Delete", func() {
@@ -1130,4 +1277,81 @@ var _ = Describe("extensionContext.useNextGenerationController", func() {
 		Entry("config true", new(true), true),
 		Entry("config false", new(false), false),
 	)
+
+	DescribeTable("policy setting UseNextGenerationController",
+		func(policyValue bool, labelValue string, configValue *bool, expected bool) {
+			exCtx.globalConfig.UseNextGenerationController = !policyValue
+			exCtx.policy = &appliedPolicy{settings: policyv1alpha1.ShootDNSServiceSettings{UseNextGenerationController: new(policyValue)}}
+			exCtx.cluster = &extensionscontroller.Cluster{
+				Seed: &gardencorev1beta1.Seed{
+					ObjectMeta: metav1.ObjectMeta{
+						Labels: map[string]string{
+							ShootDNSServiceUseNextGenerationController: labelValue,
+						},
+					},
+				},
+			}
+			exCtx.dnsconfig.UseNextGenerationController = configValue
+			Expect(exCtx.useNextGenerationController()).To(Equal(expected))
+		},
+		Entry("policy true, label 'false', config nil", true, "false", nil, true),
+		Entry("policy true, label 'false', config false", true, "false", new(false), false),
+		Entry("policy false, label 'true', config nil", false, "true", nil, false),
+		Entry("policy false, label 'true', config true", false, "true", new(true), true),
+		Entry("policy true, label 'force-false', config true", true, "force-false", new(true), false),
+		Entry("policy false, label 'force-true', config false", false, "force-true", new(false), true),
+	)
+})
+
+var _ = Describe("lookupReference", func() {
+	resources := []gardencorev1beta1.NamedResourceReference{
+		{Name: "secret", ResourceRef: autoscalingv1.CrossVersionObjectReference{Kind: "Secret", Name: "dns-secret", APIVersion: "v1"}},
+		{Name: "aws", ResourceRef: autoscalingv1.CrossVersionObjectReference{Kind: "WorkloadIdentity", Name: "route53", APIVersion: "security.gardener.cloud/v1alpha1"}},
+		{Name: "gcp", ResourceRef: autoscalingv1.CrossVersionObjectReference{Kind: "WorkloadIdentity", Name: "clouddns", APIVersion: "security.gardener.cloud/v1alpha1"}},
+		{Name: "openstack", ResourceRef: autoscalingv1.CrossVersionObjectReference{Kind: "WorkloadIdentity", Name: "designate", APIVersion: "security.gardener.cloud/v1alpha1"}},
+		{Name: "alicloud", ResourceRef: autoscalingv1.CrossVersionObjectReference{Kind: "WorkloadIdentity", Name: "alidns", APIVersion: "security.gardener.cloud/v1alpha1"}},
+	}
+
+	It("should map secrets to the referenced resources", func() {
+		Expect(lookupReference(resources, "secret", 0)).To(Equal("ref-dns-secret"))
+	})
+
+	It("should map workload identities to the referenced workload identity secrets", func() {
+		Expect(lookupReference(resources, "aws", 0)).To(Equal("workload-identity-ref-route53"))
+		Expect(lookupReference(resources, "gcp", 1)).To(Equal("workload-identity-ref-clouddns"))
+		Expect(lookupReference(resources, "openstack", 2)).To(Equal("workload-identity-ref-designate"))
+		Expect(lookupReference(resources, "alicloud", 3)).To(Equal("workload-identity-ref-alidns"))
+	})
+
+	It("should fail for unknown resources", func() {
+		_, err := lookupReference(resources, "unknown", 2)
+		Expect(err).To(MatchError("dns provider[2] secretName/credentials unknown not found in referenced resources"))
+	})
+
+	It("should fail without resource name", func() {
+		_, err := lookupReference(resources, "", 3)
+		Expect(err).To(MatchError("dns provider[3] doesn't specify a secretName or credentials field"))
+	})
+
+})
+
+var _ = Describe("lookupCredentialsBinding", func() {
+	shoot := &gardencorev1beta1.Shoot{Spec: gardencorev1beta1.ShootSpec{
+		CredentialsBindingName: new("shared-dns"),
+		Provider:               gardencorev1beta1.Provider{Type: "aws"},
+	}}
+
+	It("should map the CredentialsBinding of the shoot to the cloud provider secret", func() {
+		Expect(lookupCredentialsBinding(shoot, "shared-dns", "aws-route53", 0)).To(Equal("cloudprovider"))
+	})
+
+	It("should reject other CredentialsBindings", func() {
+		_, err := lookupCredentialsBinding(shoot, "other", "aws-route53", 1)
+		Expect(err).To(MatchError("dns provider[1] credentialsBindingName other is not the CredentialsBinding of the shoot"))
+	})
+
+	It("should reject the CredentialsBinding of the shoot for another provider type", func() {
+		_, err := lookupCredentialsBinding(shoot, "shared-dns", "google-clouddns", 2)
+		Expect(err).To(MatchError(`dns provider[2] credentialsBindingName shared-dns has provider type "aws", but providers of type "google-clouddns" need credentials of provider type "gcp"`))
+	})
 })
@@ -11,6 +11,7 @@ import (
 
 	"github.com/gardener/gardener/extensions/pkg/controller/extension"
 	"github.com/gardener/gardener/pkg/chartrenderer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 
@@ -25,8 +26,21 @@ const (
 	FinalizerSuffix = service.ExtensionServiceName
 )
 
+const (
+	// DefaultResyncPeriod is the default period of the periodic reconciliation of the Extensions.
+	DefaultResyncPeriod = 60 * time.Minute
+	// DefaultResyncJitterFactor is the default jitter factor applied to the resync period.
+	DefaultResyncJitterFactor = 0.1
+	// DefaultWatchDebounce is the default delay for requeuing an Extension on changes of the resources it depends on.
+	DefaultWatchDebounce = 10 * time.Second
+)
+
 // DefaultAddOptions contains configuration for the dns service.
-var DefaultAddOptions = AddOptions{}
+var DefaultAddOptions = AddOptions{
+	ResyncPeriod:       DefaultResyncPeriod,
+	ResyncJitterFactor: DefaultResyncJitterFactor,
+	WatchDebounce:      DefaultWatchDebounce,
+}
 
 // AddOptions are options to apply when adding the dns service controller to the manager.
 type AddOptions struct {
@@ -34,6 +48,12 @@ type AddOptions struct {
 	Controller controller.Options
 	// IgnoreOperationAnnotation specifies whether to ignore the operation annotation or not.
 	IgnoreOperationAnnotation bool
+	// ResyncPeriod is the period of the periodic reconciliation of the Extensions (0 = disabled).
+	ResyncPeriod time.Duration
+	// ResyncJitterFactor is the maximum factor the resync period is randomly extended by.
+	ResyncJitterFactor float64
+	// WatchDebounce is the delay for requeuing an Extension on changes of its DNSProviders, DNSEntries or secrets.
+	WatchDebounce time.Duration
 }
 
 // AddToManager adds a controller with the default Options to the given Controller Manager.
@@ -48,8 +68,13 @@ func AddToManagerWithOptions(ctx context.Context, mgr manager.Manager, opts AddO
 		return fmt.Errorf("failed to create chart renderer: %v", err)
 	}
 
+	watchClient, err := client.NewWithWatch(mgr.GetConfig(), client.Options{Scheme: mgr.GetScheme(), Mapper: mgr.GetRESTMapper()})
+	if err != nil {
+		return fmt.Errorf("failed to create watch client: %v", err)
+	}
+
 	return extension.Add(mgr, extension.AddArgs{
-		Actuator: NewActuator(mgr.GetClient(), mgr.GetScheme(), chartRenderer, config.DNSService,
+		Actuator: NewActuator(mgr.GetClient(), watchClient, mgr.GetScheme(), chartRenderer, config.DNSService,
 			&realManagedResourcesAccess{client: mgr.GetClient()},
 			&realShootClient{seedClient: mgr.GetClient()},
 			&newProviderDeployWaiterFactory{client: mgr.GetClient()},
@@ -57,8 +82,8 @@ func AddToManagerWithOptions(ctx context.Context, mgr manager.Manager, opts AddO
 		ControllerOptions: opts.Controller,
 		Name:              Name,
 		FinalizerSuffix:   FinalizerSuffix,
-		Resync:            60 * time.Minute,
 		Predicates:        extension.DefaultPredicates(ctx, mgr, opts.IgnoreOperationAnnotation),
 		Type:              service.ExtensionType,
+		WatchBuilder:      newWatchBuilder(mgr, config.DNSService, opts),
 	})
 }
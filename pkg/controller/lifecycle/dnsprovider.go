@@ -27,6 +27,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/gardener/gardener-extension-shoot-dns-service/pkg/apis/helper"
+	"github.com/gardener/gardener-extension-shoot-dns-service/pkg/controller/config"
 )
 
 // TimeNow returns the current time. Exposed for testing.
@@ -38,7 +39,7 @@ type newProviderDeployWaiterFactory struct {
 }
 
 // New creates a new DeployWaiter for the given DNSProvider.
-func (f *newProviderDeployWaiterFactory) New(exCtx extensionContext, provider *dnsv1alpha1.DNSProvider) component.DeployWaiter {
+func (f *newProviderDeployWaiterFactory) New(exCtx extensionContext, dnsProvider *dnsv1alpha1.DNSProvider) component.DeployWaiter {
 	var class *string
 	if exCtx.useNextGenerationController() {
 		class = new(NextGenerationTargetClass)
@@ -47,13 +48,17 @@ func (f *newProviderDeployWaiterFactory) New(exCtx extensionContext, provider *d
 	if f.waitInterval != nil {
 		waitIntervals = []time.Duration{*f.waitInterval}
 	}
-	return NewProviderDeployWaiter(
+	deployWaiter := NewProviderDeployWaiter(
 		exCtx.log,
 		f.client,
-		provider,
+		dnsProvider,
 		class,
 		waitIntervals...,
-	)
+	).(*provider)
+	timeouts := exCtx.globalConfig.Timeouts.WithDefaults()
+	deployWaiter.readyTimeout = timeouts.DNSProviderReady
+	deployWaiter.deletionTimeout = timeouts.DNSProviderDeletion
+	return deployWaiter
 }
 
 // NewProviderDeployWaiter creates a new instance of DeployWaiter for a specific DNSProvider.
@@ -75,6 +80,9 @@ func NewProviderDeployWaiter(
 		class:        class,
 		waitInterval: interval,
 
+		readyTimeout:    config.DefaultDNSProviderReadyTimeout,
+		deletionTimeout: config.DefaultDNSProviderDeletionTimeout,
+
 		dnsProvider: &dnsv1alpha1.DNSProvider{
 			ObjectMeta: metav1.ObjectMeta{
 				Name:      new.Name,
@@ -91,6 +99,9 @@ type provider struct {
 	class        *string
 	waitInterval time.Duration
 
+	readyTimeout    time.Duration
+	deletionTimeout time.Duration
+
 	dnsProvider *dnsv1alpha1.DNSProvider
 }
 
@@ -124,13 +135,13 @@ func (p *provider) Wait(ctx context.Context) error {
 		dnsv1alpha1.DNSProviderKind,
 		p.waitInterval,
 		3*p.waitInterval,
-		2*time.Minute,
+		p.readyTimeout,
 		nil,
 	)
 }
 
 func (p *provider) WaitCleanup(ctx context.Context) error {
-	timeoutCtx, cancel := context.WithTimeout(ctx, 2*time.Minute)
+	timeoutCtx, cancel := context.WithTimeout(ctx, p.deletionTimeout)
 	defer cancel()
 	return kutil.WaitUntilResourceDeleted(timeoutCtx, p.client, p.dnsProvider, 5*time.Second)
 }
@@ -0,0 +1,200 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	dnsv1alpha1 "github.com/gardener/external-dns-management/pkg/apis/dns/v1alpha1"
+	"github.com/gardener/external-dns-management/pkg/dns"
+	v1beta1constants "github.com/gardener/gardener/pkg/apis/core/v1beta1/constants"
+	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
+	"github.com/gardener/gardener/pkg/utils/flow"
+	"github.com/go-logr/logr"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/watch"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/gardener/gardener-extension-shoot-dns-service/pkg/controller/common"
+	"github.com/gardener/gardener-extension-shoot-dns-service/pkg/controller/metrics"
+)
+
+const (
+	// entryReconciliationParallelism is the number of DNS entries patched in parallel on restore.
+	entryReconciliationParallelism = 20
+	// entryReconciliationTimeoutPerEntry is the additional time to wait per DNS entry on restore.
+	entryReconciliationTimeoutPerEntry = 200 * time.Millisecond
+	// entryReconciliationProgressInterval is the minimum interval between two progress updates in the extension status.
+	entryReconciliationProgressInterval = 10 * time.Second
+	// entryReconciliationMaxProgress is the progress of the last operation if all DNS entries are reconciled.
+	// The remaining progress is left for the reconciliation following the restore.
+	entryReconciliationMaxProgress = 50
+)
+
+// errEntryReconciliationTimeout is returned if the DNS entries are not reconciled in time on restore.
+var errEntryReconciliationTimeout = errors.New("timeout")
+
+// entryReconciliationTimeout returns the time to wait for the reconciliation of the given number of DNS entries.
+func (a *actuator) entryReconciliationTimeout(count int) time.Duration {
+	if a.fastTestMode {
+		return 2 * time.Second
+	}
+	timeouts := a.timeouts()
+	return min(timeouts.EntryReconciliation+time.Duration(count)*entryReconciliationTimeoutPerEntry, timeouts.EntryReconciliationMax)
+}
+
+// waitForEntryReconciliation triggers the reconciliation of all shoot DNS entries and waits until the DNS controller
+// has removed the operation annotation from all of them. The entries are watched instead of polled.
+func (a *actuator) waitForEntryReconciliation(ctx context.Context, log logr.Logger, ex *extensionsv1alpha1.Extension) (err error) {
+	entriesHelper := common.NewShootDNSEntriesHelper(ctx, a.client, ex)
+	matchingLabel, err := entriesHelper.ShootDNSEntryMatchingLabel()
+	if err != nil {
+		return err
+	}
+	list, err := entriesHelper.List()
+	if err != nil {
+		return err
+	}
+	if len(list) == 0 {
+		return nil
+	}
+
+	start := time.Now()
+	defer func() {
+		result := metrics.ResultOf(err)
+		if errors.Is(err, errEntryReconciliationTimeout) {
+			result = metrics.ResultTimeout
+		}
+		metrics.DNSEntriesRestoreWaitSeconds.WithLabelValues(result).Observe(time.Since(start).Seconds())
+	}()
+
+	// annotate all entries with gardener.cloud/operation=reconcile
+	fns := make([]flow.TaskFn, 0, len(list))
+	for _, item := range list {
+		entry := item
+		fns = append(fns, func(ctx context.Context) error {
+			patch := client.MergeFrom(entry.DeepCopy())
+			if entry.Annotations == nil {
+				entry.Annotations = map[string]string{}
+			}
+			entry.Annotations[v1beta1constants.GardenerOperation] = v1beta1constants.GardenerOperationReconcile
+			delete(entry.Annotations, dns.AnnotationHardIgnore) // should not be needed as the DNSEntries have been recreated, but just to be sure
+			if err := client.IgnoreNotFound(a.client.Patch(ctx, &entry, patch)); err != nil {
+				return fmt.Errorf("failed to revert ignore DNS entry %q: %w", entry.Name, err)
+			}
+			return nil
+		})
+	}
+	if err := flow.ParallelN(entryReconciliationParallelism, fns...)(ctx); err != nil {
+		return err
+	}
+
+	// wait for all entries to be reconciled i.e., gardener.cloud/operation annotation is removed
+	total := len(list)
+	timeout := a.entryReconciliationTimeout(total)
+	waitCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	var (
+		pending        sets.Set[string]
+		lastReported   = -1
+		lastReportTime time.Time
+	)
+	for {
+		entries := &dnsv1alpha1.DNSEntryList{}
+		if err := a.watchClient.List(waitCtx, entries, client.InNamespace(ex.Namespace), matchingLabel); err != nil {
+			return a.entryReconciliationError(ctx, err, pending, total, timeout)
+		}
+		pending = sets.New[string]()
+		for _, entry := range entries.Items {
+			if isEntryReconciliationPending(&entry) {
+				pending.Insert(entry.Name)
+			}
+		}
+
+		watcher, err := a.watchClient.Watch(waitCtx, &dnsv1alpha1.DNSEntryList{}, client.InNamespace(ex.Namespace), matchingLabel,
+			&client.ListOptions{Raw: &metav1.ListOptions{ResourceVersion: entries.ResourceVersion}})
+		if err != nil {
+			return a.entryReconciliationError(ctx, err, pending, total, timeout)
+		}
+
+		done, err := func() (bool, error) {
+			defer watcher.Stop()
+			for {
+				reconciled := total - pending.Len()
+				if reconciled != lastReported && (pending.Len() == 0 || time.Since(lastReportTime) >= entryReconciliationProgressInterval) {
+					log.Info("Waiting for DNS entries to be reconciled", "reconciled", reconciled, "total", total)
+					if err := a.updateEntryReconciliationProgress(ctx, ex, reconciled, total); err != nil {
+						log.Info("updating progress of DNS entry reconciliation failed", "error", err)
+					}
+					lastReported = reconciled
+					lastReportTime = time.Now()
+				}
+				if pending.Len() == 0 {
+					return true, nil
+				}
+
+				select {
+				case <-waitCtx.Done():
+					return false, waitCtx.Err()
+				case event, ok := <-watcher.ResultChan():
+					if !ok {
+						// watch has been closed by the server, list and watch again
+						return false, nil
+					}
+					entry, ok := event.Object.(*dnsv1alpha1.DNSEntry)
+					if !ok {
+						// e.g. an error event for an expired resource version, list and watch again
+						return false, nil
+					}
+					if !pending.Has(entry.Name) {
+						continue
+					}
+					if event.Type == watch.Deleted || !isEntryReconciliationPending(entry) {
+						log.Info("DNS entry reconciled", "entry", entry.Name)
+						pending.Delete(entry.Name)
+					}
+				}
+			}
+		}()
+		if err != nil {
+			return a.entryReconciliationError(ctx, err, pending, total, timeout)
+		}
+		if done {
+			return nil
+		}
+	}
+}
+
+func isEntryReconciliationPending(entry *dnsv1alpha1.DNSEntry) bool {
+	_, ok := entry.Annotations[v1beta1constants.GardenerOperation]
+	return ok
+}
+
+func (a *actuator) entryReconciliationError(ctx context.Context, err error, pending sets.Set[string], total int, timeout time.Duration) error {
+	if pending.Len() == 0 {
+		return fmt.Errorf("failed waiting for DNS entries to be reconciled: %w", err)
+	}
+	if ctx.Err() == nil && errors.Is(err, context.DeadlineExceeded) {
+		err = fmt.Errorf("%w after %s", errEntryReconciliationTimeout, timeout)
+	}
+	return fmt.Errorf("%d of %d DNS entries not reconciled (%s): %w", pending.Len(), total, strings.Join(sets.List(pending), ", "), err)
+}
+
+// updateEntryReconciliationProgress records the progress of the DNS entry reconciliation in the last operation of the extension.
+func (a *actuator) updateEntryReconciliationProgress(ctx context.Context, ex *extensionsv1alpha1.Extension, reconciled, total int) error {
+	if ex.Status.LastOperation == nil {
+		return nil
+	}
+	patch := client.MergeFrom(ex.DeepCopy())
+	ex.Status.LastOperation.Description = fmt.Sprintf("Waiting for DNS entries to be reconciled (%d/%d)", reconciled, total)
+	ex.Status.LastOperation.Progress = int32(1 + (entryReconciliationMaxProgress-1)*reconciled/total)
+	return a.client.Status().Patch(ctx, ex, patch)
+}
@@ -0,0 +1,149 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package lifecycle
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	dnsv1alpha1 "github.com/gardener/external-dns-management/pkg/apis/dns/v1alpha1"
+	"github.com/gardener/external-dns-management/pkg/dns"
+	extensionscontroller "github.com/gardener/gardener/extensions/pkg/controller"
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	v1beta1constants "github.com/gardener/gardener/pkg/apis/core/v1beta1/constants"
+	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/gardener/gardener-extension-shoot-dns-service/pkg/controller/common"
+	"github.com/gardener/gardener-extension-shoot-dns-service/pkg/controller/config"
+)
+
+var _ = Describe("actuator.waitForEntryReconciliation", func() {
+	const (
+		namespace       = "shoot--foo--bar"
+		clusterIdentity = "shoot--foo--bar-78897def-5208-4feb-b0f0-015950eadbb9-test-landscape"
+	)
+
+	var (
+		ctx        context.Context
+		seedClient client.WithWatch
+		a          *actuator
+		ex         *extensionsv1alpha1.Extension
+
+		// reconcileEntries mimics the DNS controller by removing the operation annotation from the given entries.
+		reconcileEntries = func(ctx context.Context, names ...string) {
+			defer GinkgoRecover()
+			time.Sleep(200 * time.Millisecond)
+			for _, name := range names {
+				entry := &dnsv1alpha1.DNSEntry{}
+				Expect(seedClient.Get(ctx, client.ObjectKey{Namespace: namespace, Name: name}, entry)).To(Succeed())
+				Expect(entry.Annotations).To(HaveKeyWithValue(v1beta1constants.GardenerOperation, v1beta1constants.GardenerOperationReconcile))
+				patch := client.MergeFrom(entry.DeepCopy())
+				delete(entry.Annotations, v1beta1constants.GardenerOperation)
+				Expect(seedClient.Patch(ctx, entry, patch)).To(Succeed())
+			}
+		}
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		scheme := runtime.NewScheme()
+		Expect(dnsv1alpha1.AddToScheme(scheme)).To(Succeed())
+		Expect(extensionscontroller.AddToScheme(scheme)).To(Succeed())
+		seedClient = fake.NewClientBuilder().WithScheme(scheme).WithStatusSubresource(&extensionsv1alpha1.Extension{}).Build()
+		a = &actuator{client: seedClient, watchClient: seedClient, fastTestMode: true}
+
+		cluster := &extensionsv1alpha1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Name: namespace},
+			Spec: extensionsv1alpha1.ClusterSpec{
+				Shoot: runtime.RawExtension{
+					Object: &gardencorev1beta1.Shoot{
+						TypeMeta: metav1.TypeMeta{
+							APIVersion: "core.gardener.cloud/v1beta1",
+							Kind:       "Shoot",
+						},
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      "bar",
+							Namespace: "garden-foo",
+						},
+						Status: gardencorev1beta1.ShootStatus{
+							ClusterIdentity: new(clusterIdentity),
+						},
+					},
+				},
+			},
+		}
+		Expect(seedClient.Create(ctx, cluster)).To(Succeed())
+
+		ex = &extensionsv1alpha1.Extension{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "shoot-dns-service",
+				Namespace: namespace,
+			},
+		}
+		Expect(seedClient.Create(ctx, ex)).To(Succeed())
+		ex.Status.LastOperation = &gardencorev1beta1.LastOperation{
+			Type:     gardencorev1beta1.LastOperationTypeRestore,
+			State:    gardencorev1beta1.LastOperationStateProcessing,
+			Progress: 1,
+		}
+		Expect(seedClient.Status().Update(ctx, ex)).To(Succeed())
+
+		for i := range 3 {
+			entry := &dnsv1alpha1.DNSEntry{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        fmt.Sprintf("entry-%d", i),
+					Namespace:   namespace,
+					Labels:      map[string]string{common.ShootDNSEntryLabelKey: common.ShortenID(clusterIdentity, 63)},
+					Annotations: map[string]string{dns.AnnotationHardIgnore: "true"},
+				},
+				Spec: dnsv1alpha1.DNSEntrySpec{
+					DNSName: fmt.Sprintf("e%d.foo.bar.external.example.com", i),
+					Targets: []string{"1.2.3.4"},
+				},
+			}
+			Expect(seedClient.Create(ctx, entry)).To(Succeed())
+		}
+	})
+
+	It("should wait until all entries are reconciled and record the progress", func() {
+		go reconcileEntries(ctx, "entry-0", "entry-1", "entry-2")
+
+		Expect(a.waitForEntryReconciliation(ctx, GinkgoLogr, ex)).To(Succeed())
+
+		Expect(seedClient.Get(ctx, client.ObjectKeyFromObject(ex), ex)).To(Succeed())
+		Expect(ex.Status.LastOperation.Description).To(Equal("Waiting for DNS entries to be reconciled (3/3)"))
+		Expect(ex.Status.LastOperation.Progress).To(Equal(int32(entryReconciliationMaxProgress)))
+		for i := range 3 {
+			entry := &dnsv1alpha1.DNSEntry{}
+			Expect(seedClient.Get(ctx, client.ObjectKey{Namespace: namespace, Name: fmt.Sprintf("entry-%d", i)}, entry)).To(Succeed())
+			Expect(entry.Annotations).To(BeEmpty())
+		}
+	})
+
+	It("should report the pending entries on timeout", func() {
+		go reconcileEntries(ctx, "entry-1")
+
+		err := a.waitForEntryReconciliation(ctx, GinkgoLogr, ex)
+		Expect(err).To(MatchError("2 of 3 DNS entries not reconciled (entry-0, entry-2): timeout after 2s"))
+
+		Expect(seedClient.Get(ctx, client.ObjectKeyFromObject(ex), ex)).To(Succeed())
+		// progress updates are throttled, so only the initial progress is recorded
+		Expect(ex.Status.LastOperation.Description).To(Equal("Waiting for DNS entries to be reconciled (0/3)"))
+	})
+
+	It("should scale the timeout with the number of entries", func() {
+		a.fastTestMode = false
+		Expect(a.entryReconciliationTimeout(10)).To(Equal(3*time.Minute + 2*time.Second))
+		Expect(a.entryReconciliationTimeout(1000)).To(Equal(3*time.Minute + 200*time.Second))
+		Expect(a.entryReconciliationTimeout(100000)).To(Equal(config.DefaultEntryReconciliationMaxTimeout))
+	})
+})
@@ -0,0 +1,120 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package lifecycle
+
+import (
+	"fmt"
+	"slices"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/gardener/gardener-extension-shoot-dns-service/pkg/apis"
+	apisservice "github.com/gardener/gardener-extension-shoot-dns-service/pkg/apis/service"
+	"github.com/gardener/gardener-extension-shoot-dns-service/pkg/controller/common"
+)
+
+// hibernationMode returns the handling of the DNS records of a hibernated shoot.
+func hibernationMode(dnsconfig *apisservice.DNSConfig) apisservice.HibernationMode {
+	if dnsconfig != nil && dnsconfig.Hibernation != nil {
+		return *dnsconfig.Hibernation
+	}
+	return apisservice.HibernationModeDeleteRecords
+}
+
+// keepsRecords returns true if the DNS entries of the shoot are kept, i.e. the shoot is either awake or
+// keeps its DNS records during hibernation.
+func (a *actuator) keepsRecords(exCtx extensionContext) bool {
+	return !a.isHibernated(exCtx.cluster) || hibernationMode(exCtx.dnsconfig) != apisservice.HibernationModeDeleteRecords
+}
+
+// hibernatedControllerMode returns the controller mode of the shoot-dns-service deployment of a hibernated shoot.
+// If the DNS records are kept, only the control plane controllers are needed to keep the DNS entries up to date.
+func (a *actuator) hibernatedControllerMode(exCtx extensionContext) controllerMode {
+	if a.keepsRecords(exCtx) {
+		return controllerModeCleaningUp
+	}
+	return controllerModeScaledDown
+}
+
+// reconcileHibernationRedirect redirects the targets of the shoot DNS entries to the configured redirect targets
+// if the shoot is hibernated with hibernation mode `redirect`. The original targets are stored in the extension
+// state before and are restored on wake-up. As older versions of the extension cannot read them, the original
+// targets can only be stored if the state envelope is enabled.
+func (a *actuator) reconcileHibernationRedirect(exCtx extensionContext) error {
+	redirect := a.isHibernated(exCtx.cluster) && hibernationMode(exCtx.dnsconfig) == apisservice.HibernationModeRedirect
+	if redirect && len(a.config.HibernationRedirectTargets) == 0 {
+		return fmt.Errorf("hibernation mode %q is not supported, as no redirect targets are configured", apisservice.HibernationModeRedirect)
+	}
+	if redirect && !common.WriteStateEnvelope {
+		return fmt.Errorf("hibernation mode %q is not supported, as the state envelope is not enabled", apisservice.HibernationModeRedirect)
+	}
+
+	handler, err := common.NewStateHandler(exCtx.ctx, exCtx.log, a.client, exCtx.ex)
+	if err != nil {
+		if !redirect {
+			exCtx.log.Info("ignoring state handler error", "error", err, "namespace", exCtx.ex.Namespace)
+			return nil
+		}
+		return err
+	}
+	if !redirect && len(handler.RedirectedEntries()) == 0 {
+		return nil
+	}
+
+	entries, err := handler.ShootDNSEntriesHelper().List()
+	if err != nil {
+		return err
+	}
+
+	if redirect {
+		redirected := handler.RedirectedEntries()
+		for _, entry := range entries {
+			if len(entry.Spec.Targets) == 0 || slices.ContainsFunc(redirected, func(r *apis.RedirectedDNSEntry) bool { return r.Name == entry.Name }) {
+				continue
+			}
+			redirected = append(redirected, &apis.RedirectedDNSEntry{Name: entry.Name, Targets: slices.Clone(entry.Spec.Targets)})
+		}
+		// the original targets must be stored before the entries are redirected
+		handler.SetRedirectedEntries(redirected)
+		if err := handler.Update("hibernation redirect"); err != nil {
+			return err
+		}
+		for _, entry := range entries {
+			if len(entry.Spec.Targets) == 0 || slices.Equal(entry.Spec.Targets, a.config.HibernationRedirectTargets) {
+				continue
+			}
+			patch := client.MergeFrom(entry.DeepCopy())
+			entry.Spec.Targets = slices.Clone(a.config.HibernationRedirectTargets)
+			if err := client.IgnoreNotFound(a.client.Patch(exCtx.ctx, &entry, patch)); err != nil {
+				return fmt.Errorf("failed to redirect DNS entry %q: %w", entry.Name, err)
+			}
+		}
+		exCtx.log.Info("Redirected DNS entries of hibernated shoot", "namespace", exCtx.ex.Namespace, "count", len(redirected))
+		return nil
+	}
+
+	originalTargets := map[string][]string{}
+	for _, r := range handler.RedirectedEntries() {
+		originalTargets[r.Name] = r.Targets
+	}
+	for _, entry := range entries {
+		targets, ok := originalTargets[entry.Name]
+		// entries updated in the meantime (e.g. by the source controllers) are not touched
+		if !ok || !slices.Equal(entry.Spec.Targets, a.config.HibernationRedirectTargets) {
+			continue
+		}
+		patch := client.MergeFrom(entry.DeepCopy())
+		entry.Spec.Targets = slices.Clone(targets)
+		if err := client.IgnoreNotFound(a.client.Patch(exCtx.ctx, &entry, patch)); err != nil {
+			return fmt.Errorf("failed to restore targets of DNS entry %q: %w", entry.Name, err)
+		}
+	}
+	handler.SetRedirectedEntries(nil)
+	if err := handler.Update("hibernation redirect restored"); err != nil {
+		return err
+	}
+	exCtx.log.Info("Restored targets of redirected DNS entries", "namespace", exCtx.ex.Namespace, "count", len(originalTargets))
+	return nil
+}
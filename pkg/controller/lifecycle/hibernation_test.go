@@ -0,0 +1,50 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package lifecycle
+
+import (
+	"github.com/gardener/gardener/extensions/pkg/controller"
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	apisservice "github.com/gardener/gardener-extension-shoot-dns-service/pkg/apis/service"
+	"github.com/gardener/gardener-extension-shoot-dns-service/pkg/controller/common"
+)
+
+var _ = Describe("Hibernation", func() {
+	Describe("#reconcileHibernationRedirect", func() {
+		var (
+			a     *actuator
+			exCtx extensionContext
+		)
+
+		BeforeEach(func() {
+			a = &actuator{}
+			a.config.HibernationRedirectTargets = []string{"10.0.0.1"}
+			exCtx = extensionContext{
+				dnsconfig: &apisservice.DNSConfig{Hibernation: new(apisservice.HibernationModeRedirect)},
+				cluster: &controller.Cluster{
+					Shoot: &gardencorev1beta1.Shoot{
+						Spec: gardencorev1beta1.ShootSpec{Hibernation: &gardencorev1beta1.Hibernation{Enabled: new(true)}},
+					},
+				},
+			}
+		})
+
+		It("should refuse redirects without redirect targets", func() {
+			a.config.HibernationRedirectTargets = nil
+			Expect(a.reconcileHibernationRedirect(exCtx)).To(MatchError(`hibernation mode "redirect" is not supported, as no redirect targets are configured`))
+		})
+
+		It("should refuse redirects without the state envelope", func() {
+			oldWriteStateEnvelope := common.WriteStateEnvelope
+			common.WriteStateEnvelope = false
+			DeferCleanup(func() { common.WriteStateEnvelope = oldWriteStateEnvelope })
+
+			Expect(a.reconcileHibernationRedirect(exCtx)).To(MatchError(`hibernation mode "redirect" is not supported, as the state envelope is not enabled`))
+		})
+	})
+})
@@ -0,0 +1,39 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package lifecycle
+
+import (
+	"fmt"
+
+	dnsv1alpha1 "github.com/gardener/external-dns-management/pkg/apis/dns/v1alpha1"
+
+	"github.com/gardener/gardener-extension-shoot-dns-service/pkg/controller/common"
+)
+
+// recordLeakedRecords writes the DNS records of the given entries to the inventory of leaked DNS records,
+// before their finalizers are removed on forced deletion. The records can be deleted later on with the
+// `shoot-dns-service-leaked-records` command line tool, as soon as the provider credentials are available again.
+func (a *actuator) recordLeakedRecords(exCtx extensionContext, entries []dnsv1alpha1.DNSEntry) error {
+	records := common.LeakedRecordsFromEntries(entries)
+	if len(records) == 0 {
+		return nil
+	}
+	if a.config.LeakedRecordsNamespace == "" {
+		exCtx.log.Info("DNS records may be leaked by forced deletion, but no namespace for the inventory is configured",
+			"namespace", exCtx.ex.Namespace, "count", len(records))
+		return nil
+	}
+
+	shootID, _, err := common.ShootID(exCtx.cluster)
+	if err != nil {
+		return err
+	}
+	if err := common.AddLeakedRecords(exCtx.ctx, a.client, a.config.LeakedRecordsNamespace, shootID, exCtx.ex.Namespace, records); err != nil {
+		return fmt.Errorf("recording leaked DNS records failed: %w", err)
+	}
+	exCtx.log.Info("Recorded DNS records possibly leaked by forced deletion", "namespace", exCtx.ex.Namespace,
+		"inventory", a.config.LeakedRecordsNamespace+"/"+common.LeakedRecordsConfigMapName(shootID), "count", len(records))
+	return nil
+}
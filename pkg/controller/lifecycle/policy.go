@@ -0,0 +1,123 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package lifecycle
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/gardener/gardener/extensions/pkg/controller"
+	gardencore "github.com/gardener/gardener/pkg/apis/core"
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	policyv1alpha1 "github.com/gardener/gardener-extension-shoot-dns-service/pkg/apis/policy/v1alpha1"
+	policyhelper "github.com/gardener/gardener-extension-shoot-dns-service/pkg/apis/policy/v1alpha1/helper"
+	servicev1alpha1 "github.com/gardener/gardener-extension-shoot-dns-service/pkg/apis/service/v1alpha1"
+	"github.com/gardener/gardener-extension-shoot-dns-service/pkg/apis/service/validation"
+	"github.com/gardener/gardener-extension-shoot-dns-service/pkg/controller/config"
+)
+
+// defaultControllerLogLevel is the log level of the DNS controller in the shoot namespace if not set by a policy.
+const defaultControllerLogLevel = "info"
+
+// appliedPolicy is the rule of a ShootDNSServicePolicy applied to a shoot.
+type appliedPolicy struct {
+	name     string
+	rule     string
+	settings policyv1alpha1.ShootDNSServiceSettings
+}
+
+// resolvePolicy returns the rule of the ShootDNSServicePolicies in the seed applying to the shoot of the cluster,
+// or nil if there is none.
+func (a *actuator) resolvePolicy(ctx context.Context, log logr.Logger, cluster *controller.Cluster) (*appliedPolicy, error) {
+	if cluster.Shoot == nil {
+		return nil, nil
+	}
+	list := &policyv1alpha1.ShootDNSServicePolicyList{}
+	if err := a.client.List(ctx, list); err != nil {
+		if meta.IsNoMatchError(err) {
+			// the CRD is not installed, i.e. there are no policies
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to list ShootDNSServicePolicies: %w", err)
+	}
+
+	policy, rule, err := policyhelper.FindRule(list.Items, validation.ProjectNameFromTechnicalID(cluster.Shoot.Status.TechnicalID, cluster.Shoot.Name), cluster.Shoot)
+	if err != nil {
+		log.Error(err, "Ignoring invalid rules of ShootDNSServicePolicies")
+	}
+	if rule == nil {
+		return nil, nil
+	}
+	log.Info("Applying ShootDNSServicePolicy", "policy", policy.Name, "rule", rule.Name)
+	return &appliedPolicy{
+		name:     policy.Name,
+		rule:     rule.Name,
+		settings: *rule.Settings.DeepCopy(),
+	}, nil
+}
+
+// applyTo overwrites the settings of the given configuration which are set by the policy.
+// The quotas of the policy are considered by getDefaultDomainQuota to report them as source of the quota.
+func (p *appliedPolicy) applyTo(cfg *config.DNSServiceConfig) {
+	if p == nil {
+		return
+	}
+	if p.settings.DNSProviderReplication != nil {
+		cfg.ReplicateDNSProviders = *p.settings.DNSProviderReplication
+	}
+}
+
+// allowedProviderTypes returns the allowed provider types set by the policy, or nil if not set.
+func (p *appliedPolicy) allowedProviderTypes() []string {
+	if p == nil {
+		return nil
+	}
+	return p.settings.AllowedProviderTypes
+}
+
+// allowedProviderTypes returns the types of additional DNS providers allowed for the shoot of the extension context.
+// The allowed types of the policy restrict the provider type restrictions of the configuration further.
+func (a *actuator) allowedProviderTypes(exCtx extensionContext) sets.Set[string] {
+	var seedLabels map[string]string
+	if exCtx.cluster.Seed != nil {
+		seedLabels = exCtx.cluster.Seed.Labels
+	}
+	project := validation.ProjectNameFromTechnicalID(exCtx.cluster.Shoot.Status.TechnicalID, exCtx.cluster.Shoot.Name)
+	allowed := a.config.ProviderTypeRestrictions.EffectiveProviderTypes(project, seedLabels)
+	return sets.New(validation.RestrictProviderTypes(allowed, exCtx.policy.allowedProviderTypes())...)
+}
+
+// disallowedProviders returns the additional DNS providers of the provider config whose type is not allowed for the
+// shoot. They are not deployed, but reported in the provider status of the extension.
+func (a *actuator) disallowedProviders(exCtx extensionContext) []servicev1alpha1.DNSDisallowedProviderStatus {
+	if exCtx.dnsconfig == nil || len(exCtx.dnsconfig.Providers) == 0 {
+		return nil
+	}
+	allowed := a.allowedProviderTypes(exCtx)
+	var result []servicev1alpha1.DNSDisallowedProviderStatus
+	for i, p := range exCtx.dnsconfig.Providers {
+		if p.Type == nil || *p.Type == gardencore.DNSUnmanaged || allowed.Has(*p.Type) {
+			continue
+		}
+		result = append(result, servicev1alpha1.DNSDisallowedProviderStatus{
+			Index:   int32(i),
+			Type:    *p.Type,
+			Message: fmt.Sprintf("has type %q which is not allowed. Allowed types are: %s", *p.Type, strings.Join(sets.List(allowed), ", ")),
+		})
+	}
+	return result
+}
+
+// controllerLogLevel returns the log level of the DNS controller in the shoot namespace.
+func (p *appliedPolicy) controllerLogLevel() string {
+	if p == nil || p.settings.ControllerLogLevel == nil {
+		return defaultControllerLogLevel
+	}
+	return *p.settings.ControllerLogLevel
+}
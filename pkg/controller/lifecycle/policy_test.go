@@ -0,0 +1,106 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package lifecycle
+
+import (
+	"github.com/gardener/gardener/extensions/pkg/controller"
+	gardencore "github.com/gardener/gardener/pkg/apis/core"
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	policyv1alpha1 "github.com/gardener/gardener-extension-shoot-dns-service/pkg/apis/policy/v1alpha1"
+	apisservice "github.com/gardener/gardener-extension-shoot-dns-service/pkg/apis/service"
+	servicev1alpha1 "github.com/gardener/gardener-extension-shoot-dns-service/pkg/apis/service/v1alpha1"
+	"github.com/gardener/gardener-extension-shoot-dns-service/pkg/apis/service/validation"
+)
+
+var _ = Describe("Policy", func() {
+	var (
+		a     *actuator
+		exCtx extensionContext
+	)
+
+	BeforeEach(func() {
+		a = &actuator{}
+		exCtx = extensionContext{
+			dnsconfig: &apisservice.DNSConfig{
+				Providers: []apisservice.DNSProvider{
+					{Type: new("aws-route53")},
+					{Type: new("google-clouddns")},
+					{Type: new(gardencore.DNSUnmanaged)},
+					{},
+				},
+			},
+			cluster: &controller.Cluster{
+				Shoot: &gardencorev1beta1.Shoot{
+					ObjectMeta: metav1.ObjectMeta{Name: "bar", Namespace: "garden-foo"},
+					Status:     gardencorev1beta1.ShootStatus{TechnicalID: "shoot--foo--bar"},
+				},
+			},
+		}
+	})
+
+	Describe("#disallowedProviders", func() {
+		It("should report no providers if all types are allowed", func() {
+			Expect(a.disallowedProviders(exCtx)).To(BeEmpty())
+		})
+
+		It("should report the providers with types denied by the provider type restrictions", func() {
+			a.config.ProviderTypeRestrictions = &validation.ProviderTypeRestrictions{Denied: []string{"google-clouddns"}}
+
+			Expect(a.disallowedProviders(exCtx)).To(ConsistOf(And(
+				HaveField("Index", int32(1)),
+				HaveField("Type", "google-clouddns"),
+				HaveField("Message", ContainSubstring(`has type "google-clouddns" which is not allowed. Allowed types are: `)),
+			)))
+		})
+
+		It("should report the providers with types not allowed by the policy", func() {
+			exCtx.policy = &appliedPolicy{
+				name:     "policy",
+				rule:     "rule",
+				settings: policyv1alpha1.ShootDNSServiceSettings{AllowedProviderTypes: []string{"google-clouddns", "azure-dns"}},
+			}
+
+			Expect(a.disallowedProviders(exCtx)).To(Equal([]servicev1alpha1.DNSDisallowedProviderStatus{
+				{
+					Index:   0,
+					Type:    "aws-route53",
+					Message: `has type "aws-route53" which is not allowed. Allowed types are: azure-dns, google-clouddns`,
+				},
+			}))
+		})
+
+		It("should not allow types denied by the provider type restrictions again", func() {
+			a.config.ProviderTypeRestrictions = &validation.ProviderTypeRestrictions{Denied: []string{"google-clouddns"}}
+			exCtx.policy = &appliedPolicy{
+				name:     "policy",
+				rule:     "rule",
+				settings: policyv1alpha1.ShootDNSServiceSettings{AllowedProviderTypes: []string{"google-clouddns", "azure-dns"}},
+			}
+
+			Expect(a.disallowedProviders(exCtx)).To(Equal([]servicev1alpha1.DNSDisallowedProviderStatus{
+				{
+					Index:   0,
+					Type:    "aws-route53",
+					Message: `has type "aws-route53" which is not allowed. Allowed types are: azure-dns`,
+				},
+				{
+					Index:   1,
+					Type:    "google-clouddns",
+					Message: `has type "google-clouddns" which is not allowed. Allowed types are: azure-dns`,
+				},
+			}))
+		})
+
+		It("should report no providers without provider config", func() {
+			exCtx.policy = &appliedPolicy{settings: policyv1alpha1.ShootDNSServiceSettings{AllowedProviderTypes: []string{"azure-dns"}}}
+			exCtx.dnsconfig = nil
+			Expect(a.disallowedProviders(exCtx)).To(BeEmpty())
+		})
+	})
+})
@@ -0,0 +1,156 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package lifecycle
+
+import (
+	"bytes"
+	"encoding/json"
+	"slices"
+	"strings"
+
+	dnsv1alpha1 "github.com/gardener/external-dns-management/pkg/apis/dns/v1alpha1"
+	v1beta1helper "github.com/gardener/gardener/pkg/api/core/v1beta1/helper"
+	"github.com/prometheus/client_golang/prometheus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	servicev1alpha1 "github.com/gardener/gardener-extension-shoot-dns-service/pkg/apis/service/v1alpha1"
+	"github.com/gardener/gardener-extension-shoot-dns-service/pkg/controller/common"
+	"github.com/gardener/gardener-extension-shoot-dns-service/pkg/controller/metrics"
+)
+
+// DNSStatusKind is the kind of the provider status of the extension.
+const DNSStatusKind = "DNSStatus"
+
+// updateProviderStatus writes the state of the managed DNS providers and the shoot DNS entries
+// to the provider status of the extension.
+func (a *actuator) updateProviderStatus(exCtx extensionContext) error {
+	namespace := exCtx.ex.Namespace
+	entries, err := common.NewShootDNSEntriesHelper(exCtx.ctx, a.client, exCtx.ex).List()
+	if err != nil {
+		return err
+	}
+	providerList := &dnsv1alpha1.DNSProviderList{}
+	if err := a.client.List(exCtx.ctx, providerList, client.InNamespace(namespace)); err != nil {
+		return err
+	}
+
+	var quota defaultDomainQuota
+	if exCtx.cluster != nil && exCtx.cluster.Shoot != nil {
+		quota, _ = getDefaultDomainQuota(exCtx.globalConfig, exCtx.cluster, exCtx.policy)
+	}
+	status := buildDNSStatus(providerList.Items, entries, quota)
+	if exCtx.policy != nil {
+		status.Policy = &servicev1alpha1.DNSPolicyStatus{Name: exCtx.policy.name, Rule: exCtx.policy.rule}
+	}
+	if exCtx.cluster != nil && exCtx.cluster.Shoot != nil && a.isManagingDNSProviders(exCtx.cluster.Shoot.Spec.DNS) {
+		status.DisallowedProviders = a.disallowedProviders(exCtx)
+	}
+	recordDNSStatusMetrics(namespace, status)
+	raw, err := json.Marshal(status)
+	if err != nil {
+		return err
+	}
+	if exCtx.ex.Status.ProviderStatus != nil && bytes.Equal(exCtx.ex.Status.ProviderStatus.Raw, raw) {
+		return nil
+	}
+
+	patch := client.MergeFrom(exCtx.ex.DeepCopy())
+	exCtx.ex.Status.ProviderStatus = &runtime.RawExtension{Raw: raw}
+	return a.client.Status().Patch(exCtx.ctx, exCtx.ex, patch)
+}
+
+// buildDNSStatus builds the provider status. The source and message of the quota are reported for the entries quota
+// of the external provider.
+func buildDNSStatus(providers []dnsv1alpha1.DNSProvider, entries []dnsv1alpha1.DNSEntry, quota defaultDomainQuota) *servicev1alpha1.DNSStatus {
+	status := &servicev1alpha1.DNSStatus{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: servicev1alpha1.SchemeGroupVersion.String(),
+			Kind:       DNSStatusKind,
+		},
+		Entries: countDNSEntries(entries),
+	}
+
+	for _, provider := range providers {
+		if provider.Name != ExternalDNSProviderName && !isAdditionalProvider(provider) {
+			continue
+		}
+		providerStatus := servicev1alpha1.DNSProviderStatus{
+			Name:    provider.Name,
+			Type:    provider.Spec.Type,
+			State:   provider.Status.State,
+			Message: provider.Status.Message,
+		}
+		if err := CheckDNSProvider(&provider); err != nil {
+			providerStatus.ErrorCodes = v1beta1helper.ExtractErrorCodes(err)
+		}
+		if provider.Name == ExternalDNSProviderName {
+			providerStatus.EntriesQuota = &servicev1alpha1.DNSProviderQuotaUsage{
+				Used: countDNSEntriesOfProvider(entries, client.ObjectKeyFromObject(&provider).String()),
+			}
+			if provider.Spec.Quotas != nil {
+				providerStatus.EntriesQuota.Limit = ptr.Deref(provider.Spec.Quotas.Entries, 0)
+			}
+			providerStatus.EntriesQuota.Source = quota.source
+			providerStatus.EntriesQuota.Message = quota.message
+		}
+		status.Providers = append(status.Providers, providerStatus)
+	}
+	slices.SortFunc(status.Providers, func(a, b servicev1alpha1.DNSProviderStatus) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+	return status
+}
+
+// recordDNSStatusMetrics sets the metrics of the DNS entries and managed DNS providers of the shoot namespace.
+func recordDNSStatusMetrics(namespace string, status *servicev1alpha1.DNSStatus) {
+	metrics.DNSEntries.WithLabelValues(namespace, "ready").Set(float64(status.Entries.Ready))
+	metrics.DNSEntries.WithLabelValues(namespace, "pending").Set(float64(status.Entries.Pending))
+	metrics.DNSEntries.WithLabelValues(namespace, "error").Set(float64(status.Entries.Error))
+	metrics.DNSEntries.WithLabelValues(namespace, "stale").Set(float64(status.Entries.Stale))
+
+	// providers may have been removed since the last reconciliation
+	metrics.DNSProviderReady.DeletePartialMatch(prometheus.Labels{metrics.LabelNamespace: namespace})
+	for _, provider := range status.Providers {
+		ready := 0.0
+		if provider.State == dnsv1alpha1.STATE_READY {
+			ready = 1
+		}
+		metrics.DNSProviderReady.WithLabelValues(namespace, provider.Name, provider.Type).Set(ready)
+		if provider.EntriesQuota != nil {
+			metrics.ExternalProviderEntriesQuotaUsed.WithLabelValues(namespace).Set(float64(provider.EntriesQuota.Used))
+			metrics.ExternalProviderEntriesQuotaLimit.WithLabelValues(namespace).Set(float64(provider.EntriesQuota.Limit))
+		}
+	}
+}
+
+func countDNSEntries(entries []dnsv1alpha1.DNSEntry) servicev1alpha1.DNSEntriesStatus {
+	result := servicev1alpha1.DNSEntriesStatus{Total: int32(len(entries))}
+	for _, entry := range entries {
+		switch entry.Status.State {
+		case dnsv1alpha1.STATE_READY:
+			result.Ready++
+		case "", dnsv1alpha1.STATE_PENDING:
+			result.Pending++
+		case dnsv1alpha1.STATE_ERROR, dnsv1alpha1.STATE_INVALID:
+			result.Error++
+		case dnsv1alpha1.STATE_STALE:
+			result.Stale++
+		}
+	}
+	return result
+}
+
+func countDNSEntriesOfProvider(entries []dnsv1alpha1.DNSEntry, providerKey string) int32 {
+	var count int32
+	for _, entry := range entries {
+		if ptr.Deref(entry.Status.Provider, "") == providerKey {
+			count++
+		}
+	}
+	return count
+}
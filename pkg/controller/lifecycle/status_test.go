@@ -0,0 +1,164 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package lifecycle
+
+import (
+	dnsv1alpha1 "github.com/gardener/external-dns-management/pkg/apis/dns/v1alpha1"
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	v1beta1constants "github.com/gardener/gardener/pkg/apis/core/v1beta1/constants"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	servicev1alpha1 "github.com/gardener/gardener-extension-shoot-dns-service/pkg/apis/service/v1alpha1"
+	"github.com/gardener/gardener-extension-shoot-dns-service/pkg/controller/metrics"
+)
+
+var _ = Describe("buildDNSStatus", func() {
+	const namespace = "shoot--foo--bar"
+
+	var (
+		newProvider = func(name, providerType, state string, message *string, labels map[string]string) dnsv1alpha1.DNSProvider {
+			return dnsv1alpha1.DNSProvider{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      name,
+					Namespace: namespace,
+					Labels:    labels,
+				},
+				Spec: dnsv1alpha1.DNSProviderSpec{
+					Type: providerType,
+				},
+				Status: dnsv1alpha1.DNSProviderStatus{
+					State:   state,
+					Message: message,
+				},
+			}
+		}
+
+		newEntry = func(name, state string, provider *string) dnsv1alpha1.DNSEntry {
+			return dnsv1alpha1.DNSEntry{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      name,
+					Namespace: namespace,
+				},
+				Status: dnsv1alpha1.DNSEntryStatus{
+					State:    state,
+					Provider: provider,
+				},
+			}
+		}
+	)
+
+	It("should build the status of providers and entries", func() {
+		external := newProvider("external", "aws-route53", dnsv1alpha1.STATE_READY, nil, nil)
+		external.Spec.Quotas = &dnsv1alpha1.Quotas{Entries: new(int32(100))}
+		additionalLabels := map[string]string{v1beta1constants.GardenRole: DNSProviderRoleAdditional}
+		providers := []dnsv1alpha1.DNSProvider{
+			newProvider("google-clouddns-secret", "google-clouddns", dnsv1alpha1.STATE_ERROR, new("AccessDenied: permission missing"), additionalLabels),
+			external,
+			newProvider("replicated", "aws-route53", dnsv1alpha1.STATE_READY, nil, map[string]string{"gardener.cloud/shoot-id": "foo"}),
+		}
+		entries := []dnsv1alpha1.DNSEntry{
+			newEntry("e1", dnsv1alpha1.STATE_READY, new(namespace+"/external")),
+			newEntry("e2", dnsv1alpha1.STATE_READY, new(namespace+"/external")),
+			newEntry("e3", dnsv1alpha1.STATE_PENDING, new(namespace+"/google-clouddns-secret")),
+			newEntry("e4", "", nil),
+			newEntry("e5", dnsv1alpha1.STATE_ERROR, nil),
+			newEntry("e6", dnsv1alpha1.STATE_INVALID, nil),
+			newEntry("e7", dnsv1alpha1.STATE_STALE, new(namespace+"/external")),
+		}
+
+		Expect(buildDNSStatus(providers, entries, defaultDomainQuota{value: 100, source: "rule:production"})).To(Equal(&servicev1alpha1.DNSStatus{
+			TypeMeta: metav1.TypeMeta{
+				APIVersion: "service.dns.extensions.gardener.cloud/v1alpha1",
+				Kind:       "DNSStatus",
+			},
+			Providers: []servicev1alpha1.DNSProviderStatus{
+				{
+					Name:         "external",
+					Type:         "aws-route53",
+					State:        dnsv1alpha1.STATE_READY,
+					EntriesQuota: &servicev1alpha1.DNSProviderQuotaUsage{Used: 3, Limit: 100, Source: "rule:production"},
+				},
+				{
+					Name:       "google-clouddns-secret",
+					Type:       "google-clouddns",
+					State:      dnsv1alpha1.STATE_ERROR,
+					Message:    new("AccessDenied: permission missing"),
+					ErrorCodes: []gardencorev1beta1.ErrorCode{gardencorev1beta1.ErrorInfraUnauthorized},
+				},
+			},
+			Entries: servicev1alpha1.DNSEntriesStatus{
+				Total:   7,
+				Ready:   2,
+				Pending: 2,
+				Error:   2,
+				Stale:   1,
+			},
+		}))
+	})
+
+	It("should report the message of a limited quota", func() {
+		external := newProvider("external", "aws-route53", dnsv1alpha1.STATE_READY, nil, nil)
+		external.Spec.Quotas = &dnsv1alpha1.Quotas{Entries: new(int32(500))}
+
+		status := buildDNSStatus([]dnsv1alpha1.DNSProvider{external}, nil, defaultDomainQuota{value: 500, source: "annotation", message: "exceeds the maximum"})
+		Expect(status.Providers).To(HaveLen(1))
+		Expect(status.Providers[0].EntriesQuota).To(Equal(&servicev1alpha1.DNSProviderQuotaUsage{Limit: 500, Source: "annotation", Message: "exceeds the maximum"}))
+	})
+
+	It("should build an empty status", func() {
+		status := buildDNSStatus(nil, nil, defaultDomainQuota{})
+		Expect(status.Providers).To(BeEmpty())
+		Expect(status.Entries).To(Equal(servicev1alpha1.DNSEntriesStatus{}))
+	})
+})
+
+var _ = Describe("recordDNSStatusMetrics", func() {
+	const namespace = "shoot--foo--metrics"
+
+	AfterEach(func() {
+		metrics.DeleteShoot(namespace)
+	})
+
+	It("should record the metrics of entries, providers and the quota of the external provider", func() {
+		recordDNSStatusMetrics(namespace, &servicev1alpha1.DNSStatus{
+			Providers: []servicev1alpha1.DNSProviderStatus{
+				{
+					Name:         "external",
+					Type:         "aws-route53",
+					State:        dnsv1alpha1.STATE_READY,
+					EntriesQuota: &servicev1alpha1.DNSProviderQuotaUsage{Used: 3, Limit: 100, Source: "rule:production"},
+				},
+				{
+					Name:  "additional",
+					Type:  "google-clouddns",
+					State: dnsv1alpha1.STATE_ERROR,
+				},
+			},
+			Entries: servicev1alpha1.DNSEntriesStatus{Total: 4, Ready: 2, Pending: 1, Stale: 1},
+		})
+
+		Expect(testutil.ToFloat64(metrics.DNSEntries.WithLabelValues(namespace, "ready"))).To(Equal(2.0))
+		Expect(testutil.ToFloat64(metrics.DNSEntries.WithLabelValues(namespace, "pending"))).To(Equal(1.0))
+		Expect(testutil.ToFloat64(metrics.DNSEntries.WithLabelValues(namespace, "error"))).To(Equal(0.0))
+		Expect(testutil.ToFloat64(metrics.DNSEntries.WithLabelValues(namespace, "stale"))).To(Equal(1.0))
+		Expect(testutil.ToFloat64(metrics.DNSProviderReady.WithLabelValues(namespace, "external", "aws-route53"))).To(Equal(1.0))
+		Expect(testutil.ToFloat64(metrics.DNSProviderReady.WithLabelValues(namespace, "additional", "google-clouddns"))).To(Equal(0.0))
+		Expect(testutil.ToFloat64(metrics.ExternalProviderEntriesQuotaUsed.WithLabelValues(namespace))).To(Equal(3.0))
+		Expect(testutil.ToFloat64(metrics.ExternalProviderEntriesQuotaLimit.WithLabelValues(namespace))).To(Equal(100.0))
+	})
+
+	It("should drop the readiness of removed providers", func() {
+		recordDNSStatusMetrics(namespace, &servicev1alpha1.DNSStatus{
+			Providers: []servicev1alpha1.DNSProviderStatus{{Name: "additional", Type: "google-clouddns", State: dnsv1alpha1.STATE_READY}},
+		})
+		Expect(testutil.CollectAndCount(metrics.DNSProviderReady)).To(Equal(1))
+
+		recordDNSStatusMetrics(namespace, &servicev1alpha1.DNSStatus{})
+		Expect(testutil.CollectAndCount(metrics.DNSProviderReady)).To(Equal(0))
+	})
+})
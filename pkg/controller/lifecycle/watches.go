@@ -0,0 +1,275 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package lifecycle
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	dnsv1alpha1 "github.com/gardener/external-dns-management/pkg/apis/dns/v1alpha1"
+	extensionscontroller "github.com/gardener/gardener/extensions/pkg/controller"
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	v1beta1constants "github.com/gardener/gardener/pkg/apis/core/v1beta1/constants"
+	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
+	resourcesv1alpha1 "github.com/gardener/gardener/pkg/apis/resources/v1alpha1"
+	predicateutils "github.com/gardener/gardener/pkg/controllerutils/predicate"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	policyv1alpha1 "github.com/gardener/gardener-extension-shoot-dns-service/pkg/apis/policy/v1alpha1"
+	"github.com/gardener/gardener-extension-shoot-dns-service/pkg/controller/common"
+	"github.com/gardener/gardener-extension-shoot-dns-service/pkg/controller/config"
+	"github.com/gardener/gardener-extension-shoot-dns-service/pkg/service"
+)
+
+// RemoteDefaultDomainsSecretName is the name of the copy of the remote default domain secret in the shoot namespace.
+const RemoteDefaultDomainsSecretName = "shoot-dns-service-remote-default-domains"
+
+// watches provides the additional watches of the lifecycle controller, which requeue the Extension
+// if a resource it depends on has changed.
+type watches struct {
+	client                    client.Reader
+	remoteDefaultDomainSecret *types.NamespacedName
+	debounce                  time.Duration
+	resyncPeriod              time.Duration
+	resyncJitterFactor        float64
+}
+
+func newWatchBuilder(mgr manager.Manager, cfg config.DNSServiceConfig, opts AddOptions) extensionscontroller.WatchBuilder {
+	w := &watches{
+		client:                    mgr.GetClient(),
+		remoteDefaultDomainSecret: cfg.RemoteDefaultDomainSecret,
+		debounce:                  opts.WatchDebounce,
+		resyncPeriod:              opts.ResyncPeriod,
+		resyncJitterFactor:        opts.ResyncJitterFactor,
+	}
+
+	builder := extensionscontroller.NewWatchBuilder(
+		func(c controller.Controller) error {
+			return c.Watch(source.Kind[client.Object](mgr.GetCache(), &dnsv1alpha1.DNSProvider{},
+				w.debouncedHandler(w.mapToExtensionsInNamespace), w.dnsProviderPredicate()))
+		},
+		func(c controller.Controller) error {
+			return c.Watch(source.Kind[client.Object](mgr.GetCache(), &dnsv1alpha1.DNSEntry{},
+				w.debouncedHandler(w.mapToExtensionsInNamespace), w.dnsEntryPredicate()))
+		},
+		func(c controller.Controller) error {
+			return c.Watch(source.Kind[client.Object](mgr.GetCache(), &policyv1alpha1.ShootDNSServicePolicy{},
+				w.debouncedHandler(w.mapToAllExtensions), predicate.GenerationChangedPredicate{}))
+		},
+		func(c controller.Controller) error {
+			// Secrets are only watched by their metadata to avoid caching all secrets of the seed.
+			secret := &metav1.PartialObjectMetadata{}
+			secret.SetGroupVersionKind(corev1.SchemeGroupVersion.WithKind("Secret"))
+			return c.Watch(source.Kind[client.Object](mgr.GetCache(), secret,
+				w.debouncedHandler(w.mapSecretToExtensions), w.secretPredicate()))
+		},
+	)
+	if w.resyncPeriod > 0 {
+		builder.Register(func(c controller.Controller) error {
+			return c.Watch(source.Kind[client.Object](mgr.GetCache(), &extensionsv1alpha1.Extension{},
+				w.resyncHandler(), predicateutils.HasType(service.ExtensionType)))
+		})
+	}
+	return builder
+}
+
+// debouncedHandler enqueues the requests returned by the map function after the debounce delay.
+// As the delaying work queue only keeps the earliest point in time for an item, all events for the same
+// Extension within this delay result in a single reconciliation.
+func (w *watches) debouncedHandler(mapFunc handler.MapFunc) handler.EventHandler {
+	enqueue := func(ctx context.Context, obj client.Object, q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+		for _, req := range mapFunc(ctx, obj) {
+			if w.debounce > 0 {
+				q.AddAfter(req, w.debounce)
+			} else {
+				q.Add(req)
+			}
+		}
+	}
+
+	return handler.Funcs{
+		CreateFunc: func(ctx context.Context, e event.CreateEvent, q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+			enqueue(ctx, e.Object, q)
+		},
+		UpdateFunc: func(ctx context.Context, e event.UpdateEvent, q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+			enqueue(ctx, e.ObjectNew, q)
+		},
+		DeleteFunc: func(ctx context.Context, e event.DeleteEvent, q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+			enqueue(ctx, e.Object, q)
+		},
+		GenericFunc: func(ctx context.Context, e event.GenericEvent, q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+			enqueue(ctx, e.Object, q)
+		},
+	}
+}
+
+// resyncHandler schedules the periodic reconciliation of the Extension with a jittered resync period,
+// once it has been created or successfully reconciled. This replaces the fixed requeue interval of the
+// generic Extension reconciler, so that the reconciliations of all shoots are spread over time.
+func (w *watches) resyncHandler() handler.EventHandler {
+	schedule := func(obj client.Object, q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+		q.AddAfter(reconcile.Request{NamespacedName: client.ObjectKeyFromObject(obj)}, wait.Jitter(w.resyncPeriod, w.resyncJitterFactor))
+	}
+
+	return handler.Funcs{
+		CreateFunc: func(_ context.Context, e event.CreateEvent, q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+			if e.Object.GetDeletionTimestamp() == nil {
+				schedule(e.Object, q)
+			}
+		},
+		UpdateFunc: func(_ context.Context, e event.UpdateEvent, q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+			oldEx, ok1 := e.ObjectOld.(*extensionsv1alpha1.Extension)
+			newEx, ok2 := e.ObjectNew.(*extensionsv1alpha1.Extension)
+			if ok1 && ok2 && reconciledSuccessfully(oldEx, newEx) {
+				schedule(newEx, q)
+			}
+		},
+	}
+}
+
+func reconciledSuccessfully(oldEx, newEx *extensionsv1alpha1.Extension) bool {
+	if newEx.DeletionTimestamp != nil {
+		return false
+	}
+	op := newEx.Status.LastOperation
+	if op == nil || op.State != gardencorev1beta1.LastOperationStateSucceeded {
+		return false
+	}
+	switch op.Type {
+	case gardencorev1beta1.LastOperationTypeCreate, gardencorev1beta1.LastOperationTypeReconcile, gardencorev1beta1.LastOperationTypeRestore:
+	default:
+		return false
+	}
+	oldOp := oldEx.Status.LastOperation
+	return oldOp == nil || oldOp.State != op.State || !oldOp.LastUpdateTime.Equal(&op.LastUpdateTime)
+}
+
+// mapToExtensionsInNamespace maps an object in a shoot namespace to the shoot-dns-service Extension of this namespace.
+func (w *watches) mapToExtensionsInNamespace(ctx context.Context, obj client.Object) []reconcile.Request {
+	ex, err := common.FindExtension(ctx, w.client, obj.GetNamespace())
+	if err != nil {
+		logf.FromContext(ctx).Error(err, "Failed to find extension", "namespace", obj.GetNamespace())
+		return nil
+	}
+	if ex == nil || ex.DeletionTimestamp != nil {
+		return nil
+	}
+	return []reconcile.Request{{NamespacedName: client.ObjectKeyFromObject(ex)}}
+}
+
+// mapSecretToExtensions maps a changed secret to the Extensions depending on it. The remote default domain
+// secret is used by all shoots of the seed, all other secrets only by the Extension in their namespace.
+func (w *watches) mapSecretToExtensions(ctx context.Context, obj client.Object) []reconcile.Request {
+	if !w.isRemoteDefaultDomainSecret(obj) {
+		return w.mapToExtensionsInNamespace(ctx, obj)
+	}
+	return w.mapToAllExtensions(ctx, obj)
+}
+
+// mapToAllExtensions maps an object used by all shoots of the seed to all shoot-dns-service Extensions.
+func (w *watches) mapToAllExtensions(ctx context.Context, _ client.Object) []reconcile.Request {
+	list := &extensionsv1alpha1.ExtensionList{}
+	if err := w.client.List(ctx, list); err != nil {
+		logf.FromContext(ctx).Error(err, "Failed to list extensions")
+		return nil
+	}
+	var requests []reconcile.Request
+	for _, ex := range list.Items {
+		if ex.Spec.Type == service.ExtensionType && ex.DeletionTimestamp == nil {
+			requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&ex)})
+		}
+	}
+	return requests
+}
+
+func (w *watches) isRemoteDefaultDomainSecret(obj client.Object) bool {
+	return w.remoteDefaultDomainSecret != nil && client.ObjectKeyFromObject(obj) == *w.remoteDefaultDomainSecret
+}
+
+// dnsProviderPredicate reacts on DNSProviders entering or leaving a failed state and on their deletion.
+// Creations are ignored, as the providers are either created by the Extension itself or reported on its
+// next reconciliation anyway.
+func (w *watches) dnsProviderPredicate() predicate.Predicate {
+	return predicate.Funcs{
+		CreateFunc: func(event.CreateEvent) bool { return false },
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			oldProvider, ok1 := e.ObjectOld.(*dnsv1alpha1.DNSProvider)
+			newProvider, ok2 := e.ObjectNew.(*dnsv1alpha1.DNSProvider)
+			return ok1 && ok2 && isFailedState(oldProvider.Status.State) != isFailedState(newProvider.Status.State)
+		},
+		DeleteFunc:  func(event.DeleteEvent) bool { return true },
+		GenericFunc: func(event.GenericEvent) bool { return false },
+	}
+}
+
+// dnsEntryPredicate reacts on DNSEntries of the shoot entering or leaving a failed state.
+func (w *watches) dnsEntryPredicate() predicate.Predicate {
+	return predicate.And(
+		predicate.NewPredicateFuncs(func(obj client.Object) bool {
+			_, ok := obj.GetLabels()[common.ShootDNSEntryLabelKey]
+			return ok
+		}),
+		predicate.Funcs{
+			CreateFunc: func(event.CreateEvent) bool { return false },
+			UpdateFunc: func(e event.UpdateEvent) bool {
+				oldEntry, ok1 := e.ObjectOld.(*dnsv1alpha1.DNSEntry)
+				newEntry, ok2 := e.ObjectNew.(*dnsv1alpha1.DNSEntry)
+				return ok1 && ok2 && isFailedState(oldEntry.Status.State) != isFailedState(newEntry.Status.State)
+			},
+			DeleteFunc:  func(event.DeleteEvent) bool { return false },
+			GenericFunc: func(event.GenericEvent) bool { return false },
+		},
+	)
+}
+
+// secretPredicate reacts on changes and deletions of the secrets referenced by the DNS providers of a shoot
+// and of the remote default domain secret. Creations are ignored to avoid reconciling all Extensions on startup.
+// Token renewals of workload identity secrets are ignored, as the DNS providers read the token on their own.
+func (w *watches) secretPredicate() predicate.Predicate {
+	return predicate.And(
+		predicate.NewPredicateFuncs(func(obj client.Object) bool {
+			return w.isRemoteDefaultDomainSecret(obj) || isShootProviderSecret(obj.GetName())
+		}),
+		predicate.Funcs{
+			CreateFunc: func(event.CreateEvent) bool { return false },
+			UpdateFunc: func(e event.UpdateEvent) bool {
+				if e.ObjectOld.GetResourceVersion() == e.ObjectNew.GetResourceVersion() {
+					return false
+				}
+				return e.ObjectOld.GetAnnotations()[resourcesv1alpha1.ServiceAccountTokenRenewTimestamp] ==
+					e.ObjectNew.GetAnnotations()[resourcesv1alpha1.ServiceAccountTokenRenewTimestamp]
+			},
+			DeleteFunc:  func(event.DeleteEvent) bool { return true },
+			GenericFunc: func(event.GenericEvent) bool { return false },
+		},
+	)
+}
+
+// isShootProviderSecret returns true for the secrets which may be referenced by DNS providers. The cloud provider
+// secret is referenced by providers using the CredentialsBinding of the shoot.
+func isShootProviderSecret(name string) bool {
+	return strings.HasPrefix(name, v1beta1constants.ReferencedResourcesPrefix) ||
+		strings.HasPrefix(name, v1beta1constants.ReferencedWorkloadIdentityPrefix) ||
+		name == v1beta1constants.SecretNameCloudProvider ||
+		name == RemoteDefaultDomainsSecretName
+}
+
+func isFailedState(state string) bool {
+	return state == dnsv1alpha1.STATE_ERROR || state == dnsv1alpha1.STATE_INVALID
+}
@@ -0,0 +1,174 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package lifecycle
+
+import (
+	"context"
+	"time"
+
+	dnsv1alpha1 "github.com/gardener/external-dns-management/pkg/apis/dns/v1alpha1"
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
+	resourcesv1alpha1 "github.com/gardener/gardener/pkg/apis/resources/v1alpha1"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/gardener/gardener-extension-shoot-dns-service/pkg/controller/common"
+	"github.com/gardener/gardener-extension-shoot-dns-service/pkg/service"
+)
+
+type fakeQueue struct {
+	workqueue.TypedRateLimitingInterface[reconcile.Request]
+	added map[reconcile.Request]time.Duration
+}
+
+func (q *fakeQueue) Add(req reconcile.Request) {
+	q.added[req] = 0
+}
+
+func (q *fakeQueue) AddAfter(req reconcile.Request, d time.Duration) {
+	if old, ok := q.added[req]; !ok || d < old {
+		q.added[req] = d
+	}
+}
+
+var _ = Describe("Watches", func() {
+	var (
+		ctx   context.Context
+		w     *watches
+		queue *fakeQueue
+
+		remoteSecret = types.NamespacedName{Namespace: "garden", Name: "remote-default-domain"}
+
+		newExtension = func(namespace, extensionType string) *extensionsv1alpha1.Extension {
+			return &extensionsv1alpha1.Extension{
+				ObjectMeta: metav1.ObjectMeta{Name: "ext", Namespace: namespace},
+				Spec:       extensionsv1alpha1.ExtensionSpec{DefaultSpec: extensionsv1alpha1.DefaultSpec{Type: extensionType}},
+			}
+		}
+		newSecret = func(namespace, name string) *metav1.PartialObjectMetadata {
+			return &metav1.PartialObjectMetadata{ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name, ResourceVersion: "1"}}
+		}
+		request = func(namespace string) reconcile.Request {
+			return reconcile.Request{NamespacedName: types.NamespacedName{Namespace: namespace, Name: "ext"}}
+		}
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		scheme := runtime.NewScheme()
+		Expect(extensionsv1alpha1.AddToScheme(scheme)).To(Succeed())
+		seedClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+			newExtension("shoot--foo--bar", service.ExtensionType),
+			newExtension("shoot--foo--baz", service.ExtensionType),
+			newExtension("shoot--foo--other", "other"),
+		).Build()
+
+		w = &watches{
+			client:                    seedClient,
+			remoteDefaultDomainSecret: &remoteSecret,
+			debounce:                  10 * time.Second,
+			resyncPeriod:              time.Hour,
+			resyncJitterFactor:        0.1,
+		}
+		queue = &fakeQueue{added: map[reconcile.Request]time.Duration{}}
+	})
+
+	It("should requeue the extension of the namespace with debounce", func() {
+		h := w.debouncedHandler(w.mapToExtensionsInNamespace)
+		provider := &dnsv1alpha1.DNSProvider{ObjectMeta: metav1.ObjectMeta{Namespace: "shoot--foo--bar", Name: "external"}}
+		h.Update(ctx, event.UpdateEvent{ObjectOld: provider, ObjectNew: provider}, queue)
+		h.Delete(ctx, event.DeleteEvent{Object: &dnsv1alpha1.DNSProvider{ObjectMeta: metav1.ObjectMeta{Namespace: "shoot--foo--other", Name: "external"}}}, queue)
+
+		Expect(queue.added).To(Equal(map[reconcile.Request]time.Duration{request("shoot--foo--bar"): 10 * time.Second}))
+	})
+
+	It("should requeue all extensions on changes of the remote default domain secret", func() {
+		h := w.debouncedHandler(w.mapSecretToExtensions)
+		h.Delete(ctx, event.DeleteEvent{Object: newSecret(remoteSecret.Namespace, remoteSecret.Name)}, queue)
+
+		Expect(queue.added).To(HaveLen(2))
+		Expect(queue.added).To(HaveKey(request("shoot--foo--bar")))
+		Expect(queue.added).To(HaveKey(request("shoot--foo--baz")))
+	})
+
+	It("should only react on failed state changes of DNS providers", func() {
+		p := w.dnsProviderPredicate()
+		oldProvider := &dnsv1alpha1.DNSProvider{Status: dnsv1alpha1.DNSProviderStatus{State: dnsv1alpha1.STATE_READY}}
+		newProvider := oldProvider.DeepCopy()
+		newProvider.Generation = 2
+		Expect(p.Update(event.UpdateEvent{ObjectOld: oldProvider, ObjectNew: newProvider})).To(BeFalse())
+		newProvider.Status.State = dnsv1alpha1.STATE_ERROR
+		Expect(p.Update(event.UpdateEvent{ObjectOld: oldProvider, ObjectNew: newProvider})).To(BeTrue())
+		Expect(p.Create(event.CreateEvent{Object: newProvider})).To(BeFalse())
+		Expect(p.Delete(event.DeleteEvent{Object: newProvider})).To(BeTrue())
+	})
+
+	It("should only react on failed state changes of DNS entries of the shoot", func() {
+		p := w.dnsEntryPredicate()
+		oldEntry := &dnsv1alpha1.DNSEntry{Status: dnsv1alpha1.DNSEntryStatus{State: dnsv1alpha1.STATE_READY}}
+		newEntry := oldEntry.DeepCopy()
+		newEntry.Status.State = dnsv1alpha1.STATE_INVALID
+		Expect(p.Update(event.UpdateEvent{ObjectOld: oldEntry, ObjectNew: newEntry})).To(BeFalse())
+
+		oldEntry.Labels = map[string]string{common.ShootDNSEntryLabelKey: "shoot-id"}
+		newEntry.Labels = oldEntry.Labels
+		Expect(p.Update(event.UpdateEvent{ObjectOld: oldEntry, ObjectNew: newEntry})).To(BeTrue())
+	})
+
+	It("should only react on changes of referenced secrets", func() {
+		p := w.secretPredicate()
+		update := func(name string, annotations map[string]string) bool {
+			oldSecret := newSecret("shoot--foo--bar", name)
+			newSecret := oldSecret.DeepCopy()
+			newSecret.ResourceVersion = "2"
+			newSecret.Annotations = annotations
+			return p.Update(event.UpdateEvent{ObjectOld: oldSecret, ObjectNew: newSecret})
+		}
+
+		Expect(update("ref-dns-credentials", nil)).To(BeTrue())
+		Expect(update("workload-identity-ref-dns", nil)).To(BeTrue())
+		Expect(update("cloudprovider", nil)).To(BeTrue())
+		Expect(update(RemoteDefaultDomainsSecretName, nil)).To(BeTrue())
+		Expect(update("some-secret", nil)).To(BeFalse())
+		Expect(update("workload-identity-ref-dns", map[string]string{resourcesv1alpha1.ServiceAccountTokenRenewTimestamp: "2026-01-01T00:00:00Z"})).To(BeFalse())
+		Expect(p.Update(event.UpdateEvent{ObjectOld: newSecret(remoteSecret.Namespace, remoteSecret.Name), ObjectNew: newSecret(remoteSecret.Namespace, remoteSecret.Name)})).To(BeFalse())
+		Expect(p.Create(event.CreateEvent{Object: newSecret("shoot--foo--bar", "ref-dns-credentials")})).To(BeFalse())
+		Expect(p.Delete(event.DeleteEvent{Object: newSecret(remoteSecret.Namespace, remoteSecret.Name)})).To(BeTrue())
+	})
+
+	It("should schedule the resync with jitter after successful reconciliations", func() {
+		h := w.resyncHandler()
+		oldEx := newExtension("shoot--foo--bar", service.ExtensionType)
+		oldEx.Status.LastOperation = &gardencorev1beta1.LastOperation{
+			Type:  gardencorev1beta1.LastOperationTypeReconcile,
+			State: gardencorev1beta1.LastOperationStateProcessing,
+		}
+		newEx := oldEx.DeepCopy()
+		h.Update(ctx, event.UpdateEvent{ObjectOld: oldEx, ObjectNew: newEx}, queue)
+		Expect(queue.added).To(BeEmpty())
+
+		newEx.Status.LastOperation.State = gardencorev1beta1.LastOperationStateSucceeded
+		h.Update(ctx, event.UpdateEvent{ObjectOld: oldEx, ObjectNew: newEx}, queue)
+		Expect(queue.added).To(HaveKeyWithValue(request("shoot--foo--bar"), And(
+			BeNumerically(">=", time.Hour),
+			BeNumerically("<=", 66*time.Minute),
+		)))
+	})
+
+	It("should not schedule the resync for extensions in deletion", func() {
+		ex := newExtension("shoot--foo--bar", service.ExtensionType)
+		ex.DeletionTimestamp = &metav1.Time{Time: time.Now()}
+		w.resyncHandler().Create(ctx, event.CreateEvent{Object: ex}, queue)
+		Expect(queue.added).To(BeEmpty())
+	})
+})
@@ -0,0 +1,181 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	ctrlmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+const (
+	// LabelNamespace is the label for the shoot namespace in the seed.
+	LabelNamespace = "namespace"
+	// LabelState is the label for the state of DNS entries.
+	LabelState = "state"
+	// LabelProvider is the label for the name of a DNS provider.
+	LabelProvider = "provider"
+	// LabelProviderType is the label for the type of a DNS provider.
+	LabelProviderType = "providertype"
+	// LabelEncoding is the label for the encoding of the extension state.
+	LabelEncoding = "encoding"
+	// LabelMode is the label for the controller mode of the shoot-dns-service deployment.
+	LabelMode = "mode"
+	// LabelResult is the label for the result of an operation.
+	LabelResult = "result"
+	// LabelSource is the label for the source of orphaned DNS records.
+	LabelSource = "source"
+
+	// EncodingUncompressed is the encoding label value for the uncompressed DNSState.
+	EncodingUncompressed = "uncompressed"
+	// EncodingCompressed is the encoding label value for the state stored in the extension status.
+	EncodingCompressed = "compressed"
+
+	// ResultSuccess is the result label value for a successful operation.
+	ResultSuccess = "success"
+	// ResultError is the result label value for a failed operation.
+	ResultError = "error"
+	// ResultTimeout is the result label value for an operation which did not complete in time.
+	ResultTimeout = "timeout"
+)
+
+// RegisterAll registers all metrics with the controller-runtime registry, which is served by the metrics server.
+func RegisterAll() {
+	ctrlmetrics.Registry.MustRegister(DNSEntries)
+	ctrlmetrics.Registry.MustRegister(DNSProviderReady)
+	ctrlmetrics.Registry.MustRegister(DNSEntriesDeletionWaitSeconds)
+	ctrlmetrics.Registry.MustRegister(DNSEntriesDeletionPending)
+	ctrlmetrics.Registry.MustRegister(DNSEntriesRestoreWaitSeconds)
+	ctrlmetrics.Registry.MustRegister(ExtensionStateSize)
+	ctrlmetrics.Registry.MustRegister(ExternalProviderEntriesQuotaUsed)
+	ctrlmetrics.Registry.MustRegister(ExternalProviderEntriesQuotaLimit)
+	ctrlmetrics.Registry.MustRegister(Reconciliations)
+	ctrlmetrics.Registry.MustRegister(OrphanedDNSRecords)
+	ctrlmetrics.Registry.MustRegister(OrphanedDNSRecordsDeletions)
+}
+
+var waitDurationBuckets = []float64{1, 5, 10, 20, 35, 60, 120, 300, 600, 1200, 1800}
+
+var (
+	// DNSEntries tracks the number of DNS entries per shoot namespace and state.
+	DNSEntries = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "shoot_dns_service_dns_entries",
+			Help: "Number of shoot DNS entries per shoot namespace, grouped by state",
+		},
+		[]string{LabelNamespace, LabelState},
+	)
+
+	// DNSProviderReady tracks the readiness of the managed DNS providers per shoot namespace.
+	DNSProviderReady = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "shoot_dns_service_dns_provider_ready",
+			Help: "Readiness of the managed DNS providers per shoot namespace (1 = ready, 0 = not ready)",
+		},
+		[]string{LabelNamespace, LabelProvider, LabelProviderType},
+	)
+
+	// DNSEntriesDeletionWaitSeconds tracks the time waited for the deletion of the shoot DNS entries.
+	DNSEntriesDeletionWaitSeconds = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "shoot_dns_service_dns_entries_deletion_wait_duration_seconds",
+			Help:    "Duration of waiting for the deletion of the shoot DNS entries",
+			Buckets: waitDurationBuckets,
+		},
+		[]string{LabelResult},
+	)
+
+	// DNSEntriesDeletionPending tracks the number of shoot DNS entries still existing after waiting for their deletion.
+	DNSEntriesDeletionPending = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "shoot_dns_service_dns_entries_deletion_pending",
+			Help: "Number of shoot DNS entries per shoot namespace not deleted after waiting for their deletion",
+		},
+		[]string{LabelNamespace},
+	)
+
+	// DNSEntriesRestoreWaitSeconds tracks the time waited for the reconciliation of the shoot DNS entries on restore.
+	DNSEntriesRestoreWaitSeconds = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "shoot_dns_service_dns_entries_restore_wait_duration_seconds",
+			Help:    "Duration of waiting for the reconciliation of the shoot DNS entries on restore",
+			Buckets: waitDurationBuckets,
+		},
+		[]string{LabelResult},
+	)
+
+	// ExtensionStateSize tracks the size of the extension state per shoot namespace.
+	ExtensionStateSize = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "shoot_dns_service_extension_state_size_bytes",
+			Help: "Size of the DNS state of the extension per shoot namespace, uncompressed and as stored in the extension status",
+		},
+		[]string{LabelNamespace, LabelEncoding},
+	)
+
+	// ExternalProviderEntriesQuotaUsed tracks the number of DNS entries served by the external DNS provider.
+	ExternalProviderEntriesQuotaUsed = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "shoot_dns_service_external_provider_entries_quota_used",
+			Help: "Number of DNS entries per shoot namespace served by the external DNS provider",
+		},
+		[]string{LabelNamespace},
+	)
+
+	// ExternalProviderEntriesQuotaLimit tracks the DNS entries quota of the external DNS provider.
+	ExternalProviderEntriesQuotaLimit = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "shoot_dns_service_external_provider_entries_quota_limit",
+			Help: "DNS entries quota of the external DNS provider per shoot namespace (0 = no quota)",
+		},
+		[]string{LabelNamespace},
+	)
+
+	// Reconciliations counts the reconciliations of the shoot-dns-service deployment per controller mode and result.
+	Reconciliations = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "shoot_dns_service_reconciliations_total",
+			Help: "Total reconciliations of the shoot-dns-service deployment in the shoot namespaces, grouped by controller mode and result",
+		},
+		[]string{LabelMode, LabelResult},
+	)
+
+	// OrphanedDNSRecords tracks the number of DNS records of shoots without Cluster resource found by the last
+	// garbage collection run, grouped by source.
+	OrphanedDNSRecords = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "shoot_dns_service_orphaned_dns_records",
+			Help: "Number of DNS records of shoots without Cluster resource in the seed found by the last garbage collection run, grouped by source",
+		},
+		[]string{LabelSource},
+	)
+
+	// OrphanedDNSRecordsDeletions counts the deletions of orphaned DNS records by the garbage collector per result.
+	OrphanedDNSRecordsDeletions = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "shoot_dns_service_orphaned_dns_records_deletions_total",
+			Help: "Total deletions of orphaned DNS records by the garbage collector, grouped by result",
+		},
+		[]string{LabelResult},
+	)
+)
+
+// ResultOf returns the result label value for the given error.
+func ResultOf(err error) string {
+	if err != nil {
+		return ResultError
+	}
+	return ResultSuccess
+}
+
+// DeleteShoot deletes all metrics of the given shoot namespace.
+func DeleteShoot(namespace string) {
+	labels := prometheus.Labels{LabelNamespace: namespace}
+	DNSEntries.DeletePartialMatch(labels)
+	DNSProviderReady.DeletePartialMatch(labels)
+	DNSEntriesDeletionPending.DeletePartialMatch(labels)
+	ExtensionStateSize.DeletePartialMatch(labels)
+	ExternalProviderEntriesQuotaUsed.DeletePartialMatch(labels)
+	ExternalProviderEntriesQuotaLimit.DeletePartialMatch(labels)
+}
@@ -0,0 +1,25 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package orphans
+
+import (
+	"context"
+
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+
+	"github.com/gardener/gardener-extension-shoot-dns-service/pkg/controller/config"
+)
+
+// Name is the name of the garbage collector for orphaned DNS records.
+const Name = "shoot_dns_service_orphan_gc_controller"
+
+// AddToManager adds the garbage collector for orphaned DNS records to the given manager, if it is enabled by
+// a non-zero interval.
+func AddToManager(_ context.Context, mgr manager.Manager) error {
+	if config.DNSService.OrphanGC.Interval == 0 {
+		return nil
+	}
+	return mgr.Add(NewCollector(mgr.GetClient(), mgr.GetLogger().WithName(Name), config.DNSService))
+}
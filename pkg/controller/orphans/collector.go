@@ -0,0 +1,390 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package orphans
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	dnsv1alpha1 "github.com/gardener/external-dns-management/pkg/apis/dns/v1alpha1"
+	dnsman2config "github.com/gardener/external-dns-management/pkg/dnsman2/apis/config"
+	"github.com/gardener/external-dns-management/pkg/dnsman2/dns/provider"
+	"github.com/gardener/external-dns-management/pkg/dnsman2/dns/provider/handler"
+	"github.com/gardener/external-dns-management/pkg/dnsman2/dns/utils"
+	"github.com/gardener/gardener/extensions/pkg/controller"
+	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/util/flowcontrol"
+	"k8s.io/utils/clock"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/gardener/gardener-extension-shoot-dns-service/pkg/controller/common"
+	"github.com/gardener/gardener-extension-shoot-dns-service/pkg/controller/config"
+	"github.com/gardener/gardener-extension-shoot-dns-service/pkg/controller/metrics"
+)
+
+const (
+	// SourceDNSEntry is the source of orphaned DNS records of DNS entries labeled with an unknown shoot ID.
+	SourceDNSEntry = "dnsentry"
+	// SourceInventory is the source of orphaned DNS records of inventories of leaked records of unknown shoots.
+	SourceInventory = "inventory"
+)
+
+// OrphanedRecord is a DNS record of a shoot without Cluster resource in the seed.
+type OrphanedRecord struct {
+	common.LeakedDNSRecord
+	// ShootID is the shoot ID owning the record.
+	ShootID string
+	// Source is the source of the record, i.e. SourceDNSEntry or SourceInventory.
+	Source string
+	// Owner is the key of the DNS entry or the inventory config map containing the record.
+	Owner client.ObjectKey
+}
+
+// Result is the result of a garbage collection run.
+type Result struct {
+	// Orphans are the orphaned DNS records found.
+	Orphans []OrphanedRecord
+	// Deleted is the number of deleted DNS records.
+	Deleted int
+	// Failed is the number of DNS records whose deletion failed.
+	Failed int
+	// Skipped is the number of DNS records which could not be deleted, as no DNS provider with credentials for
+	// their zone was found.
+	Skipped int
+}
+
+// NewHandlerFunc creates a DNS handler for the given provider type.
+type NewHandlerFunc func(providerType string, config *provider.DNSHandlerConfig) (provider.DNSHandler, error)
+
+// Collector periodically compares the shoot IDs owning DNS entries and inventories of leaked records in the seed
+// with the existing Cluster resources. DNS records of unknown shoots are reported and optionally deleted using the
+// credentials of a DNS provider of the seed serving their zone.
+type Collector struct {
+	client             client.Client
+	log                logr.Logger
+	config             config.OrphanGCConfig
+	inventoryNamespace string
+	clock              clock.Clock
+	newHandler         NewHandlerFunc
+}
+
+// NewCollector creates a new garbage collector for orphaned DNS records.
+func NewCollector(c client.Client, log logr.Logger, cfg config.DNSServiceConfig) *Collector {
+	return &Collector{
+		client:             c,
+		log:                log,
+		config:             cfg.OrphanGC,
+		inventoryNamespace: cfg.LeakedRecordsNamespace,
+		clock:              clock.RealClock{},
+		newHandler:         handler.CreateStandardDNSHandlerFactory(dnsman2config.DNSProviderControllerConfig{}).Create,
+	}
+}
+
+// Start runs the garbage collection periodically until the context is cancelled. It implements manager.Runnable.
+func (c *Collector) Start(ctx context.Context) error {
+	wait.JitterUntilWithContext(ctx, func(ctx context.Context) {
+		if _, err := c.Collect(ctx); err != nil {
+			c.log.Error(err, "Garbage collection of orphaned DNS records failed")
+		}
+	}, c.config.Interval, 0.1, true)
+	return nil
+}
+
+// Collect runs a single garbage collection.
+func (c *Collector) Collect(ctx context.Context) (*Result, error) {
+	knownIDs, knownNamespaces, err := c.knownShoots(ctx)
+	if err != nil {
+		return nil, err
+	}
+	isOrphaned := func(shootID, namespace string) bool {
+		return !knownNamespaces.Has(namespace) && !knownIDs.Has(shootID) && !knownIDs.Has(common.ShortenID(shootID, 63))
+	}
+
+	result := &Result{}
+	entries, err := c.orphanedEntries(ctx, isOrphaned)
+	if err != nil {
+		return nil, err
+	}
+	inventories, err := c.orphanedInventories(ctx, isOrphaned)
+	if err != nil {
+		return nil, err
+	}
+	counts := map[string]int{SourceDNSEntry: 0, SourceInventory: 0}
+	for _, entry := range entries {
+		for _, record := range common.LeakedRecordsFromEntries([]dnsv1alpha1.DNSEntry{entry}) {
+			result.Orphans = append(result.Orphans, OrphanedRecord{
+				LeakedDNSRecord: record,
+				ShootID:         entry.Labels[common.ShootDNSEntryLabelKey],
+				Source:          SourceDNSEntry,
+				Owner:           client.ObjectKeyFromObject(&entry),
+			})
+			counts[SourceDNSEntry]++
+		}
+	}
+	for _, inventory := range inventories {
+		for _, record := range inventory.Records {
+			result.Orphans = append(result.Orphans, OrphanedRecord{
+				LeakedDNSRecord: record,
+				ShootID:         inventory.ShootID,
+				Source:          SourceInventory,
+				Owner:           client.ObjectKey{Namespace: inventory.Namespace, Name: inventory.Name},
+			})
+			counts[SourceInventory]++
+		}
+	}
+	for source, count := range counts {
+		metrics.OrphanedDNSRecords.WithLabelValues(source).Set(float64(count))
+	}
+
+	for _, orphan := range result.Orphans {
+		c.log.Info("Found orphaned DNS record", "shootID", orphan.ShootID, "source", orphan.Source, "owner", orphan.Owner,
+			"dnsName", orphan.DNSName, "recordType", orphan.RecordType, "zone", orphan.Zone, "providerType", orphan.ProviderType)
+	}
+	if !c.config.Delete {
+		c.log.Info("Garbage collection of orphaned DNS records finished (dry-run)", "orphans", len(result.Orphans))
+		return result, nil
+	}
+
+	deleter, err := c.newDeleter(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer deleter.release()
+
+	for _, entry := range entries {
+		records := common.LeakedRecordsFromEntries([]dnsv1alpha1.DNSEntry{entry})
+		if remaining := deleter.deleteRecords(ctx, records, result); len(remaining) > 0 {
+			continue
+		}
+		if err := c.deleteEntry(ctx, &entry); err != nil {
+			return result, err
+		}
+	}
+	for _, inventory := range inventories {
+		remaining := deleter.deleteRecords(ctx, inventory.Records, result)
+		if len(remaining) == len(inventory.Records) {
+			continue
+		}
+		inventory.Records = remaining
+		if err := common.UpdateLeakedRecords(ctx, c.client, &inventory); err != nil {
+			return result, fmt.Errorf("failed updating inventory of leaked DNS records %s/%s: %w", inventory.Namespace, inventory.Name, err)
+		}
+	}
+	c.log.Info("Garbage collection of orphaned DNS records finished", "orphans", len(result.Orphans),
+		"deleted", result.Deleted, "failed", result.Failed, "skipped", result.Skipped)
+	return result, nil
+}
+
+// knownShoots returns the shoot IDs (full and shortened) and the namespaces of all Cluster resources.
+// The namespace of a Cluster is always known, even if the shoot or its cluster identity cannot be determined.
+func (c *Collector) knownShoots(ctx context.Context) (sets.Set[string], sets.Set[string], error) {
+	clusters := &extensionsv1alpha1.ClusterList{}
+	if err := c.client.List(ctx, clusters); err != nil {
+		return nil, nil, fmt.Errorf("failed listing clusters: %w", err)
+	}
+	ids, namespaces := sets.New[string](), sets.New[string]()
+	for _, cluster := range clusters.Items {
+		namespaces.Insert(cluster.Name)
+		shoot, err := controller.ShootFromCluster(&cluster)
+		if err != nil || shoot == nil || shoot.Status.ClusterIdentity == nil {
+			continue
+		}
+		ids.Insert(*shoot.Status.ClusterIdentity, common.ShortenID(*shoot.Status.ClusterIdentity, 63))
+	}
+	return ids, namespaces, nil
+}
+
+func (c *Collector) orphanedEntries(ctx context.Context, isOrphaned func(shootID, namespace string) bool) ([]dnsv1alpha1.DNSEntry, error) {
+	list := &dnsv1alpha1.DNSEntryList{}
+	if err := c.client.List(ctx, list, client.HasLabels{common.ShootDNSEntryLabelKey}); err != nil {
+		return nil, fmt.Errorf("failed listing DNS entries: %w", err)
+	}
+	var result []dnsv1alpha1.DNSEntry
+	for _, entry := range list.Items {
+		if c.isYoung(entry.CreationTimestamp) || !isOrphaned(entry.Labels[common.ShootDNSEntryLabelKey], entry.Namespace) {
+			continue
+		}
+		result = append(result, entry)
+	}
+	return result, nil
+}
+
+func (c *Collector) orphanedInventories(ctx context.Context, isOrphaned func(shootID, namespace string) bool) ([]common.LeakedRecordsInventory, error) {
+	if c.inventoryNamespace == "" {
+		return nil, nil
+	}
+	inventories, err := common.ListLeakedRecords(ctx, c.client, c.inventoryNamespace)
+	if err != nil {
+		return nil, fmt.Errorf("failed listing inventories of leaked DNS records: %w", err)
+	}
+	var result []common.LeakedRecordsInventory
+	for _, inventory := range inventories {
+		if c.isYoung(inventory.CreationTimestamp) || !isOrphaned(inventory.ShootID, inventory.ShootNamespace) {
+			continue
+		}
+		result = append(result, inventory)
+	}
+	return result, nil
+}
+
+// isYoung returns true if the object is younger than the grace period, as the Cluster resource may not be visible yet.
+func (c *Collector) isYoung(creationTimestamp metav1.Time) bool {
+	return c.clock.Since(creationTimestamp.Time) < c.config.GracePeriod
+}
+
+// deleteEntry deletes the DNS entry after its DNS records have been deleted by removing its finalizers.
+func (c *Collector) deleteEntry(ctx context.Context, entry *dnsv1alpha1.DNSEntry) error {
+	if err := client.IgnoreNotFound(c.client.Delete(ctx, entry)); err != nil {
+		return fmt.Errorf("failed deleting orphaned DNS entry %s/%s: %w", entry.Namespace, entry.Name, err)
+	}
+	if len(entry.Finalizers) == 0 {
+		return nil
+	}
+	patch := client.MergeFrom(entry.DeepCopy())
+	entry.SetFinalizers(nil)
+	if err := client.IgnoreNotFound(c.client.Patch(ctx, entry, patch)); err != nil {
+		return fmt.Errorf("removing finalizers for orphaned DNS entry %s/%s failed: %w", entry.Namespace, entry.Name, err)
+	}
+	return nil
+}
+
+// recordDeleter deletes DNS records using the credentials of the DNS providers in the seed.
+type recordDeleter struct {
+	collector *Collector
+	providers []dnsv1alpha1.DNSProvider
+	handlers  map[client.ObjectKey]*zonedHandler
+}
+
+type zonedHandler struct {
+	handler provider.DNSHandler
+	zones   []provider.DNSHostedZone
+	err     error
+}
+
+func (c *Collector) newDeleter(ctx context.Context) (*recordDeleter, error) {
+	list := &dnsv1alpha1.DNSProviderList{}
+	if err := c.client.List(ctx, list); err != nil {
+		return nil, fmt.Errorf("failed listing DNS providers: %w", err)
+	}
+	return &recordDeleter{
+		collector: c,
+		providers: list.Items,
+		handlers:  map[client.ObjectKey]*zonedHandler{},
+	}, nil
+}
+
+func (d *recordDeleter) release() {
+	for _, h := range d.handlers {
+		if h.handler != nil {
+			h.handler.Release()
+		}
+	}
+}
+
+// deleteRecords deletes the given DNS records and returns the records which have not been deleted.
+func (d *recordDeleter) deleteRecords(ctx context.Context, records []common.LeakedDNSRecord, result *Result) []common.LeakedDNSRecord {
+	var remaining []common.LeakedDNSRecord
+	for _, record := range records {
+		log := d.collector.log.WithValues("dnsName", record.DNSName, "recordType", record.RecordType, "zone", record.Zone)
+		h, zone := d.findHandler(ctx, record)
+		if zone == nil {
+			log.Info("Skipping orphaned DNS record, as no DNS provider with credentials for its zone was found")
+			result.Skipped++
+			remaining = append(remaining, record)
+			continue
+		}
+		if err := common.DeleteLeakedRecord(logr.NewContext(ctx, log), h, zone, record); err != nil {
+			log.Error(err, "Deletion of orphaned DNS record failed")
+			metrics.OrphanedDNSRecordsDeletions.WithLabelValues(metrics.ResultError).Inc()
+			result.Failed++
+			remaining = append(remaining, record)
+			continue
+		}
+		log.Info("Deleted orphaned DNS record")
+		metrics.OrphanedDNSRecordsDeletions.WithLabelValues(metrics.ResultSuccess).Inc()
+		result.Deleted++
+	}
+	return remaining
+}
+
+// findHandler returns the DNS handler and the hosted zone of the record using the credentials of the DNS provider
+// which created the record, or of any other ready DNS provider of the same type serving the zone.
+func (d *recordDeleter) findHandler(ctx context.Context, record common.LeakedDNSRecord) (provider.DNSHandler, provider.DNSHostedZone) {
+	candidates := slices.Clone(d.providers)
+	slices.SortStableFunc(candidates, func(a, b dnsv1alpha1.DNSProvider) int {
+		return boolToInt(providerName(&b) == record.Provider) - boolToInt(providerName(&a) == record.Provider)
+	})
+	for _, p := range candidates {
+		if p.Spec.Type != record.ProviderType || p.Status.State != dnsv1alpha1.STATE_READY || !slices.Contains(p.Status.Zones.Included, record.Zone) {
+			continue
+		}
+		h := d.getHandler(ctx, &p)
+		if h.err != nil {
+			continue
+		}
+		for _, zone := range h.zones {
+			if zone.ZoneID().ID == record.Zone {
+				return h.handler, zone
+			}
+		}
+	}
+	return nil, nil
+}
+
+func (d *recordDeleter) getHandler(ctx context.Context, p *dnsv1alpha1.DNSProvider) *zonedHandler {
+	key := client.ObjectKeyFromObject(p)
+	if h, ok := d.handlers[key]; ok {
+		return h
+	}
+	h := &zonedHandler{}
+	d.handlers[key] = h
+	log := d.collector.log.WithValues("provider", key)
+
+	if p.Spec.SecretRef == nil {
+		h.err = fmt.Errorf("missing secret reference")
+		return h
+	}
+	secretKey := client.ObjectKey{Namespace: p.Spec.SecretRef.Namespace, Name: p.Spec.SecretRef.Name}
+	if secretKey.Namespace == "" {
+		secretKey.Namespace = p.Namespace
+	}
+	secret := &corev1.Secret{}
+	if h.err = d.collector.client.Get(ctx, secretKey, secret); h.err != nil {
+		log.Error(h.err, "Failed reading secret of DNS provider")
+		return h
+	}
+	h.handler, h.err = d.collector.newHandler(p.Spec.Type, &provider.DNSHandlerConfig{
+		Log:         log,
+		Properties:  utils.NewPropertiesFromSecretData(secret.Data),
+		Config:      p.Spec.ProviderConfig,
+		Metrics:     common.NoMetrics{},
+		RateLimiter: flowcontrol.NewTokenBucketRateLimiter(1, 5),
+	})
+	if h.err != nil {
+		log.Error(h.err, "Failed creating DNS handler for DNS provider")
+		return h
+	}
+	if h.zones, h.err = h.handler.GetZones(logr.NewContext(ctx, log)); h.err != nil {
+		log.Error(h.err, "Failed getting hosted zones of DNS provider")
+	}
+	return h
+}
+
+func providerName(p *dnsv1alpha1.DNSProvider) string {
+	return p.Namespace + "/" + p.Name
+}
+
+func boolToInt(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
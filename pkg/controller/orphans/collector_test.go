@@ -0,0 +1,237 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package orphans
+
+import (
+	"context"
+	"time"
+
+	dnsv1alpha1 "github.com/gardener/external-dns-management/pkg/apis/dns/v1alpha1"
+	"github.com/gardener/external-dns-management/pkg/dnsman2/dns"
+	"github.com/gardener/external-dns-management/pkg/dnsman2/dns/provider"
+	"github.com/gardener/external-dns-management/pkg/dnsman2/dns/provider/handler/local"
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/util/flowcontrol"
+	testclock "k8s.io/utils/clock/testing"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/gardener/gardener-extension-shoot-dns-service/pkg/controller/common"
+	"github.com/gardener/gardener-extension-shoot-dns-service/pkg/controller/config"
+)
+
+var _ = Describe("Collector", func() {
+	const (
+		inventoryNamespace = "extension-shoot-dns-service"
+		account            = "orphans-test"
+		knownShootID       = "shoot--foo--bar-78897def-5208-4feb-b0f0-015950eadbb9-test-landscape"
+		orphanedShootID    = "shoot--foo--gone-1e6ab3c5-0d2f-4c5e-9a51-7d8e1f2a3b4c-test-landscape"
+	)
+
+	var (
+		ctx        context.Context
+		seedClient client.Client
+		fakeClock  *testclock.FakeClock
+		dnsHandler provider.DNSHandler
+		mock       *local.InMemory
+		zone       provider.DNSHostedZone
+		collector  *Collector
+
+		createEntry = func(namespace, name, shootID, dnsName string, age time.Duration) {
+			GinkgoHelper()
+			entry := &dnsv1alpha1.DNSEntry{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:              name,
+					Namespace:         namespace,
+					Labels:            map[string]string{common.ShootDNSEntryLabelKey: common.ShortenID(shootID, 63)},
+					Finalizers:        []string{"dns.gardener.cloud/compound"},
+					CreationTimestamp: metav1.NewTime(fakeClock.Now().Add(-age)),
+				},
+				Spec: dnsv1alpha1.DNSEntrySpec{
+					DNSName: dnsName,
+					Targets: []string{"1.2.3.4"},
+				},
+				Status: dnsv1alpha1.DNSEntryStatus{
+					State:        dnsv1alpha1.STATE_READY,
+					ProviderType: new(local.ProviderType),
+					Provider:     new("garden/local"),
+					Zone:         new(zone.ZoneID().ID),
+					DNSName:      new(dnsName),
+					TTL:          new(int64(300)),
+					Targets:      []string{"1.2.3.4"},
+				},
+			}
+			Expect(seedClient.Create(ctx, entry)).To(Succeed())
+		}
+
+		addRecord = func(dnsName string) {
+			GinkgoHelper()
+			requests := provider.NewChangeRequests(dns.DNSSetName{DNSName: dnsName})
+			requests.Updates[dns.TypeA] = &provider.ChangeRequestUpdate{
+				New: dns.NewRecordSet(dns.TypeA, 300, []*dns.Record{{Value: "1.2.3.4"}}),
+			}
+			Expect(dnsHandler.ExecuteRequests(ctx, zone, *requests)).To(Succeed())
+		}
+
+		hasRecord = func(dnsName string) bool {
+			return mock.GetRecordset(zone.ZoneID(), dns.DNSSetName{DNSName: dnsName}, dns.TypeA) != nil
+		}
+
+		entryExists = func(namespace, name string) bool {
+			GinkgoHelper()
+			err := seedClient.Get(ctx, client.ObjectKey{Namespace: namespace, Name: name}, &dnsv1alpha1.DNSEntry{})
+			if apierrors.IsNotFound(err) {
+				return false
+			}
+			Expect(err).NotTo(HaveOccurred())
+			return true
+		}
+	)
+
+	BeforeEach(func() {
+		ctx = logr.NewContext(context.Background(), GinkgoLogr)
+		fakeClock = testclock.NewFakeClock(time.Now())
+
+		scheme := runtime.NewScheme()
+		Expect(corev1.AddToScheme(scheme)).To(Succeed())
+		Expect(extensionsv1alpha1.AddToScheme(scheme)).To(Succeed())
+		Expect(dnsv1alpha1.AddToScheme(scheme)).To(Succeed())
+		seedClient = fake.NewClientBuilder().WithScheme(scheme).Build()
+
+		var err error
+		dnsHandler, err = local.NewHandler(&provider.DNSHandlerConfig{
+			Log:         GinkgoLogr,
+			Config:      &runtime.RawExtension{Raw: []byte(`{"account":"` + account + `","zones":[{"dnsName":"example.com"}]}`)},
+			Metrics:     common.NoMetrics{},
+			RateLimiter: flowcontrol.NewFakeAlwaysRateLimiter(),
+		})
+		Expect(err).NotTo(HaveOccurred())
+		DeferCleanup(dnsHandler.Release)
+		mock = local.GetInMemoryMock(account)
+		zones, err := dnsHandler.GetZones(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(zones).To(HaveLen(1))
+		zone = zones[0]
+
+		Expect(seedClient.Create(ctx, &extensionsv1alpha1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "shoot--foo--bar"},
+			Spec: extensionsv1alpha1.ClusterSpec{
+				Shoot: runtime.RawExtension{
+					Object: &gardencorev1beta1.Shoot{
+						TypeMeta:   metav1.TypeMeta{APIVersion: "core.gardener.cloud/v1beta1", Kind: "Shoot"},
+						ObjectMeta: metav1.ObjectMeta{Name: "bar", Namespace: "garden-foo"},
+						Status:     gardencorev1beta1.ShootStatus{ClusterIdentity: new(knownShootID)},
+					},
+				},
+			},
+		})).To(Succeed())
+		Expect(seedClient.Create(ctx, &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "local-credentials", Namespace: "garden"},
+		})).To(Succeed())
+		Expect(seedClient.Create(ctx, &dnsv1alpha1.DNSProvider{
+			ObjectMeta: metav1.ObjectMeta{Name: "local", Namespace: "garden"},
+			Spec: dnsv1alpha1.DNSProviderSpec{
+				Type:      local.ProviderType,
+				SecretRef: &corev1.SecretReference{Name: "local-credentials"},
+			},
+			Status: dnsv1alpha1.DNSProviderStatus{
+				State: dnsv1alpha1.STATE_READY,
+				Zones: dnsv1alpha1.DNSSelectionStatus{Included: []string{zone.ZoneID().ID}},
+			},
+		})).To(Succeed())
+
+		createEntry("shoot--foo--bar", "known", knownShootID, "known.example.com", 2*time.Hour)
+		createEntry("shoot--foo--gone", "orphan", orphanedShootID, "orphan.example.com", 2*time.Hour)
+		createEntry("shoot--foo--new", "young", "shoot--foo--new-5f0c2b9e-test-landscape", "young.example.com", time.Minute)
+		Expect(common.AddLeakedRecords(ctx, seedClient, inventoryNamespace, orphanedShootID, "shoot--foo--gone", []common.LeakedDNSRecord{{
+			Entry:        "leaked",
+			DNSName:      "leaked.example.com",
+			RecordType:   "A",
+			Targets:      []string{"1.2.3.4"},
+			TTL:          300,
+			Provider:     "shoot--foo--gone/external",
+			ProviderType: local.ProviderType,
+			Zone:         zone.ZoneID().ID,
+		}})).To(Succeed())
+		for _, dnsName := range []string{"known.example.com", "orphan.example.com", "young.example.com", "leaked.example.com"} {
+			addRecord(dnsName)
+		}
+
+		collector = NewCollector(seedClient, GinkgoLogr, config.DNSServiceConfig{
+			LeakedRecordsNamespace: inventoryNamespace,
+			OrphanGC:               config.OrphanGCConfig{Interval: time.Hour, GracePeriod: time.Hour},
+		})
+		collector.clock = fakeClock
+		collector.newHandler = func(providerType string, _ *provider.DNSHandlerConfig) (provider.DNSHandler, error) {
+			Expect(providerType).To(Equal(local.ProviderType))
+			return dnsHandler, nil
+		}
+	})
+
+	It("should only report orphaned records by default", func() {
+		result, err := collector.Collect(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.Orphans).To(ConsistOf(
+			And(HaveField("DNSName", "orphan.example.com"), HaveField("Source", SourceDNSEntry), HaveField("ShootID", common.ShortenID(orphanedShootID, 63))),
+			And(HaveField("DNSName", "leaked.example.com"), HaveField("Source", SourceInventory), HaveField("ShootID", orphanedShootID)),
+		))
+		Expect(result.Deleted).To(BeZero())
+
+		for _, dnsName := range []string{"known.example.com", "orphan.example.com", "young.example.com", "leaked.example.com"} {
+			Expect(hasRecord(dnsName)).To(BeTrue(), dnsName)
+		}
+		Expect(entryExists("shoot--foo--gone", "orphan")).To(BeTrue())
+	})
+
+	It("should delete orphaned records with the credentials of the DNS provider serving the zone", func() {
+		collector.config.Delete = true
+		result, err := collector.Collect(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.Orphans).To(HaveLen(2))
+		Expect(result.Deleted).To(Equal(2))
+		Expect(result.Failed).To(BeZero())
+
+		Expect(hasRecord("orphan.example.com")).To(BeFalse())
+		Expect(hasRecord("leaked.example.com")).To(BeFalse())
+		Expect(hasRecord("known.example.com")).To(BeTrue())
+		Expect(hasRecord("young.example.com")).To(BeTrue())
+		Expect(entryExists("shoot--foo--gone", "orphan")).To(BeFalse())
+		Expect(entryExists("shoot--foo--bar", "known")).To(BeTrue())
+		inventories, err := common.ListLeakedRecords(ctx, seedClient, inventoryNamespace)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(inventories).To(BeEmpty())
+	})
+
+	It("should consider young entries after the grace period", func() {
+		fakeClock.Step(time.Hour)
+		result, err := collector.Collect(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.Orphans).To(ContainElement(HaveField("DNSName", "young.example.com")))
+	})
+
+	It("should keep orphaned records if no DNS provider serves their zone", func() {
+		Expect(seedClient.Delete(ctx, &dnsv1alpha1.DNSProvider{ObjectMeta: metav1.ObjectMeta{Name: "local", Namespace: "garden"}})).To(Succeed())
+		collector.config.Delete = true
+		result, err := collector.Collect(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.Skipped).To(Equal(2))
+		Expect(result.Deleted).To(BeZero())
+
+		Expect(hasRecord("orphan.example.com")).To(BeTrue())
+		Expect(hasRecord("leaked.example.com")).To(BeTrue())
+		Expect(entryExists("shoot--foo--gone", "orphan")).To(BeTrue())
+		inventories, err := common.ListLeakedRecords(ctx, seedClient, inventoryNamespace)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(inventories).To(HaveLen(1))
+	})
+})
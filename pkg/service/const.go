@@ -11,6 +11,9 @@ const (
 	SeedChartName        = ServiceName + "-seed"
 	ShootChartName       = ServiceName + "-shoot"
 
+	// ExtensionLabel is the label set on shoots with the extension. It selects the shoots for the admission webhooks.
+	ExtensionLabel = "extensions.extensions.gardener.cloud/" + ExtensionType
+
 	// ImageName is the name of the dns controller manager.
 	ImageName = "dns-controller-manager"
 	// ImageNameNextGeneration is the name of the next generation dns controller manager.
@@ -20,4 +23,16 @@ const (
 	ShootAccessSecretName = "extension-shoot-dns-service"
 	// ShootAccessServiceAccountName is the name of the service account used for accessing the shoot.
 	ShootAccessServiceAccountName = ShootAccessSecretName
+
+	// DefaultExternalProviderEntriesQuotaAnnotation is the shoot annotation to request a DNS entries quota for the
+	// default external provider different from the configured default.
+	DefaultExternalProviderEntriesQuotaAnnotation = "service.dns.extensions.gardener.cloud/default-external-provider-entries-quota"
+	// DefaultExternalProviderEntriesQuotaVerifiedAnnotation is the shoot annotation set by the admission webhook to the
+	// value of the quota annotation, if the requesting user is allowed to raise the quota. It is removed on changes of
+	// the quota annotation by other users.
+	DefaultExternalProviderEntriesQuotaVerifiedAnnotation = "service.dns.extensions.gardener.cloud/default-external-provider-entries-quota-verified"
+
+	// UseNextGenerationControllerSeedLabel is the seed label to select the next generation DNS controller.
+	// The values "force-true" and "force-false" override the DNSConfig setting for all shoots in the seed.
+	UseNextGenerationControllerSeedLabel = "service.dns.extensions.gardener.cloud/use-next-generation-controller"
 )
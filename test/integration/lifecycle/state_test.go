@@ -24,6 +24,7 @@ import (
 	"github.com/go-logr/logr"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -189,42 +190,7 @@ var _ = BeforeSuite(func() {
 
 var _ = Describe("Lifecycle state tests", func() {
 	It("it should update the extension status state", func() {
-		By("creating extension")
-		ext := &extensionsv1alpha1.Extension{
-			ObjectMeta: metav1.ObjectMeta{
-				Name:      "test",
-				Namespace: testName,
-			},
-			Spec: extensionsv1alpha1.ExtensionSpec{
-				DefaultSpec: extensionsv1alpha1.DefaultSpec{
-					Type: "shoot-dns-service",
-				},
-			},
-		}
-		Expect(c.Create(ctx, ext)).To(Succeed())
-
-		By("wait for 'external' DNSProvider and patch it to Ready")
-		CEventually(ctx, func(g Gomega) error {
-			provider := &dnsv1alpha1.DNSProvider{
-				ObjectMeta: metav1.ObjectMeta{
-					Namespace: testName,
-					Name:      "external",
-				},
-			}
-			g.Expect(c.Get(ctx, client.ObjectKeyFromObject(provider), provider)).To(Succeed())
-
-			patch := client.MergeFrom(provider.DeepCopy())
-			provider.Status.State = "Ready"
-			provider.Status.LastUpdateTime = &metav1.Time{Time: time.Now()}
-			provider.Status.ObservedGeneration = provider.Generation
-			return c.Status().Patch(ctx, provider, patch)
-		}).Should(Succeed())
-
-		By("waiting for extension last operation to succeed")
-		CEventually(ctx, func() bool {
-			Expect(c.Get(ctx, client.ObjectKeyFromObject(ext), ext)).To(Succeed())
-			return ext.Status.LastOperation != nil && ext.Status.LastOperation.State == gardencorev1beta1.LastOperationStateSucceeded
-		}).WithPolling(1 * time.Second).WithTimeout(defaultTimeout).Should(BeTrue())
+		ext := createExtensionAndWaitForReconciliation()
 
 		By("check for managed resources")
 		mr := &resourcesv1alpha1.ManagedResource{}
@@ -234,60 +200,144 @@ var _ = Describe("Lifecycle state tests", func() {
 		By("check empty compressed extension state")
 		Expect(c.Get(ctx, client.ObjectKeyFromObject(ext), ext)).To(Succeed())
 		Expect(ext.Status.State).NotTo(BeNil())
-		Expect(common.LooksLikeCompressedEntriesState(ext.Status.State.Raw)).Should(BeTrue())
+		Expect(common.DetectStateFormat(ext.Status.State.Raw)).To(Equal(common.StateFormatCompressed))
 
-		state, err := common.GetExtensionState(ext)
+		state, err := common.GetExtensionState(ctx, c, ext)
 		Expect(err).NotTo(HaveOccurred())
 		Expect(state).NotTo(BeNil())
 		Expect(state.Entries).To(HaveLen(0))
 
-		By("start migration")
-		CEventually(ctx, func(g Gomega) error {
-			patch := client.MergeFrom(ext.DeepCopy())
-			if ext.Annotations == nil {
-				ext.Annotations = map[string]string{}
-			}
-			ext.Annotations[v1beta1constants.GardenerOperation] = v1beta1constants.GardenerOperationMigrate
-			return c.Patch(ctx, ext, patch)
-		}).Should(Succeed())
-
-		By("waiting for extension last operation to succeed")
-		CEventually(ctx, func() bool {
-			Expect(c.Get(ctx, client.ObjectKeyFromObject(ext), ext)).To(Succeed())
-			return ext.Status.LastOperation != nil &&
-				ext.Status.LastOperation.State == gardencorev1beta1.LastOperationStateSucceeded &&
-				ext.Status.LastOperation.Type == gardencorev1beta1.LastOperationTypeMigrate
-		}).WithPolling(1 * time.Second).WithTimeout(defaultTimeout).Should(BeTrue())
+		migrateExtension(ext)
 
 		By("check non-empty compressed extension state")
-		Expect(common.LooksLikeCompressedEntriesState(ext.Status.State.Raw)).Should(BeTrue())
+		Expect(common.DetectStateFormat(ext.Status.State.Raw)).To(Equal(common.StateFormatCompressed))
 		compressedSize := len(ext.Status.State.Raw)
 		uncompressed, err := common.DecompressEntriesState(ext.Status.State.Raw)
 		Expect(err).NotTo(HaveOccurred())
 
-		state, err = common.GetExtensionState(ext)
+		state, err = common.GetExtensionState(ctx, c, ext)
 		Expect(err).NotTo(HaveOccurred())
 		Expect(state).NotTo(BeNil())
 		Expect(state.Entries).To(HaveLen(len(entries)))
 
 		By("check uncompressed extension state")
 		ext.Status.State.Raw = uncompressed
-		state2, err := common.GetExtensionState(ext)
+		state2, err := common.GetExtensionState(ctx, c, ext)
 		Expect(err).NotTo(HaveOccurred())
 		Expect(state2).NotTo(BeNil())
 		Expect(state).To(Equal(state2))
 
 		log.Info("compressed rate", "rate", fmt.Sprintf("%.1f %%", 100.0*float32(compressedSize)/float32(len(uncompressed))))
 
-		By("deleting extension")
-		Expect(c.Delete(ctx, ext)).To(Succeed())
-		CEventually(ctx, func() bool {
-			err := c.Get(ctx, client.ObjectKeyFromObject(ext), ext)
-			return err != nil && client.IgnoreNotFound(err) == nil
-		}).WithPolling(1 * time.Second).WithTimeout(defaultTimeout).Should(BeTrue())
+		deleteExtension(ext)
+	})
+
+	It("it should store a large extension state in chunks", func() {
+		// lower the limits so that the state of the test entries exceeds the inline size
+		oldMaxInlineStateSize, oldStateChunkSize := common.MaxInlineStateSize, common.StateChunkSize
+		common.MaxInlineStateSize, common.StateChunkSize = 64, 128
+		DeferCleanup(func() {
+			common.MaxInlineStateSize, common.StateChunkSize = oldMaxInlineStateSize, oldStateChunkSize
+		})
+
+		ext := createExtensionAndWaitForReconciliation()
+		migrateExtension(ext)
+
+		By("check chunked extension state")
+		Expect(common.DetectStateFormat(ext.Status.State.Raw)).To(Equal(common.StateFormatChunked))
+		secrets := &corev1.SecretList{}
+		Expect(c.List(ctx, secrets, client.InNamespace(testName), client.MatchingLabels{common.StateChunkLabel: "true"})).To(Succeed())
+		Expect(len(secrets.Items)).To(BeNumerically(">", 1))
+		Expect(ext.Status.Resources).To(HaveLen(len(secrets.Items)))
+		for _, ref := range ext.Status.Resources {
+			Expect(secrets.Items).To(ContainElement(HaveField("Name", ref.ResourceRef.Name)))
+		}
+
+		By("check reassembled extension state")
+		state, err := common.GetExtensionState(ctx, c, ext)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(state.Entries).To(HaveLen(len(entries)))
+		for _, entry := range entries {
+			Expect(state.Entries).To(ContainElement(PointTo(MatchFields(IgnoreExtras, Fields{
+				"Name": Equal(entry.Name),
+				"Spec": PointTo(HaveField("DNSName", entry.Spec.DNSName)),
+			}))))
+		}
+
+		deleteExtension(ext)
 	})
 })
 
+func createExtensionAndWaitForReconciliation() *extensionsv1alpha1.Extension {
+	By("creating extension")
+	ext := &extensionsv1alpha1.Extension{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test",
+			Namespace: testName,
+		},
+		Spec: extensionsv1alpha1.ExtensionSpec{
+			DefaultSpec: extensionsv1alpha1.DefaultSpec{
+				Type: "shoot-dns-service",
+			},
+		},
+	}
+	Expect(c.Create(ctx, ext)).To(Succeed())
+
+	By("wait for 'external' DNSProvider and patch it to Ready")
+	CEventually(ctx, func(g Gomega) error {
+		provider := &dnsv1alpha1.DNSProvider{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: testName,
+				Name:      "external",
+			},
+		}
+		g.Expect(c.Get(ctx, client.ObjectKeyFromObject(provider), provider)).To(Succeed())
+
+		patch := client.MergeFrom(provider.DeepCopy())
+		provider.Status.State = "Ready"
+		provider.Status.LastUpdateTime = &metav1.Time{Time: time.Now()}
+		provider.Status.ObservedGeneration = provider.Generation
+		return c.Status().Patch(ctx, provider, patch)
+	}).Should(Succeed())
+
+	By("waiting for extension last operation to succeed")
+	CEventually(ctx, func() bool {
+		Expect(c.Get(ctx, client.ObjectKeyFromObject(ext), ext)).To(Succeed())
+		return ext.Status.LastOperation != nil && ext.Status.LastOperation.State == gardencorev1beta1.LastOperationStateSucceeded
+	}).WithPolling(1 * time.Second).WithTimeout(defaultTimeout).Should(BeTrue())
+
+	return ext
+}
+
+func migrateExtension(ext *extensionsv1alpha1.Extension) {
+	By("start migration")
+	CEventually(ctx, func(g Gomega) error {
+		patch := client.MergeFrom(ext.DeepCopy())
+		if ext.Annotations == nil {
+			ext.Annotations = map[string]string{}
+		}
+		ext.Annotations[v1beta1constants.GardenerOperation] = v1beta1constants.GardenerOperationMigrate
+		return c.Patch(ctx, ext, patch)
+	}).Should(Succeed())
+
+	By("waiting for extension last operation to succeed")
+	CEventually(ctx, func() bool {
+		Expect(c.Get(ctx, client.ObjectKeyFromObject(ext), ext)).To(Succeed())
+		return ext.Status.LastOperation != nil &&
+			ext.Status.LastOperation.State == gardencorev1beta1.LastOperationStateSucceeded &&
+			ext.Status.LastOperation.Type == gardencorev1beta1.LastOperationTypeMigrate
+	}).WithPolling(1 * time.Second).WithTimeout(defaultTimeout).Should(BeTrue())
+}
+
+func deleteExtension(ext *extensionsv1alpha1.Extension) {
+	By("deleting extension")
+	Expect(c.Delete(ctx, ext)).To(Succeed())
+	CEventually(ctx, func() bool {
+		err := c.Get(ctx, client.ObjectKeyFromObject(ext), ext)
+		return err != nil && client.IgnoreNotFound(err) == nil
+	}).WithPolling(1 * time.Second).WithTimeout(defaultTimeout).Should(BeTrue())
+}
+
 func setupShootEnvironment(ctx context.Context, c client.Client, namespace *corev1.Namespace, entries []*dnsv1alpha1.DNSEntry, cluster *extensionsv1alpha1.Cluster) {
 	Expect(c.Create(ctx, namespace)).To(Succeed())
 	for _, entry := range entries {
@@ -306,6 +356,7 @@ func teardownShootEnvironment(ctx context.Context, c client.Client, namespace *c
 		Expect(client.IgnoreNotFound(c.Delete(ctx, entry))).To(Succeed())
 	}
 	Expect(c.DeleteAllOf(ctx, &corev1.Secret{}, client.InNamespace(namespace.Name), client.MatchingLabels{"resources.gardener.cloud/garbage-collectable-reference": "true"})).To(Succeed())
+	Expect(c.DeleteAllOf(ctx, &corev1.Secret{}, client.InNamespace(namespace.Name), client.MatchingLabels{common.StateChunkLabel: "true"})).To(Succeed())
 	Expect(client.IgnoreNotFound(c.Delete(ctx, namespace))).To(Succeed())
 }
 